@@ -0,0 +1,101 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ackexpiry contains implementation of time-boxed acknowledgements.
+// Insights Results Aggregator stores acknowledgements without any expiration,
+// so expirations set by clients are stored by Smart Proxy and expired
+// acknowledgements are deleted by a background sweeper.
+package ackexpiry
+
+import (
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// MemoryStorage selects expirations stored in memory of the service
+	// instance
+	MemoryStorage = "memory"
+	// RedisStorage selects expirations stored in Redis, shared by all
+	// instances
+	RedisStorage = "redis"
+)
+
+// Expirations maps rule selectors of acknowledgements of one organization to
+// their expiration
+type Expirations map[types.RuleSelector]time.Time
+
+// Expired method checks if the acknowledgement of given rule has expired
+func (expirations Expirations) Expired(selector types.RuleSelector, now time.Time) bool {
+	expiresAt, found := expirations[selector]
+	return found && !expiresAt.After(now)
+}
+
+// Expiration represents expiration of one acknowledgement
+type Expiration struct {
+	OrgID        types.OrgID
+	RuleSelector types.RuleSelector
+	ExpiresAt    time.Time
+}
+
+// Store represents storage of expirations of acknowledgements
+type Store interface {
+	// Set stores expiration of the acknowledgement, previous expiration
+	// is replaced
+	Set(orgID types.OrgID, selector types.RuleSelector, expiresAt time.Time) error
+	// Delete removes expiration of the acknowledgement. Nothing happens
+	// when the acknowledgement has no expiration.
+	Delete(orgID types.OrgID, selector types.RuleSelector) error
+	// List returns expirations of acknowledgements of the organization
+	List(orgID types.OrgID) (Expirations, error)
+	// Expired returns all acknowledgements expired before given time
+	Expired(now time.Time) ([]Expiration, error)
+	// AcquireLease tries to acquire the lease for sweeping of expired
+	// acknowledgements, so only one service instance sweeps them at the
+	// same time. False is returned when the lease is held by someone
+	// else. The lease expires after given time when it is not released.
+	AcquireLease(ttl time.Duration) (bool, error)
+	// ReleaseLease releases the lease for sweeping
+	ReleaseLease() error
+}
+
+// New function constructs the expirations store selected in configuration.
+// Redis connection is used only for the Redis storage. Nil is returned
+// when time-boxed acknowledgements are disabled.
+func New(conf Configuration, connection redisV9.UniversalClient) (Store, error) {
+	if !conf.Enabled {
+		log.Info().Msg("Expiration of acknowledgements is disabled")
+		return nil, nil
+	}
+
+	switch conf.Storage {
+	case "", MemoryStorage:
+		log.Info().Msg("Using in-memory store for expiration of acknowledgements")
+		return NewMemoryStore(), nil
+	case RedisStorage:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Msg("Using Redis store for expiration of acknowledgements")
+		return NewRedisStore(connection), nil
+	default:
+		return nil, fmt.Errorf("unknown acknowledgements expiration storage '%s'", conf.Storage)
+	}
+}
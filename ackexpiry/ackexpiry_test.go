@@ -0,0 +1,168 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	testOrgID    = 42
+	testSelector = types.RuleSelector("rule.module|ERROR_KEY")
+)
+
+// TestNewStore checks that the storage selected in configuration is used
+func TestNewStore(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	store, err := ackexpiry.New(ackexpiry.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = ackexpiry.New(ackexpiry.Configuration{Enabled: true}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &ackexpiry.MemoryStore{}, store)
+
+	store, err = ackexpiry.New(ackexpiry.Configuration{Enabled: true, Storage: ackexpiry.RedisStorage}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &ackexpiry.RedisStore{}, store)
+
+	_, err = ackexpiry.New(ackexpiry.Configuration{Enabled: true, Storage: ackexpiry.RedisStorage}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = ackexpiry.New(ackexpiry.Configuration{Enabled: true, Storage: "disk"}, client.Connection)
+	assert.EqualError(t, err, "unknown acknowledgements expiration storage 'disk'")
+}
+
+// TestExpirationsExpired checks that only acknowledgements with expiration
+// in the past are expired
+func TestExpirationsExpired(t *testing.T) {
+	now := time.Now()
+	expirations := ackexpiry.Expirations{
+		"expired|KEY": now.Add(-time.Minute),
+		"active|KEY":  now.Add(time.Minute),
+	}
+
+	assert.True(t, expirations.Expired("expired|KEY", now))
+	assert.False(t, expirations.Expired("active|KEY", now))
+	assert.False(t, expirations.Expired("permanent|KEY", now))
+	assert.False(t, ackexpiry.Expirations(nil).Expired("expired|KEY", now))
+}
+
+// TestMemoryStore checks that expirations are stored per organization and
+// expired ones can be found
+func TestMemoryStore(t *testing.T) {
+	store := ackexpiry.NewMemoryStore()
+	now := time.Now()
+	expiresAt := now.Add(-time.Minute)
+
+	assert.NoError(t, store.Set(testOrgID, testSelector, now.Add(time.Hour)))
+	assert.NoError(t, store.Set(testOrgID, testSelector, expiresAt))
+	assert.NoError(t, store.Set(testOrgID, "other.module|KEY", now.Add(time.Hour)))
+	assert.NoError(t, store.Set(testOrgID+1, testSelector, now.Add(time.Hour)))
+
+	expirations, err := store.List(testOrgID)
+	assert.NoError(t, err)
+	assert.Len(t, expirations, 2)
+	assert.Equal(t, expiresAt, expirations[testSelector])
+
+	expired, err := store.Expired(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []ackexpiry.Expiration{
+		{OrgID: testOrgID, RuleSelector: testSelector, ExpiresAt: expiresAt},
+	}, expired)
+
+	assert.NoError(t, store.Delete(testOrgID, testSelector))
+	assert.NoError(t, store.Delete(testOrgID, "missing|KEY"))
+
+	expired, err = store.Expired(now)
+	assert.NoError(t, err)
+	assert.Empty(t, expired)
+
+	expirations, err = store.List(testOrgID + 2)
+	assert.NoError(t, err)
+	assert.Empty(t, expirations)
+}
+
+// TestSweep checks that expired acknowledgements are deleted together with
+// their expirations and failed deletions are retried later
+func TestSweep(t *testing.T) {
+	store := ackexpiry.NewMemoryStore()
+	now := time.Now()
+	helpers.FailOnError(t, store.Set(testOrgID, testSelector, now.Add(-time.Minute)))
+	helpers.FailOnError(t, store.Set(testOrgID, "failing.module|KEY", now.Add(-time.Minute)))
+	helpers.FailOnError(t, store.Set(testOrgID, "active.module|KEY", now.Add(time.Hour)))
+
+	var deleted []types.RuleSelector
+	deleteAck := func(_ context.Context, orgID types.OrgID, selector types.RuleSelector) error {
+		assert.Equal(t, types.OrgID(testOrgID), orgID)
+		deleted = append(deleted, selector)
+		if selector == "failing.module|KEY" {
+			return errors.New("aggregator is not available")
+		}
+		return nil
+	}
+
+	sweeper := ackexpiry.NewSweeper(ackexpiry.Configuration{}, store, deleteAck)
+	sweeper.Sweep(context.Background())
+
+	assert.ElementsMatch(t, []types.RuleSelector{testSelector, "failing.module|KEY"}, deleted)
+
+	expirations, err := store.List(testOrgID)
+	assert.NoError(t, err)
+	assert.Len(t, expirations, 2)
+	assert.Contains(t, expirations, types.RuleSelector("failing.module|KEY"))
+	assert.Contains(t, expirations, types.RuleSelector("active.module|KEY"))
+}
+
+// TestSweepSkippedWithoutLease checks that expired acknowledgements are not
+// deleted by instance that does not hold the sweep lease
+func TestSweepSkippedWithoutLease(t *testing.T) {
+	store := ackexpiry.NewMemoryStore()
+	helpers.FailOnError(t, store.Set(testOrgID, testSelector, time.Now().Add(-time.Minute)))
+
+	deleted := 0
+	deleteAck := func(context.Context, types.OrgID, types.RuleSelector) error {
+		deleted++
+		return nil
+	}
+	sweeper := ackexpiry.NewSweeper(ackexpiry.Configuration{}, store, deleteAck)
+
+	leased, err := store.AcquireLease(time.Minute)
+	helpers.FailOnError(t, err)
+	assert.True(t, leased)
+
+	sweeper.Sweep(context.Background())
+	assert.Equal(t, 0, deleted)
+
+	helpers.FailOnError(t, store.ReleaseLease())
+	sweeper.Sweep(context.Background())
+	assert.Equal(t, 1, deleted)
+
+	// the lease is released after the sweep
+	leased, err = store.AcquireLease(time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry
+
+import (
+	"time"
+)
+
+// Configuration represents the configuration of time-boxed acknowledgements
+type Configuration struct {
+	// Enabled allows clients to set expiration of acknowledgements and
+	// turns on the background sweeper deleting expired acknowledgements
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Storage selects where expirations are stored: "memory" (useful for
+	// development only) or "redis"
+	Storage string `mapstructure:"storage" toml:"storage"`
+	// SweepInterval is the time between two runs of the sweeper
+	SweepInterval time.Duration `mapstructure:"sweep_interval" toml:"sweep_interval"`
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// MemoryStore is a Store implementation that keeps expirations in memory of
+// the service instance
+type MemoryStore struct {
+	mutex       sync.RWMutex
+	expirations map[types.OrgID]Expirations
+	leasedUntil time.Time
+}
+
+// NewMemoryStore function constructs new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		expirations: make(map[types.OrgID]Expirations),
+	}
+}
+
+// Set method stores expiration of the acknowledgement
+func (store *MemoryStore) Set(orgID types.OrgID, selector types.RuleSelector, expiresAt time.Time) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	orgExpirations, found := store.expirations[orgID]
+	if !found {
+		orgExpirations = make(Expirations)
+		store.expirations[orgID] = orgExpirations
+	}
+	orgExpirations[selector] = expiresAt
+
+	return nil
+}
+
+// Delete method removes expiration of the acknowledgement
+func (store *MemoryStore) Delete(orgID types.OrgID, selector types.RuleSelector) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.expirations[orgID], selector)
+	if len(store.expirations[orgID]) == 0 {
+		delete(store.expirations, orgID)
+	}
+
+	return nil
+}
+
+// List method returns expirations of acknowledgements of the organization
+func (store *MemoryStore) List(orgID types.OrgID) (Expirations, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	expirations := make(Expirations, len(store.expirations[orgID]))
+	for selector, expiresAt := range store.expirations[orgID] {
+		expirations[selector] = expiresAt
+	}
+
+	return expirations, nil
+}
+
+// Expired method returns all acknowledgements expired before given time
+func (store *MemoryStore) Expired(now time.Time) ([]Expiration, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	expired := make([]Expiration, 0)
+	for orgID, orgExpirations := range store.expirations {
+		for selector, expiresAt := range orgExpirations {
+			if orgExpirations.Expired(selector, now) {
+				expired = append(expired, Expiration{
+					OrgID:        orgID,
+					RuleSelector: selector,
+					ExpiresAt:    expiresAt,
+				})
+			}
+		}
+	}
+
+	return expired, nil
+}
+
+// AcquireLease method acquires the lease for sweeping when it is not held or
+// when it has expired
+func (store *MemoryStore) AcquireLease(ttl time.Duration) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(store.leasedUntil) {
+		return false, nil
+	}
+	store.leasedUntil = now.Add(ttl)
+	return true, nil
+}
+
+// ReleaseLease method releases the lease for sweeping
+func (store *MemoryStore) ReleaseLease() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.leasedUntil = time.Time{}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// RedisExpirationsKey is a key pattern for hash with expirations of
+	// acknowledgements of one organization. Organization ID is used as a
+	// parameter. Rule selectors are used as fields of the hash.
+	RedisExpirationsKey = "smart-proxy:ack-expiry:%v"
+	// RedisExpirationsIndexKey is a key of sorted set with expirations of
+	// all organizations. Members are in "org ID:rule selector" format,
+	// scores are expiration times, so expired acknowledgements can be
+	// found without reading all hashes.
+	RedisExpirationsIndexKey = "smart-proxy:ack-expiry"
+	// RedisSweepLeaseKey is a key of lease that prevents concurrent sweeps
+	// of expired acknowledgements by multiple replicas
+	RedisSweepLeaseKey = "smart-proxy:ack-expiry:sweep-lease"
+)
+
+// RedisStore is a Store implementation that keeps expirations in Redis
+type RedisStore struct {
+	connection redisV9.Cmdable
+}
+
+// NewRedisStore function constructs new Redis store
+func NewRedisStore(connection redisV9.Cmdable) *RedisStore {
+	return &RedisStore{
+		connection: connection,
+	}
+}
+
+// indexMember function returns member of the expirations index for given
+// acknowledgement
+func indexMember(orgID types.OrgID, selector types.RuleSelector) string {
+	return fmt.Sprintf("%v:%v", orgID, selector)
+}
+
+// Set method stores expiration into the hash of the organization and into
+// the index
+func (store *RedisStore) Set(orgID types.OrgID, selector types.RuleSelector, expiresAt time.Time) error {
+	ctx := context.Background()
+
+	_, err := store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.HSet(ctx, fmt.Sprintf(RedisExpirationsKey, orgID), string(selector), expiresAt.UTC().Format(time.RFC3339))
+		pipe.ZAdd(ctx, RedisExpirationsIndexKey, redisV9.Z{
+			Score:  float64(expiresAt.Unix()),
+			Member: indexMember(orgID, selector),
+		})
+		return nil
+	})
+	return err
+}
+
+// Delete method removes expiration from the hash of the organization and
+// from the index
+func (store *RedisStore) Delete(orgID types.OrgID, selector types.RuleSelector) error {
+	ctx := context.Background()
+
+	_, err := store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.HDel(ctx, fmt.Sprintf(RedisExpirationsKey, orgID), string(selector))
+		pipe.ZRem(ctx, RedisExpirationsIndexKey, indexMember(orgID, selector))
+		return nil
+	})
+	return err
+}
+
+// List method returns expirations of acknowledgements of the organization
+func (store *RedisStore) List(orgID types.OrgID) (Expirations, error) {
+	values, err := store.connection.HGetAll(context.Background(), fmt.Sprintf(RedisExpirationsKey, orgID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	expirations := make(Expirations, len(values))
+	for selector, value := range values {
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.Error().Err(err).Str("ruleSelector", selector).Msg("Unable to parse acknowledgement expiration stored in Redis")
+			continue
+		}
+		expirations[types.RuleSelector(selector)] = expiresAt
+	}
+
+	return expirations, nil
+}
+
+// Expired method returns all acknowledgements expired before given time
+func (store *RedisStore) Expired(now time.Time) ([]Expiration, error) {
+	members, err := store.connection.ZRangeByScoreWithScores(context.Background(), RedisExpirationsIndexKey, &redisV9.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	expired := make([]Expiration, 0, len(members))
+	for _, member := range members {
+		value, _ := member.Member.(string)
+		orgIDStr, selector, found := strings.Cut(value, ":")
+		orgID, err := strconv.ParseUint(orgIDStr, 10, 32)
+		if !found || err != nil {
+			log.Error().Str("member", value).Msg("Improper member of acknowledgements expiration index")
+			continue
+		}
+
+		expired = append(expired, Expiration{
+			OrgID:        types.OrgID(orgID),
+			RuleSelector: types.RuleSelector(selector),
+			ExpiresAt:    time.Unix(int64(member.Score), 0).UTC(),
+		})
+	}
+
+	return expired, nil
+}
+
+// AcquireLease method acquires the lease for sweeping. The lease is stored
+// in Redis, so it is shared by all replicas.
+func (store *RedisStore) AcquireLease(ttl time.Duration) (bool, error) {
+	return store.connection.SetNX(context.Background(), RedisSweepLeaseKey, 1, ttl).Result()
+}
+
+// ReleaseLease method releases the lease for sweeping
+func (store *RedisStore) ReleaseLease() error {
+	return store.connection.Del(context.Background(), RedisSweepLeaseKey).Err()
+}
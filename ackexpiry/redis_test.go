@@ -0,0 +1,127 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+var testKey = fmt.Sprintf(ackexpiry.RedisExpirationsKey, testOrgID)
+
+// TestRedisStoreSetAndDelete checks that expiration is stored into the hash
+// of the organization and into the index
+func TestRedisStoreSetAndDelete(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := ackexpiry.NewRedisStore(client)
+	expiresAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	member := fmt.Sprintf("%v:%v", testOrgID, testSelector)
+
+	server.ExpectTxPipeline()
+	server.ExpectHSet(testKey, string(testSelector), "2026-01-02T10:00:00Z").SetVal(1)
+	server.ExpectZAdd(ackexpiry.RedisExpirationsIndexKey, redisV9.Z{
+		Score:  float64(expiresAt.Unix()),
+		Member: member,
+	}).SetVal(1)
+	server.ExpectTxPipelineExec()
+	assert.NoError(t, store.Set(testOrgID, testSelector, expiresAt))
+
+	server.ExpectTxPipeline()
+	server.ExpectHDel(testKey, string(testSelector)).SetVal(1)
+	server.ExpectZRem(ackexpiry.RedisExpirationsIndexKey, member).SetVal(1)
+	server.ExpectTxPipelineExec()
+	assert.NoError(t, store.Delete(testOrgID, testSelector))
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestRedisStoreList checks that expirations are read from the hash of the
+// organization
+func TestRedisStoreList(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := ackexpiry.NewRedisStore(client)
+
+	server.ExpectHGetAll(testKey).SetVal(map[string]string{
+		string(testSelector): "2026-01-02T10:00:00Z",
+		"broken.module|KEY":  "not a time",
+	})
+	expirations, err := store.List(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, ackexpiry.Expirations{
+		testSelector: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+	}, expirations)
+
+	server.ExpectHGetAll(testKey).SetErr(errors.New("connection refused"))
+	_, err = store.List(testOrgID)
+	assert.Error(t, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestRedisStoreExpired checks that expired acknowledgements are read from
+// the index
+func TestRedisStoreExpired(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := ackexpiry.NewRedisStore(client)
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(-time.Hour)
+	rangeBy := &redisV9.ZRangeBy{Min: "-inf", Max: fmt.Sprint(now.Unix())}
+
+	server.ExpectZRangeByScoreWithScores(ackexpiry.RedisExpirationsIndexKey, rangeBy).SetVal([]redisV9.Z{
+		{Score: float64(expiresAt.Unix()), Member: fmt.Sprintf("%v:%v", testOrgID, testSelector)},
+		{Score: float64(expiresAt.Unix()), Member: "not an org:rule|KEY"},
+		{Score: float64(expiresAt.Unix()), Member: "broken"},
+	})
+	expired, err := store.Expired(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []ackexpiry.Expiration{
+		{OrgID: testOrgID, RuleSelector: testSelector, ExpiresAt: expiresAt},
+	}, expired)
+
+	server.ExpectZRangeByScoreWithScores(ackexpiry.RedisExpirationsIndexKey, rangeBy).SetErr(errors.New("connection refused"))
+	_, err = store.Expired(now)
+	assert.Error(t, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisStoreLease(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := ackexpiry.NewRedisStore(client)
+
+	server.ExpectSetNX(ackexpiry.RedisSweepLeaseKey, 1, time.Minute).SetVal(true)
+	leased, err := store.AcquireLease(time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+
+	server.ExpectSetNX(ackexpiry.RedisSweepLeaseKey, 1, time.Minute).SetVal(false)
+	leased, err = store.AcquireLease(time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, leased)
+
+	server.ExpectDel(ackexpiry.RedisSweepLeaseKey).SetVal(1)
+	assert.NoError(t, store.ReleaseLease())
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ackexpiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// defaultSweepInterval is used when the sweep interval is not configured
+const defaultSweepInterval = time.Minute
+
+// DeleteAck deletes the acknowledgement of the rule for the organization
+type DeleteAck func(ctx context.Context, orgID types.OrgID, selector types.RuleSelector) error
+
+// Sweeper periodically deletes acknowledgements that have expired
+type Sweeper struct {
+	store     Store
+	deleteAck DeleteAck
+	interval  time.Duration
+}
+
+// NewSweeper function constructs the sweeper
+func NewSweeper(conf Configuration, store Store, deleteAck DeleteAck) *Sweeper {
+	interval := conf.SweepInterval
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+
+	return &Sweeper{
+		store:     store,
+		deleteAck: deleteAck,
+		interval:  interval,
+	}
+}
+
+// Run method deletes expired acknowledgements periodically until the stop
+// channel is closed
+func (sweeper *Sweeper) Run(stopChannel <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopChannel
+		cancel()
+	}()
+
+	ticker := time.NewTicker(sweeper.interval)
+	defer ticker.Stop()
+	log.Info().Msgf("Sweeping expired acknowledgements each %v", sweeper.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Acknowledgements sweeper loop stopped")
+			return
+		case <-ticker.C:
+			sweeper.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep method deletes all acknowledgements that have expired. Expiration is
+// removed only when the acknowledgement has been deleted, so failed
+// deletions are retried by the next sweep. Instances that don't hold the
+// lease skip the sweep, so each acknowledgement is deleted (and audited)
+// once.
+func (sweeper *Sweeper) Sweep(ctx context.Context) {
+	// the lease is held at most for one interval, so sweep that can't
+	// release it does not block sweeps for longer than that
+	leased, err := sweeper.store.AcquireLease(sweeper.interval)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to acquire acknowledgements sweep lease")
+		return
+	}
+	if !leased {
+		log.Debug().Msg("Expired acknowledgements are swept by another instance")
+		return
+	}
+	defer func() {
+		if err := sweeper.store.ReleaseLease(); err != nil {
+			log.Error().Err(err).Msg("Unable to release acknowledgements sweep lease")
+		}
+	}()
+
+	expired, err := sweeper.store.Expired(time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to read expired acknowledgements")
+		return
+	}
+
+	for _, expiration := range expired {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger := log.With().
+			Uint32("orgID", uint32(expiration.OrgID)).
+			Str("ruleSelector", string(expiration.RuleSelector)).
+			Logger()
+
+		if err := sweeper.deleteAck(ctx, expiration.OrgID, expiration.RuleSelector); err != nil {
+			logger.Error().Err(err).Msg("Unable to delete expired acknowledgement")
+			continue
+		}
+		if err := sweeper.store.Delete(expiration.OrgID, expiration.RuleSelector); err != nil {
+			logger.Error().Err(err).Msg("Unable to delete expiration of acknowledgement")
+			continue
+		}
+		logger.Info().Time("expiresAt", expiration.ExpiresAt).Msg("Expired acknowledgement deleted")
+	}
+}
@@ -17,6 +17,7 @@
 package amsclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -29,6 +30,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	utypes "github.com/RedHatInsights/insights-operator-utils/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
 )
 
@@ -36,6 +38,10 @@ const (
 	// defaultPageSize is the page size used when it is not defined in the configuration
 	defaultPageSize = 500
 
+	// healthCheckPath is the path of AMS API metadata used to check that
+	// the service is available
+	healthCheckPath = "/api/accounts_mgmt/v1"
+
 	// strings for logging and errors
 	orgNoInternalID              = "organization doesn't have proper internal ID"
 	orgMoreInternalOrgs          = "more than one internal organization for the given orgID"
@@ -73,6 +79,7 @@ type AMSClient interface {
 	GetSingleClusterInfoForOrganization(types.OrgID, types.ClusterName) (
 		types.ClusterInfo, error,
 	)
+	HealthCheck(context.Context) error
 }
 
 // amsClientImpl is an implementation of the AMSClient interface
@@ -95,6 +102,11 @@ func NewAMSClientWithTransport(conf Configuration, transport http.RoundTripper)
 
 	if transport != nil {
 		builder.TransportWrapper(func(http.RoundTripper) http.RoundTripper { return transport })
+	} else {
+		// use retries and circuit breaker configured for AMS
+		builder.TransportWrapper(func(next http.RoundTripper) http.RoundTripper {
+			return httpclient.UpstreamTransport(httpclient.AMSUpstream).Wrap(next)
+		})
 	}
 
 	if conf.ClientID != "" && conf.ClientSecret != "" {
@@ -346,3 +358,18 @@ func (c *amsClientImpl) executeSubscriptionListRequest(
 
 	return
 }
+
+// HealthCheck method checks if AMS API is responding by reading its metadata.
+// Any response except server errors means the service is available.
+func (c *amsClientImpl) HealthCheck(ctx context.Context) error {
+	response, err := c.connection.Get().Path(healthCheckPath).SendContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if response.Status() >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code %d", response.Status())
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amsclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// DefaultClusterListCacheTTL is the time for which the cached cluster
+	// list is considered fresh when it is not configured
+	DefaultClusterListCacheTTL = 5 * time.Minute
+	// DefaultClusterListStaleTTL is the time for which the expired cluster
+	// list can still be served when it is not configured
+	DefaultClusterListStaleTTL = 1 * time.Hour
+
+	// ClusterListCacheKey is a key pattern for cluster lists stored in
+	// Redis. Organization ID is used as parameter.
+	ClusterListCacheKey = "smart-proxy:ams:organization:%v:clusters"
+	// ClusterListRefreshLockKey is a key pattern for lock that prevents
+	// concurrent refreshes of the same cluster list by multiple replicas
+	ClusterListRefreshLockKey = "smart-proxy:ams:organization:%v:refresh"
+
+	// refreshLockTimeout is the maximum time the refresh lock is held
+	refreshLockTimeout = 30 * time.Second
+
+	// results reported by cluster list cache metric
+	cacheResultHit   = "hit"
+	cacheResultStale = "stale"
+	cacheResultMiss  = "miss"
+)
+
+// releaseLockScript deletes the refresh lock only when it still holds the
+// token of the replica releasing it. The lock might have expired and been
+// acquired by another replica in the meantime.
+var releaseLockScript = redisV9.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// cachedClusterList represents cluster list stored in Redis together with
+// the time it was retrieved from AMS
+type cachedClusterList struct {
+	Clusters  []types.ClusterInfo `json:"clusters"`
+	FetchedAt time.Time           `json:"fetched_at"`
+}
+
+// cachingAMSClient is an AMSClient that stores cluster lists for
+// organizations in Redis. Expired lists are served while they are being
+// refreshed in background, and they are served also when AMS is not
+// available, until the stale TTL elapses.
+type cachingAMSClient struct {
+	AMSClient
+	connection redisV9.Cmdable
+	ttl        time.Duration
+	staleTTL   time.Duration
+	refreshes  *sync.WaitGroup
+}
+
+// NewCachingAMSClient function wraps the AMS client so cluster lists for
+// organizations are cached in Redis. Background refreshes are tracked by
+// the given wait group, so the Redis connection can be closed after they
+// finish.
+func NewCachingAMSClient(
+	client AMSClient, connection redisV9.Cmdable, conf Configuration, refreshes *sync.WaitGroup,
+) AMSClient {
+	ttl := conf.ClusterListCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultClusterListCacheTTL
+	}
+	staleTTL := conf.ClusterListStaleTTL
+	if staleTTL <= 0 {
+		staleTTL = DefaultClusterListStaleTTL
+	}
+
+	log.Info().Dur("TTL", ttl).Dur("staleTTL", staleTTL).Msg("Caching cluster lists from AMS in Redis")
+	return &cachingAMSClient{
+		AMSClient:  client,
+		connection: connection,
+		ttl:        ttl,
+		staleTTL:   staleTTL,
+		refreshes:  refreshes,
+	}
+}
+
+// GetClustersForOrganization method returns cluster list from the cache.
+// Only requests with default filters are cached, the others are always
+// sent to AMS.
+func (c *cachingAMSClient) GetClustersForOrganization(orgID types.OrgID, statusFilter, statusNegativeFilter []string) (
+	[]types.ClusterInfo, error,
+) {
+	if statusFilter != nil || statusNegativeFilter != nil {
+		return c.AMSClient.GetClustersForOrganization(orgID, statusFilter, statusNegativeFilter)
+	}
+
+	cached, found := c.readClusterList(orgID)
+	if !found {
+		metrics.AMSClusterListCache.WithLabelValues(cacheResultMiss).Inc()
+		return c.refreshClusterList(orgID)
+	}
+
+	if time.Since(cached.FetchedAt) < c.ttl {
+		metrics.AMSClusterListCache.WithLabelValues(cacheResultHit).Inc()
+		return cached.Clusters, nil
+	}
+
+	metrics.AMSClusterListCache.WithLabelValues(cacheResultStale).Inc()
+	log.Debug().Uint32(orgIDTag, uint32(orgID)).Time("fetchedAt", cached.FetchedAt).Msg("Serving stale cluster list")
+	c.refreshes.Add(1)
+	go func() {
+		defer c.refreshes.Done()
+		c.refreshClusterListInBackground(orgID)
+	}()
+
+	return cached.Clusters, nil
+}
+
+// readClusterList method reads cluster list for the organization from
+// Redis. Any Redis error is logged and reported as a cache miss.
+func (c *cachingAMSClient) readClusterList(orgID types.OrgID) (cachedClusterList, bool) {
+	var cached cachedClusterList
+
+	value, err := c.connection.Get(context.Background(), fmt.Sprintf(ClusterListCacheKey, orgID)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redisV9.Nil) {
+			log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to read cluster list from Redis")
+		}
+		return cached, false
+	}
+
+	if err := json.Unmarshal(value, &cached); err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to decode cluster list stored in Redis")
+		return cached, false
+	}
+
+	return cached, true
+}
+
+// refreshClusterList method retrieves cluster list for the organization
+// from AMS and stores it in Redis
+func (c *cachingAMSClient) refreshClusterList(orgID types.OrgID) ([]types.ClusterInfo, error) {
+	clusters, err := c.AMSClient.GetClustersForOrganization(orgID, nil, nil)
+	if err != nil {
+		return clusters, err
+	}
+
+	value, err := json.Marshal(cachedClusterList{
+		Clusters:  clusters,
+		FetchedAt: time.Now(),
+	})
+	if err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to encode cluster list")
+		return clusters, nil
+	}
+
+	// the list is kept in Redis until it is too old to be served even as stale
+	err = c.connection.Set(context.Background(), fmt.Sprintf(ClusterListCacheKey, orgID), value, c.ttl+c.staleTTL).Err()
+	if err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to store cluster list into Redis")
+	}
+
+	return clusters, nil
+}
+
+// refreshClusterListInBackground method refreshes expired cluster list. The
+// lock stored in Redis ensures that only one replica refreshes the list at
+// the same time. When AMS is not available, the stale list is kept.
+func (c *cachingAMSClient) refreshClusterListInBackground(orgID types.OrgID) {
+	ctx := context.Background()
+	lockKey := fmt.Sprintf(ClusterListRefreshLockKey, orgID)
+	token := uuid.NewString()
+
+	locked, err := c.connection.SetNX(ctx, lockKey, token, refreshLockTimeout).Result()
+	if err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to acquire cluster list refresh lock")
+		return
+	}
+	if !locked {
+		log.Debug().Uint32(orgIDTag, uint32(orgID)).Msg("Cluster list is already being refreshed")
+		return
+	}
+
+	if _, err := c.refreshClusterList(orgID); err != nil {
+		log.Warn().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to refresh cluster list, stale list is kept")
+	}
+
+	if err := releaseLockScript.Run(ctx, c.connection, []string{lockKey}, token).Err(); err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to release cluster list refresh lock")
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amsclient_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/amsclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+var (
+	cacheConf = amsclient.Configuration{
+		ClusterListCaching:  true,
+		ClusterListCacheTTL: 5 * time.Minute,
+		ClusterListStaleTTL: time.Hour,
+	}
+	clusterListKey = fmt.Sprintf(amsclient.ClusterListCacheKey, testdata.OrgID)
+	refreshLockKey = fmt.Sprintf(amsclient.ClusterListRefreshLockKey, testdata.OrgID)
+
+	cachedClusters = []types.ClusterInfo{
+		{ID: testdata.ClusterName1, DisplayName: "cluster1", Managed: false, Status: "Active"},
+	}
+	amsClusters = []types.ClusterInfo{
+		{ID: testdata.ClusterName2, DisplayName: "cluster2", Managed: true, Status: "Active"},
+	}
+)
+
+// countingAMSClient is AMS client returning predefined results and counting
+// number of requests for cluster list
+type countingAMSClient struct {
+	amsclient.AMSClient
+	mutex    sync.Mutex
+	clusters []types.ClusterInfo
+	err      error
+	calls    int
+}
+
+func (c *countingAMSClient) GetClustersForOrganization(types.OrgID, []string, []string) ([]types.ClusterInfo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls++
+	return c.clusters, c.err
+}
+
+func (c *countingAMSClient) Calls() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.calls
+}
+
+// cachedClusterListValue returns cluster list as it is stored in Redis
+func cachedClusterListValue(fetchedAt time.Time) string {
+	return fmt.Sprintf(
+		`{"clusters":[{"cluster_id":"%v","display_name":"cluster1","managed":false,"status":"Active"}],"fetched_at":"%v"}`,
+		testdata.ClusterName1, fetchedAt.Format(time.RFC3339Nano),
+	)
+}
+
+// expectClusterListStored sets expectation for storing cluster list
+// retrieved from AMS
+func expectClusterListStored(server redismock.ClientMock) {
+	server.CustomMatch(func(expected, actual []interface{}) error {
+		if len(actual) != len(expected) || actual[1] != expected[1] || actual[4] != expected[4] {
+			return fmt.Errorf("unexpected command %v", actual)
+		}
+		value, ok := actual[2].([]byte)
+		if !ok || !strings.Contains(string(value), string(testdata.ClusterName2)) {
+			return fmt.Errorf("unexpected cluster list %v", actual[2])
+		}
+		return nil
+	}).ExpectSet(clusterListKey, "", time.Hour+5*time.Minute).SetVal("OK")
+}
+
+// expectRefreshLock expects the refresh lock to be acquired with random
+// token. The returned function expects the lock to be released with the
+// same token.
+func expectRefreshLock(server redismock.ClientMock, acquired bool) func() {
+	var token interface{}
+	server.CustomMatch(func(expected, actual []interface{}) error {
+		if len(actual) != len(expected) || actual[1] != expected[1] || actual[3] != expected[3] ||
+			actual[4] != expected[4] || actual[5] != expected[5] {
+			return fmt.Errorf("unexpected command %v", actual)
+		}
+		token = actual[2]
+		return nil
+	}).ExpectSetNX(refreshLockKey, "", 30*time.Second).SetVal(acquired)
+
+	return func() {
+		server.CustomMatch(func(expected, actual []interface{}) error {
+			if len(actual) != len(expected) || actual[1] != expected[1] || actual[3] != expected[3] {
+				return fmt.Errorf("unexpected command %v", actual)
+			}
+			if actual[4] != token {
+				return fmt.Errorf("lock released with token %v instead of %v", actual[4], token)
+			}
+			return nil
+		}).ExpectEvalSha(amsclient.ReleaseLockScript.Hash(), []string{refreshLockKey}, "").SetVal(int64(1))
+	}
+}
+
+func TestCachingAMSClientMiss(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{clusters: amsClusters}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).RedisNil()
+	expectClusterListStored(server)
+
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, amsClusters, clusters)
+	assert.Equal(t, 1, client.Calls())
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestCachingAMSClientMissAMSError(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{err: errors.New("AMS is not available")}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).RedisNil()
+
+	_, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	assert.EqualError(t, err, "AMS is not available")
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestCachingAMSClientHit(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{clusters: amsClusters}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).SetVal(cachedClusterListValue(time.Now()))
+
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, cachedClusters, clusters)
+	assert.Equal(t, 0, client.Calls())
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestCachingAMSClientStaleWhileRevalidate(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{clusters: amsClusters}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).SetVal(cachedClusterListValue(time.Now().Add(-10 * time.Minute)))
+	releaseLock := expectRefreshLock(server, true)
+	expectClusterListStored(server)
+	releaseLock()
+
+	// stale list is returned immediately
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, cachedClusters, clusters)
+
+	// the background refresh is tracked by the wait group
+	refreshes.Wait()
+	helpers.RedisExpectationsMet(t, server)
+	assert.Equal(t, 1, client.Calls())
+}
+
+func TestCachingAMSClientStaleOnAMSError(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{err: errors.New("AMS is not available")}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).SetVal(cachedClusterListValue(time.Now().Add(-10 * time.Minute)))
+	releaseLock := expectRefreshLock(server, true)
+	// stale list is not overwritten
+	releaseLock()
+
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, cachedClusters, clusters)
+
+	// the background refresh is tracked by the wait group
+	refreshes.Wait()
+	helpers.RedisExpectationsMet(t, server)
+	assert.Equal(t, 1, client.Calls())
+}
+
+func TestCachingAMSClientRefreshLocked(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{clusters: amsClusters}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	server.ExpectGet(clusterListKey).SetVal(cachedClusterListValue(time.Now().Add(-10 * time.Minute)))
+	// other replica is refreshing the list
+	expectRefreshLock(server, false)
+
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, nil, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, cachedClusters, clusters)
+
+	// the background refresh is tracked by the wait group
+	refreshes.Wait()
+	helpers.RedisExpectationsMet(t, server)
+	assert.Equal(t, 0, client.Calls())
+}
+
+func TestCachingAMSClientFiltersNotCached(t *testing.T) {
+	connection, server := redismock.NewClientMock()
+	client := &countingAMSClient{clusters: amsClusters}
+	var refreshes sync.WaitGroup
+	cachingClient := amsclient.NewCachingAMSClient(client, connection, cacheConf, &refreshes)
+
+	clusters, err := cachingClient.GetClustersForOrganization(testdata.OrgID, []string{"Active"}, nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, amsClusters, clusters)
+	assert.Equal(t, 1, client.Calls())
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -14,12 +14,16 @@
 
 package amsclient
 
+import "time"
+
 // Configuration represents the configuration of the AMS API client
 type Configuration struct {
-	Token              string `mapstructure:"token" toml:"token"`
-	ClientID           string `mapstructure:"client_id" toml:"client_id"`
-	ClientSecret       string `mapstructure:"client_secret" toml:"client_secret"`
-	URL                string `mapstructure:"url" toml:"url"`
-	PageSize           int    `mapstructure:"page_size" toml:"page_size"`
-	ClusterListCaching bool   `mapstructure:"cluster_list_caching" toml:"cluster_list_caching"`
+	Token               string        `mapstructure:"token" toml:"token"`
+	ClientID            string        `mapstructure:"client_id" toml:"client_id"`
+	ClientSecret        string        `mapstructure:"client_secret" toml:"client_secret"`
+	URL                 string        `mapstructure:"url" toml:"url"`
+	PageSize            int           `mapstructure:"page_size" toml:"page_size"`
+	ClusterListCaching  bool          `mapstructure:"cluster_list_caching" toml:"cluster_list_caching"`
+	ClusterListCacheTTL time.Duration `mapstructure:"cluster_list_cache_ttl" toml:"cluster_list_cache_ttl"`
+	ClusterListStaleTTL time.Duration `mapstructure:"cluster_list_stale_ttl" toml:"cluster_list_stale_ttl"`
 }
@@ -0,0 +1,126 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit contains implementation of the audit log. Changes of
+// acknowledgements, rules disabled for clusters and votes on rules made
+// through the smart proxy are recorded as events, so it's possible to find
+// out who changed them and when.
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// MemoryStorage selects events stored in memory of the service
+	// instance
+	MemoryStorage = "memory"
+	// RedisStorage selects events stored in Redis, shared by all
+	// instances
+	RedisStorage = "redis"
+
+	// DefaultMaxEvents is the number of events kept for each organization
+	// when it is not configured
+	DefaultMaxEvents = 10000
+)
+
+// Action is the kind of change recorded by audit event
+type Action string
+
+// Actions recorded in the audit log
+const (
+	// AckCreated is recorded when the rule is acknowledged
+	AckCreated Action = "ack_created"
+	// AckUpdated is recorded when the justification of acknowledgement is
+	// changed
+	AckUpdated Action = "ack_updated"
+	// AckDeleted is recorded when the acknowledgement is deleted by user
+	AckDeleted Action = "ack_deleted"
+	// AckExpired is recorded when the expired acknowledgement is deleted
+	AckExpired Action = "ack_expired"
+	// ClusterRuleDisabled is recorded when the rule is disabled for cluster
+	ClusterRuleDisabled Action = "cluster_rule_disabled"
+	// ClusterRuleEnabled is recorded when the rule is enabled for cluster
+	ClusterRuleEnabled Action = "cluster_rule_enabled"
+	// ClusterRuleJustified is recorded when the justification of rule
+	// disabled for cluster is provided
+	ClusterRuleJustified Action = "cluster_rule_justified"
+	// VoteChanged is recorded when user votes on the rule
+	VoteChanged Action = "vote_changed"
+)
+
+// Event represents one change recorded in the audit log
+type Event struct {
+	Time             time.Time          `json:"time"`
+	Action           Action             `json:"action"`
+	OrgID            types.OrgID        `json:"org_id"`
+	UserID           types.UserID       `json:"user_id,omitempty"`
+	RuleSelector     types.RuleSelector `json:"rule_selector"`
+	ClusterID        types.ClusterName  `json:"cluster,omitempty"`
+	OldJustification string             `json:"old_justification,omitempty"`
+	NewJustification string             `json:"new_justification,omitempty"`
+	Vote             string             `json:"vote,omitempty"`
+}
+
+// Store represents storage of audit events
+type Store interface {
+	// Record stores the event. The oldest events of the organization are
+	// removed when there are more events than configured.
+	Record(event *Event) error
+	// List returns page of events of the organization ordered from the
+	// newest one together with the number of all stored events
+	List(orgID types.OrgID, offset, limit int) ([]Event, int, error)
+}
+
+// New function constructs the audit store selected in configuration. Redis
+// connection is used only for the Redis storage. Nil is returned when the
+// audit log is disabled.
+func New(conf Configuration, connection redisV9.UniversalClient) (Store, error) {
+	if !conf.Enabled {
+		log.Info().Msg("Audit log is disabled")
+		return nil, nil
+	}
+
+	maxEvents := conf.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxEvents
+	}
+
+	var store Store
+	switch conf.Storage {
+	case "", MemoryStorage:
+		log.Info().Msg("Using in-memory store for audit log")
+		store = NewMemoryStore(maxEvents)
+	case RedisStorage:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Msg("Using Redis store for audit log")
+		store = NewRedisStore(connection, maxEvents)
+	default:
+		return nil, fmt.Errorf("unknown audit log storage '%s'", conf.Storage)
+	}
+
+	if conf.LogEvents {
+		store = NewLoggingStore(store)
+	}
+	return store, nil
+}
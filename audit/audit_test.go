@@ -0,0 +1,113 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const testOrgID = 42
+
+// eventFor returns event with justification distinguishing events in tests
+func eventFor(orgID int, justification string) audit.Event {
+	return audit.Event{
+		Time:             time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		Action:           audit.AckCreated,
+		OrgID:            types.OrgID(orgID),
+		UserID:           "1",
+		RuleSelector:     "rule.module|ERROR_KEY",
+		NewJustification: justification,
+	}
+}
+
+// TestNewStore checks that the storage selected in configuration is used
+func TestNewStore(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	store, err := audit.New(audit.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = audit.New(audit.Configuration{Enabled: true}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &audit.MemoryStore{}, store)
+
+	store, err = audit.New(audit.Configuration{Enabled: true, LogEvents: true}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &audit.LoggingStore{}, store)
+
+	store, err = audit.New(audit.Configuration{Enabled: true, Storage: audit.RedisStorage}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &audit.RedisStore{}, store)
+
+	_, err = audit.New(audit.Configuration{Enabled: true, Storage: audit.RedisStorage}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = audit.New(audit.Configuration{Enabled: true, Storage: "disk"}, client.Connection)
+	assert.EqualError(t, err, "unknown audit log storage 'disk'")
+}
+
+// TestMemoryStore checks that events are listed from the newest one and
+// the oldest events are removed
+func TestMemoryStore(t *testing.T) {
+	store := audit.NewMemoryStore(3)
+
+	for _, event := range []audit.Event{
+		eventFor(testOrgID, "1"), eventFor(testOrgID, "2"), eventFor(testOrgID+1, "other"),
+		eventFor(testOrgID, "3"), eventFor(testOrgID, "4"),
+	} {
+		assert.NoError(t, store.Record(&event))
+	}
+
+	events, total, err := store.List(testOrgID, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []audit.Event{eventFor(testOrgID, "4"), eventFor(testOrgID, "3"), eventFor(testOrgID, "2")}, events)
+
+	events, total, err = store.List(testOrgID, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []audit.Event{eventFor(testOrgID, "3")}, events)
+
+	events, _, err = store.List(testOrgID, 5, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, total, err = store.List(testOrgID+2, 0, 10)
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, events)
+}
+
+// TestLoggingStore checks that events are passed to the decorated store
+func TestLoggingStore(t *testing.T) {
+	store := audit.NewLoggingStore(audit.NewMemoryStore(10))
+
+	event := eventFor(testOrgID, "logged")
+	assert.NoError(t, store.Record(&event))
+
+	events, total, err := store.List(testOrgID, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []audit.Event{event}, events)
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+// Configuration represents the configuration of the audit log
+type Configuration struct {
+	// Enabled turns on recording of audit events and the audit endpoint
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Storage selects where audit events are stored: "memory" (useful for
+	// development only) or "redis"
+	Storage string `mapstructure:"storage" toml:"storage"`
+	// MaxEvents is the number of the latest events kept for each
+	// organization
+	MaxEvents int `mapstructure:"max_events" toml:"max_events"`
+	// LogEvents turns on emitting of each audit event as structured log
+	// message
+	LogEvents bool `mapstructure:"log_events" toml:"log_events"`
+}
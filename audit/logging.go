@@ -0,0 +1,56 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// LoggingStore is a Store decorator that emits each recorded event as
+// structured log message before it is stored
+type LoggingStore struct {
+	store Store
+}
+
+// NewLoggingStore function constructs store emitting events into the log
+func NewLoggingStore(store Store) *LoggingStore {
+	return &LoggingStore{
+		store: store,
+	}
+}
+
+// Record method emits the event into the log and stores it
+func (store *LoggingStore) Record(event *Event) error {
+	log.Info().
+		Str("audit_action", string(event.Action)).
+		Time("audit_time", event.Time).
+		Uint32("org_id", uint32(event.OrgID)).
+		Str("user_id", string(event.UserID)).
+		Str("rule_selector", string(event.RuleSelector)).
+		Str("cluster", string(event.ClusterID)).
+		Str("old_justification", event.OldJustification).
+		Str("new_justification", event.NewJustification).
+		Str("vote", event.Vote).
+		Msg("Audit event")
+
+	return store.store.Record(event)
+}
+
+// List method returns page of events from the decorated store
+func (store *LoggingStore) List(orgID types.OrgID, offset, limit int) ([]Event, int, error) {
+	return store.store.List(orgID, offset, limit)
+}
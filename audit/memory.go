@@ -0,0 +1,68 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// MemoryStore is a Store implementation that keeps events in memory of the
+// service instance
+type MemoryStore struct {
+	mutex     sync.RWMutex
+	maxEvents int
+	events    map[types.OrgID][]Event
+}
+
+// NewMemoryStore function constructs new in-memory store
+func NewMemoryStore(maxEvents int) *MemoryStore {
+	return &MemoryStore{
+		maxEvents: maxEvents,
+		events:    make(map[types.OrgID][]Event),
+	}
+}
+
+// Record method stores the event and removes the oldest events of the
+// organization over the limit
+func (store *MemoryStore) Record(event *Event) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	events := append(store.events[event.OrgID], *event)
+	if len(events) > store.maxEvents {
+		events = slices.Delete(events, 0, len(events)-store.maxEvents)
+	}
+	store.events[event.OrgID] = events
+
+	return nil
+}
+
+// List method returns page of events of the organization ordered from the
+// newest one
+func (store *MemoryStore) List(orgID types.OrgID, offset, limit int) ([]Event, int, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	events := store.events[orgID]
+	page := []Event{}
+	for i := len(events) - 1 - offset; i >= 0 && len(page) < limit; i-- {
+		page = append(page, events[i])
+	}
+
+	return page, len(events), nil
+}
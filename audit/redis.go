@@ -0,0 +1,95 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// RedisEventsKey is a key pattern for list with audit events of one
+// organization. Organization ID is used as a parameter. The newest event is
+// the first item of the list.
+const RedisEventsKey = "smart-proxy:audit:%v"
+
+// RedisStore is a Store implementation that keeps events in Redis
+type RedisStore struct {
+	connection redisV9.Cmdable
+	maxEvents  int
+}
+
+// NewRedisStore function constructs new Redis store
+func NewRedisStore(connection redisV9.Cmdable, maxEvents int) *RedisStore {
+	return &RedisStore{
+		connection: connection,
+		maxEvents:  maxEvents,
+	}
+}
+
+// Record method prepends the event to the list of the organization and
+// trims the list to the configured length
+func (store *RedisStore) Record(event *Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	key := fmt.Sprintf(RedisEventsKey, event.OrgID)
+
+	_, err = store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.LPush(ctx, key, value)
+		pipe.LTrim(ctx, key, 0, int64(store.maxEvents-1))
+		return nil
+	})
+	return err
+}
+
+// List method returns page of events of the organization ordered from the
+// newest one
+func (store *RedisStore) List(orgID types.OrgID, offset, limit int) ([]Event, int, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf(RedisEventsKey, orgID)
+
+	var lengthCmd *redisV9.IntCmd
+	var rangeCmd *redisV9.StringSliceCmd
+	_, err := store.connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		lengthCmd = pipe.LLen(ctx, key)
+		rangeCmd = pipe.LRange(ctx, key, int64(offset), int64(offset+limit-1))
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values := rangeCmd.Val()
+	events := make([]Event, 0, len(values))
+	for _, value := range values {
+		var event Event
+		if err := json.Unmarshal([]byte(value), &event); err != nil {
+			log.Error().Err(err).Msg("Unable to decode audit event stored in Redis")
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, int(lengthCmd.Val()), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+var testKey = fmt.Sprintf(audit.RedisEventsKey, testOrgID)
+
+// TestRedisStoreRecord checks that event is prepended to the list and the
+// list is trimmed
+func TestRedisStoreRecord(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := audit.NewRedisStore(client, 100)
+
+	event := eventFor(testOrgID, "recorded")
+	value, err := json.Marshal(event)
+	helpers.FailOnError(t, err)
+
+	server.ExpectTxPipeline()
+	server.ExpectLPush(testKey, value).SetVal(1)
+	server.ExpectLTrim(testKey, 0, 99).SetVal("OK")
+	server.ExpectTxPipelineExec()
+	assert.NoError(t, store.Record(&event))
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestRedisStoreList checks that page of events is read from the list
+func TestRedisStoreList(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := audit.NewRedisStore(client, 100)
+
+	event := eventFor(testOrgID, "recorded")
+	value, err := json.Marshal(event)
+	helpers.FailOnError(t, err)
+
+	server.ExpectLLen(testKey).SetVal(25)
+	server.ExpectLRange(testKey, 10, 14).SetVal([]string{string(value), "not an event"})
+	events, total, err := store.List(testOrgID, 10, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 25, total)
+	assert.Equal(t, []audit.Event{event}, events)
+
+	server.ExpectLLen(testKey).SetErr(errors.New("connection refused"))
+	_, _, err = store.List(testOrgID, 0, 5)
+	assert.Error(t, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
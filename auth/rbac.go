@@ -17,6 +17,7 @@ limitations under the License.
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -31,11 +32,13 @@ import (
 type RBACClient interface {
 	IsAuthorized(token string) bool
 	IsEnforcing() bool
+	HealthCheck(ctx context.Context) error
 }
 
 type rbacClientImpl struct {
 	uri         string
 	host        string
+	statusURL   string
 	client      *http.Client
 	enforceAuth bool
 }
@@ -52,6 +55,7 @@ func NewRBACClient(conf *RBACConfig) (RBACClient, error) {
 	return &rbacClientImpl{
 		url,
 		host,
+		strings.TrimSuffix(conf.URL, "/") + "/status/",
 		client,
 		conf.EnforceAuth,
 	}, nil
@@ -63,6 +67,31 @@ func (rc *rbacClientImpl) IsEnforcing() bool {
 	return rc.enforceAuth
 }
 
+// HealthCheck checks if RBAC service is responding. Any response except
+// server errors means the service is available.
+func (rc *rbacClientImpl) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rc.statusURL, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error().Err(err).Msg("Error closing response body")
+		}
+	}()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // IsAuthorized checks if an account has the correct permissions to access our resources
 func (rc *rbacClientImpl) IsAuthorized(token string) bool {
 	permissions := rc.getPermissions(token)
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
@@ -73,3 +76,23 @@ func TestAggregatePermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACClientHealthCheck(t *testing.T) {
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/rbac/v1/status/", r.URL.Path)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	client, err := NewRBACClient(&RBACConfig{URL: server.URL + "/api/rbac/v1"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.HealthCheck(context.Background()))
+
+	statusCode = http.StatusServiceUnavailable
+	assert.EqualError(t, client.HealthCheck(context.Background()), "unexpected status code 503")
+
+	server.Close()
+	assert.Error(t, client.HealthCheck(context.Background()))
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache contains implementation of short-lived cache used to store
+// responses retrieved from other services (mainly from Insights Results
+// Aggregator). The same data are requested many times a minute when UI polls
+// the service, so even a short TTL saves a lot of requests. Cached items are
+// grouped by organization, so all of them can be invalidated when the data
+// for the organization are changed (rule is acked, disabled etc.).
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// NoCacheType disables the cache
+	NoCacheType = "none"
+	// MemoryCacheType selects cache stored in memory of the service instance
+	MemoryCacheType = "memory"
+	// RedisCacheType selects cache stored in Redis, shared by all instances
+	RedisCacheType = "redis"
+
+	// DefaultTTL is used when TTL is not configured
+	DefaultTTL = 30 * time.Second
+)
+
+// Cache represents a short-lived cache for raw responses
+type Cache interface {
+	// Get returns the cached value for the organization and key. The
+	// second return value is false when the value is not cached.
+	Get(orgID types.OrgID, key string) ([]byte, bool)
+	// Set stores the value for the organization and key
+	Set(orgID types.OrgID, key string, value []byte)
+	// InvalidateOrg removes all values cached for the organization
+	InvalidateOrg(orgID types.OrgID)
+}
+
+// NoCache is a Cache implementation that does not store anything
+type NoCache struct{}
+
+// Get method of NoCache never finds any value
+func (NoCache) Get(types.OrgID, string) ([]byte, bool) {
+	return nil, false
+}
+
+// Set method of NoCache does nothing
+func (NoCache) Set(types.OrgID, string, []byte) {}
+
+// InvalidateOrg method of NoCache does nothing
+func (NoCache) InvalidateOrg(types.OrgID) {}
+
+// New function constructs the cache selected in configuration. Redis
+// connection is used only for the Redis cache.
+func New(conf Configuration, connection redisV9.UniversalClient) (Cache, error) {
+	ttl := conf.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	switch conf.Type {
+	case "", NoCacheType:
+		log.Info().Msg("Response cache is disabled")
+		return NoCache{}, nil
+	case MemoryCacheType:
+		log.Info().Dur("TTL", ttl).Msg("Using in-memory response cache")
+		return NewMemoryCache(ttl), nil
+	case RedisCacheType:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Dur("TTL", ttl).Msg("Using Redis response cache")
+		return NewRedisCache(connection, ttl), nil
+	default:
+		return nil, fmt.Errorf("unknown cache type '%s'", conf.Type)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+const testOrgID = 42
+
+// TestNewCache checks that the cache selected in configuration is created
+func TestNewCache(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	c, err := cache.New(cache.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, cache.NoCache{}, c)
+
+	c, err = cache.New(cache.Configuration{Type: cache.MemoryCacheType}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &cache.MemoryCache{}, c)
+
+	c, err = cache.New(cache.Configuration{Type: cache.RedisCacheType, TTL: time.Minute}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &cache.RedisCache{}, c)
+
+	_, err = cache.New(cache.Configuration{Type: cache.RedisCacheType}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = cache.New(cache.Configuration{Type: "memcached"}, client.Connection)
+	assert.EqualError(t, err, "unknown cache type 'memcached'")
+}
+
+// TestNoCache checks that NoCache never returns any value
+func TestNoCache(t *testing.T) {
+	c := cache.NoCache{}
+	c.Set(testOrgID, "key", []byte("value"))
+
+	_, found := c.Get(testOrgID, "key")
+	assert.False(t, found)
+	c.InvalidateOrg(testOrgID)
+}
+
+// TestMemoryCache checks storing and invalidation of values in memory cache
+func TestMemoryCache(t *testing.T) {
+	c := cache.NewMemoryCache(time.Minute)
+
+	_, found := c.Get(testOrgID, "key")
+	assert.False(t, found)
+
+	c.Set(testOrgID, "key", []byte("value"))
+	c.Set(testOrgID+1, "key", []byte("other value"))
+
+	value, found := c.Get(testOrgID, "key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+
+	c.InvalidateOrg(testOrgID)
+
+	_, found = c.Get(testOrgID, "key")
+	assert.False(t, found)
+
+	value, found = c.Get(testOrgID+1, "key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("other value"), value)
+}
+
+// TestMemoryCacheExpiration checks that expired values are not returned
+func TestMemoryCacheExpiration(t *testing.T) {
+	c := cache.NewMemoryCache(10 * time.Millisecond)
+
+	c.Set(testOrgID, "key", []byte("value"))
+	time.Sleep(20 * time.Millisecond)
+
+	_, found := c.Get(testOrgID, "key")
+	assert.False(t, found)
+
+	// purge of expired values must not remove new ones
+	c.Set(testOrgID, "new key", []byte("value"))
+	_, found = c.Get(testOrgID, "new key")
+	assert.True(t, found)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// memoryCacheEntry is one value stored in MemoryCache
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is a Cache implementation that stores values in memory of the
+// service instance
+type MemoryCache struct {
+	ttl       time.Duration
+	mutex     sync.Mutex
+	entries   map[types.OrgID]map[string]memoryCacheEntry
+	lastPurge time.Time
+}
+
+// NewMemoryCache function constructs new in-memory cache with given TTL
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:       ttl,
+		entries:   make(map[types.OrgID]map[string]memoryCacheEntry),
+		lastPurge: time.Now(),
+	}
+}
+
+// Get method returns the cached value if it is not expired yet
+func (cache *MemoryCache) Get(orgID types.OrgID, key string) ([]byte, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, found := cache.entries[orgID][key]
+	if !found {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		delete(cache.entries[orgID], key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// Set method stores the value. Expired values of all organizations are
+// removed once per TTL, so the memory is not occupied by organizations that
+// are not active anymore.
+func (cache *MemoryCache) Set(orgID types.OrgID, key string, value []byte) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(cache.lastPurge) > cache.ttl {
+		cache.purgeExpired(now)
+	}
+
+	orgEntries, found := cache.entries[orgID]
+	if !found {
+		orgEntries = make(map[string]memoryCacheEntry)
+		cache.entries[orgID] = orgEntries
+	}
+
+	orgEntries[key] = memoryCacheEntry{
+		value:     value,
+		expiresAt: now.Add(cache.ttl),
+	}
+}
+
+// InvalidateOrg method removes all values cached for the organization
+func (cache *MemoryCache) InvalidateOrg(orgID types.OrgID) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	delete(cache.entries, orgID)
+}
+
+// purgeExpired method removes all expired values. It must be called with
+// the mutex locked.
+func (cache *MemoryCache) purgeExpired(now time.Time) {
+	for orgID, orgEntries := range cache.entries {
+		for key, entry := range orgEntries {
+			if now.After(entry.expiresAt) {
+				delete(orgEntries, key)
+			}
+		}
+		if len(orgEntries) == 0 {
+			delete(cache.entries, orgID)
+		}
+	}
+	cache.lastPurge = now
+}
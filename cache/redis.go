@@ -0,0 +1,102 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// Organization ID is used as hash tag in all keys, so all keys of one
+// organization are stored in the same hash slot in Redis Cluster. This is
+// needed to store and delete them by one transaction or command.
+const (
+	// RedisValueKey is a key pattern for the cached values. Organization ID
+	// and cache key are used as parameters.
+	RedisValueKey = "smart-proxy:cache:organization:{%v}:%v"
+	// RedisOrgKeysKey is a key pattern for the set containing all cache
+	// keys stored for the organization
+	RedisOrgKeysKey = "smart-proxy:cache:organization:{%v}:keys"
+)
+
+// RedisCache is a Cache implementation that stores values in Redis, so the
+// cache is shared by all instances of the service
+type RedisCache struct {
+	connection redisV9.Cmdable
+	ttl        time.Duration
+}
+
+// NewRedisCache function constructs new Redis cache with given TTL
+func NewRedisCache(connection redisV9.Cmdable, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		connection: connection,
+		ttl:        ttl,
+	}
+}
+
+// Get method reads the value from Redis. Any Redis error is logged and
+// reported as a cache miss.
+func (cache *RedisCache) Get(orgID types.OrgID, key string) ([]byte, bool) {
+	value, err := cache.connection.Get(context.Background(), fmt.Sprintf(RedisValueKey, orgID, key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redisV9.Nil) {
+			log.Error().Err(err).Msg("Unable to read value from Redis cache")
+		}
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set method stores the value in Redis together with its key in the set of
+// keys for the organization
+func (cache *RedisCache) Set(orgID types.OrgID, key string, value []byte) {
+	valueKey := fmt.Sprintf(RedisValueKey, orgID, key)
+	orgKeysKey := fmt.Sprintf(RedisOrgKeysKey, orgID)
+
+	_, err := cache.connection.TxPipelined(context.Background(), func(pipe redisV9.Pipeliner) error {
+		pipe.Set(context.Background(), valueKey, value, cache.ttl)
+		pipe.SAdd(context.Background(), orgKeysKey, valueKey)
+		pipe.Expire(context.Background(), orgKeysKey, cache.ttl)
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to store value into Redis cache")
+	}
+}
+
+// InvalidateOrg method removes all values cached for the organization
+func (cache *RedisCache) InvalidateOrg(orgID types.OrgID) {
+	ctx := context.Background()
+	orgKeysKey := fmt.Sprintf(RedisOrgKeysKey, orgID)
+
+	keys, err := cache.connection.SMembers(ctx, orgKeysKey).Result()
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to read keys from Redis cache")
+		return
+	}
+
+	keys = append(keys, orgKeysKey)
+	if err := cache.connection.Del(ctx, keys...).Err(); err != nil {
+		log.Error().Err(err).Msg("Unable to invalidate Redis cache")
+	}
+}
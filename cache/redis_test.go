@@ -0,0 +1,88 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+var (
+	testValueKey   = fmt.Sprintf(cache.RedisValueKey, testOrgID, "key")
+	testOrgKeysKey = fmt.Sprintf(cache.RedisOrgKeysKey, testOrgID)
+)
+
+func TestRedisCacheGet(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	c := cache.NewRedisCache(client, time.Minute)
+
+	server.ExpectGet(testValueKey).SetVal("value")
+
+	value, found := c.Get(testOrgID, "key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisCacheGetMiss(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	c := cache.NewRedisCache(client, time.Minute)
+
+	server.ExpectGet(testValueKey).RedisNil()
+	server.ExpectGet(testValueKey).SetErr(errors.New("connection refused"))
+
+	_, found := c.Get(testOrgID, "key")
+	assert.False(t, found)
+	_, found = c.Get(testOrgID, "key")
+	assert.False(t, found)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisCacheSet(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	c := cache.NewRedisCache(client, time.Minute)
+
+	server.ExpectTxPipeline()
+	server.ExpectSet(testValueKey, []byte("value"), time.Minute).SetVal("OK")
+	server.ExpectSAdd(testOrgKeysKey, testValueKey).SetVal(1)
+	server.ExpectExpire(testOrgKeysKey, time.Minute).SetVal(true)
+	server.ExpectTxPipelineExec()
+
+	c.Set(testOrgID, "key", []byte("value"))
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisCacheInvalidateOrg(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	c := cache.NewRedisCache(client, time.Minute)
+
+	server.ExpectSMembers(testOrgKeysKey).SetVal([]string{testValueKey})
+	server.ExpectDel(testValueKey, testOrgKeysKey).SetVal(2)
+
+	c.InvalidateOrg(testOrgID)
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -44,10 +44,18 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/RedHatInsights/insights-operator-utils/logger"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
 	"github.com/RedHatInsights/insights-results-smart-proxy/amsclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/server"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
 	types "github.com/RedHatInsights/insights-results-types"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -77,16 +85,25 @@ type MetricsConfiguration struct {
 
 // Config has exactly the same structure as *.toml file
 var Config struct {
-	ServerConf        server.Configuration              `mapstructure:"server" toml:"server"`
-	ServicesConf      services.Configuration            `mapstructure:"services" toml:"services"`
-	RedisConf         services.RedisConfiguration       `mapstructure:"redis" toml:"redis"`
-	SetupConf         SetupConfiguration                `mapstructure:"setup" toml:"setup"`
-	MetricsConf       MetricsConfiguration              `mapstructure:"metrics" toml:"metrics"`
-	LoggingConf       logger.LoggingConfiguration       `mapstructure:"logging" toml:"logging"`
-	CloudWatchConf    logger.CloudWatchConfiguration    `mapstructure:"cloudwatch" toml:"cloudwatch"`
-	SentryLoggingConf logger.SentryLoggingConfiguration `mapstructure:"sentry" toml:"sentry"`
-	AMSClientConf     amsclient.Configuration           `mapstructure:"amsclient" toml:"amsclient"`
-	RBACConf          auth.RBACConfig                   `mapstructure:"rbac" toml:"rbac"`
+	ServerConf        server.Configuration                   `mapstructure:"server" toml:"server"`
+	ServicesConf      services.Configuration                 `mapstructure:"services" toml:"services"`
+	RedisConf         services.RedisConfiguration            `mapstructure:"redis" toml:"redis"`
+	RequestRetention  services.RequestRetentionConfiguration `mapstructure:"request_retention" toml:"request_retention"`
+	SetupConf         SetupConfiguration                     `mapstructure:"setup" toml:"setup"`
+	MetricsConf       MetricsConfiguration                   `mapstructure:"metrics" toml:"metrics"`
+	LoggingConf       logger.LoggingConfiguration            `mapstructure:"logging" toml:"logging"`
+	CloudWatchConf    logger.CloudWatchConfiguration         `mapstructure:"cloudwatch" toml:"cloudwatch"`
+	SentryLoggingConf logger.SentryLoggingConfiguration      `mapstructure:"sentry" toml:"sentry"`
+	AMSClientConf     amsclient.Configuration                `mapstructure:"amsclient" toml:"amsclient"`
+	RBACConf          auth.RBACConfig                        `mapstructure:"rbac" toml:"rbac"`
+	ResponseCacheConf cache.Configuration                    `mapstructure:"response_cache" toml:"response_cache"`
+	UpstreamsConf     httpclient.UpstreamsConfiguration      `mapstructure:"upstreams" toml:"upstreams"`
+	TracingConf       tracing.Configuration                  `mapstructure:"tracing" toml:"tracing"`
+	RateLimitConf     ratelimit.Configuration                `mapstructure:"rate_limit" toml:"rate_limit"`
+	TrendsConf        trends.Configuration                   `mapstructure:"trends" toml:"trends"`
+	WebhooksConf      webhooks.Configuration                 `mapstructure:"webhooks" toml:"webhooks"`
+	AckExpiryConf     ackexpiry.Configuration                `mapstructure:"ack_expiry" toml:"ack_expiry"`
+	AuditConf         audit.Configuration                    `mapstructure:"audit" toml:"audit"`
 }
 
 // LoadConfiguration loads configuration from defaultConfigFile, file set in
@@ -204,11 +221,62 @@ func GetRedisConfiguration() services.RedisConfiguration {
 	return Config.RedisConf
 }
 
+// GetRequestRetentionConfiguration returns retention policy of requests
+// stored in Redis
+func GetRequestRetentionConfiguration() services.RequestRetentionConfiguration {
+	return Config.RequestRetention
+}
+
 // GetRBACConfiguration returns the RBAC configuration loaded in Config.
 func GetRBACConfiguration() auth.RBACConfig {
 	return Config.RBACConf
 }
 
+// GetResponseCacheConfiguration returns configuration of the cache for
+// responses from other services
+func GetResponseCacheConfiguration() cache.Configuration {
+	return Config.ResponseCacheConf
+}
+
+// GetUpstreamsConfiguration returns timeouts, retry policy and circuit
+// breaker settings for upstream services
+func GetUpstreamsConfiguration() httpclient.UpstreamsConfiguration {
+	return Config.UpstreamsConf
+}
+
+// GetTracingConfiguration returns configuration of OpenTelemetry tracing
+func GetTracingConfiguration() tracing.Configuration {
+	return Config.TracingConf
+}
+
+// GetRateLimitConfiguration returns configuration of rate limiting of
+// incoming requests
+func GetRateLimitConfiguration() ratelimit.Configuration {
+	return Config.RateLimitConf
+}
+
+// GetTrendsConfiguration returns configuration of recording of organization
+// overview snapshots
+func GetTrendsConfiguration() trends.Configuration {
+	return Config.TrendsConf
+}
+
+// GetWebhooksConfiguration returns configuration of webhook notifications
+func GetWebhooksConfiguration() webhooks.Configuration {
+	return Config.WebhooksConf
+}
+
+// GetAckExpiryConfiguration returns configuration of time-boxed
+// acknowledgements
+func GetAckExpiryConfiguration() ackexpiry.Configuration {
+	return Config.AckExpiryConf
+}
+
+// GetAuditConfiguration returns configuration of the audit log
+func GetAuditConfiguration() audit.Configuration {
+	return Config.AuditConf
+}
+
 func updateConfigFromClowder() {
 	if !clowder.IsClowderEnabled() {
 		fmt.Println("Clowder is disabled")
@@ -233,6 +301,9 @@ func updateRedisConfig() {
 	if clowder.LoadedConfig.InMemoryDb.Password != nil {
 		Config.RedisConf.RedisPassword = *clowder.LoadedConfig.InMemoryDb.Password
 	}
+	if clowder.LoadedConfig.InMemoryDb.SslMode != nil {
+		Config.RedisConf.RedisUseTLS = *clowder.LoadedConfig.InMemoryDb.SslMode
+	}
 }
 
 // checkIfFileExists returns nil if path doesn't exist or isn't a file,
@@ -273,6 +273,8 @@ func TestGetAMCClientConfiguration(t *testing.T) {
 	   client_secret = "-top-secret-"
 	   page_size = 6000
 	   cluster_list_caching = false
+	   cluster_list_cache_ttl = "5m"
+	   cluster_list_stale_ttl = "1h"
 	*/
 
 	TestLoadConfiguration(t)
@@ -288,6 +290,8 @@ func TestGetAMCClientConfiguration(t *testing.T) {
 	assert.Equal(t, "https://api.openshift.com", amsConfiguration.URL)
 	assert.Equal(t, 6000, amsConfiguration.PageSize)
 	assert.Equal(t, false, amsConfiguration.ClusterListCaching)
+	assert.Equal(t, 5*time.Minute, amsConfiguration.ClusterListCacheTTL)
+	assert.Equal(t, time.Hour, amsConfiguration.ClusterListStaleTTL)
 }
 
 // TestGetSetupConfiguration tests loading the Setup configuration sub-tree
@@ -438,6 +442,7 @@ func TestClowderConfigForRedis(t *testing.T) {
 	var port = 6379
 	var username = "user"
 	var password = "password"
+	var sslMode = true
 
 	// explicit Redis config
 	clowder.LoadedConfig = &clowder.AppConfig{
@@ -446,6 +451,7 @@ func TestClowderConfigForRedis(t *testing.T) {
 			Port:     port,
 			Username: &username,
 			Password: &password,
+			SslMode:  &sslMode,
 		},
 	}
 
@@ -459,4 +465,5 @@ func TestClowderConfigForRedis(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s:%d", hostname, port), redisCfg.RedisEndpoint)
 	assert.Equal(t, username, redisCfg.RedisUsername)
 	assert.Equal(t, password, redisCfg.RedisPassword)
+	assert.True(t, redisCfg.RedisUseTLS)
 }
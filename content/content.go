@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/RedHatInsights/insights-operator-utils/generators"
@@ -37,6 +38,7 @@ var (
 	stopUpdateContentLoop     = make(chan struct{})
 	rulesWithContentStorage   = getEmptyRulesWithContentMap()
 	contentDirectoryTimeout   = 5 * time.Second
+	contentDirectoryLoaded    atomic.Bool
 	dotReport                 = ".report"
 )
 
@@ -55,6 +57,9 @@ type RulesWithContentStorage struct {
 	recommendationsWithContent map[ctypes.RuleID]*types.RuleWithContent
 	internalRuleIDs            []ctypes.RuleID
 	externalRuleIDs            []ctypes.RuleID
+	// version identifies the loaded content, it is the same in all
+	// replicas that loaded the same content
+	version string
 }
 
 // SetRuleContentDirectory is made for easy testing fake rules etc. from other directories
@@ -217,9 +222,18 @@ func WaitForContentDirectoryToBeReady() error {
 		ruleContentDirectoryReady.L.Unlock()
 	}
 
+	contentDirectoryLoaded.Store(true)
 	return nil
 }
 
+// IsContentDirectoryReady returns true when WaitForContentDirectoryToBeReady
+// has succeeded at least once, i.e. the rule content is available. Unlike
+// WaitForContentDirectoryToBeReady it never blocks, so it can be used by
+// health checks.
+func IsContentDirectoryReady() bool {
+	return contentDirectoryLoaded.Load()
+}
+
 // GetRuleWithErrorKeyContent returns content for rule with provided `rule id` and `error key`.
 // Caching is done under the hood, don't worry about it.
 func GetRuleWithErrorKeyContent(
@@ -309,6 +323,12 @@ func getEmptyRulesWithContentMap() *RulesWithContentStorage {
 	return &s
 }
 
+// GetContentVersion returns version of the loaded rule content. The version
+// changes each time different content is loaded.
+func GetContentVersion() string {
+	return rulesWithContentStorage.version
+}
+
 // GetRuleIDs returns a list of rule IDs (rule modules)
 func GetRuleIDs() ([]string, error) {
 	err := WaitForContentDirectoryToBeReady()
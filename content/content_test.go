@@ -207,6 +207,20 @@ func TestResetContent(t *testing.T) {
 	assert.Equal(t, 0, len(ruleIDs))
 }
 
+func TestGetContentVersion(t *testing.T) {
+	defer content.ResetContent()
+
+	content.LoadRuleContent(&testdata.RuleContentDirectory3Rules)
+	version := content.GetContentVersion()
+	assert.NotEmpty(t, version)
+
+	content.LoadRuleContent(&testdata.RuleContentDirectory3Rules)
+	assert.Equal(t, version, content.GetContentVersion())
+
+	content.LoadRuleContent(&testdata.RuleContentDirectory5Rules)
+	assert.NotEqual(t, version, content.GetContentVersion())
+}
+
 func TestGetAllContent(t *testing.T) {
 	defer content.ResetContent()
 	content.LoadRuleContent(&testdata.RuleContentDirectory3Rules)
@@ -15,6 +15,9 @@
 package content
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
@@ -45,6 +48,7 @@ var (
 // LoadRuleContent loads the parsed rule content into the storage
 func LoadRuleContent(contentDir *ctypes.RuleContentDirectory) {
 	s := getEmptyRulesWithContentMap()
+	s.version = contentVersion(contentDir)
 	for i, rule := range contentDir.Rules {
 		ruleID := ctypes.RuleID(rule.Plugin.PythonModule)
 
@@ -104,6 +108,20 @@ func LoadRuleContent(contentDir *ctypes.RuleContentDirectory) {
 	rulesWithContentStorage = s
 }
 
+// contentVersion function computes version of the rule content as hash of
+// its JSON representation. Keys of maps are sorted when they are encoded, so
+// the same content has the same version in all replicas.
+func contentVersion(contentDir *ctypes.RuleContentDirectory) string {
+	encoded, err := json.Marshal(contentDir)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to compute version of rule content")
+		return ""
+	}
+
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:])
+}
+
 // According to rule content specification, it's explicitly defined as floor((impact + likelihood) / 2), which
 // is the default behaviour in Go
 func calculateTotalRisk(impact, likelihood int) int {
@@ -35,4 +35,6 @@ var (
 	Main             = main
 	FillInInfoParams = fillInInfoParams
 	HandleCommand    = handleCommand
+	RunLoop          = runLoop
+	WaitForLoops     = waitForLoops
 )
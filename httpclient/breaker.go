@@ -0,0 +1,166 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+)
+
+// states of the circuit breaker, the values are exposed in metrics
+const (
+	StateClosed   = 0
+	StateHalfOpen = 1
+	StateOpen     = 2
+)
+
+// defaultOpenTimeout is used when the circuit breaker is enabled, but
+// OpenTimeout is not configured
+const defaultOpenTimeout = 30 * time.Second
+
+// CircuitOpenError is returned when the request is not sent because the
+// upstream service is failing
+type CircuitOpenError struct {
+	Upstream string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker for upstream service '%s' is open", e.Upstream)
+}
+
+// circuitBreaker counts consecutive failures of the upstream service. When
+// the threshold is reached, requests are rejected until the open timeout
+// elapses. Then one probe request is allowed and its result either closes
+// the breaker or opens it again.
+type circuitBreaker struct {
+	upstream    string
+	threshold   int
+	openTimeout time.Duration
+
+	mutex         sync.Mutex
+	state         int
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// newCircuitBreaker function constructs closed circuit breaker
+func newCircuitBreaker(upstream string, threshold int, openTimeout time.Duration) *circuitBreaker {
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+
+	breaker := &circuitBreaker{
+		upstream:    upstream,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+	}
+	metrics.UpstreamCircuitBreakerState.WithLabelValues(upstream).Set(StateClosed)
+	return breaker
+}
+
+// allow method checks if the request can be sent to the upstream service
+func (breaker *circuitBreaker) allow() error {
+	if breaker == nil {
+		return nil
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case StateOpen:
+		if time.Since(breaker.openedAt) < breaker.openTimeout {
+			return breaker.reject()
+		}
+		breaker.setState(StateHalfOpen)
+		breaker.probeInFlight = true
+	case StateHalfOpen:
+		if breaker.probeInFlight {
+			return breaker.reject()
+		}
+		breaker.probeInFlight = true
+	}
+
+	return nil
+}
+
+// reject method records rejected request, it must be called with lock held
+func (breaker *circuitBreaker) reject() error {
+	metrics.UpstreamRequestsRejected.WithLabelValues(breaker.upstream).Inc()
+	return &CircuitOpenError{Upstream: breaker.upstream}
+}
+
+// record method updates the breaker with result of the request
+func (breaker *circuitBreaker) record(success bool) {
+	if breaker == nil {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probeInFlight = false
+
+	if success {
+		breaker.failures = 0
+		if breaker.state != StateClosed {
+			log.Info().Str("upstream", breaker.upstream).Msg("Circuit breaker closed")
+			breaker.setState(StateClosed)
+		}
+		return
+	}
+
+	breaker.failures++
+	if breaker.state == StateHalfOpen || breaker.failures >= breaker.threshold {
+		if breaker.state != StateOpen {
+			log.Warn().Str("upstream", breaker.upstream).Int("failures", breaker.failures).Msg("Circuit breaker opened")
+		}
+		breaker.setState(StateOpen)
+		breaker.openedAt = time.Now()
+	}
+}
+
+// release method finishes the request without recording its result, so
+// another probe can be sent when the breaker is half-open
+func (breaker *circuitBreaker) release() {
+	if breaker == nil {
+		return
+	}
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probeInFlight = false
+}
+
+// currentState method returns current state of the circuit breaker
+func (breaker *circuitBreaker) currentState() int {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	return breaker.state
+}
+
+// setState method changes the state, it must be called with lock held
+func (breaker *circuitBreaker) setState(state int) {
+	breaker.state = state
+	metrics.UpstreamCircuitBreakerState.WithLabelValues(breaker.upstream).Set(float64(state))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpclient
+
+import "time"
+
+// Configuration represents resilience settings for one upstream service
+type Configuration struct {
+	// Timeout is the maximum time of one attempt, zero means no timeout
+	Timeout time.Duration `mapstructure:"timeout" toml:"timeout"`
+	// MaxRetries is the number of retries of idempotent requests
+	MaxRetries int `mapstructure:"max_retries" toml:"max_retries"`
+	// RetryBackoff is the base delay between retries, it is doubled with
+	// each retry and randomized
+	RetryBackoff time.Duration `mapstructure:"retry_backoff" toml:"retry_backoff"`
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit breaker, zero disables the circuit breaker
+	FailureThreshold int `mapstructure:"failure_threshold" toml:"failure_threshold"`
+	// OpenTimeout is the time for which the circuit breaker stays open
+	// before a probe request is let through
+	OpenTimeout time.Duration `mapstructure:"open_timeout" toml:"open_timeout"`
+}
+
+// UpstreamsConfiguration represents resilience settings for all upstream
+// services
+type UpstreamsConfiguration struct {
+	Aggregator             Configuration `mapstructure:"aggregator" toml:"aggregator"`
+	ContentService         Configuration `mapstructure:"content" toml:"content"`
+	UpgradeRisksPrediction Configuration `mapstructure:"upgrade_risks_prediction" toml:"upgrade_risks_prediction"`
+	AMS                    Configuration `mapstructure:"ams" toml:"ams"`
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpclient provides HTTP clients used to call upstream services.
+// The clients apply per-upstream timeouts, retry idempotent requests with
+// randomized exponential backoff and stop calling failing upstream services
+// using a circuit breaker.
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// names of upstream services, they are used as labels in metrics too
+const (
+	AggregatorUpstream             = "aggregator"
+	ContentServiceUpstream         = "content-service"
+	UpgradeRisksPredictionUpstream = "upgrade-risks-prediction"
+	AMSUpstream                    = "ams"
+)
+
+// defaultTimeouts contains timeouts used for upstream services when they
+// are not configured
+var defaultTimeouts = map[string]time.Duration{
+	UpgradeRisksPredictionUpstream: 5 * time.Second,
+}
+
+var (
+	transportsMutex sync.RWMutex
+	transports      = map[string]*Transport{}
+)
+
+// Configure function sets up transports for all upstream services. It is
+// expected to be called once during service initialization.
+func Configure(conf UpstreamsConfiguration) {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	for upstream, upstreamConf := range map[string]Configuration{
+		AggregatorUpstream:             conf.Aggregator,
+		ContentServiceUpstream:         conf.ContentService,
+		UpgradeRisksPredictionUpstream: conf.UpgradeRisksPrediction,
+		AMSUpstream:                    conf.AMS,
+	} {
+		transports[upstream] = NewTransport(upstream, withDefaults(upstream, upstreamConf), nil)
+	}
+}
+
+// withDefaults function fills in default values of the configuration
+func withDefaults(upstream string, conf Configuration) Configuration {
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTimeouts[upstream]
+	}
+	return conf
+}
+
+// UpstreamTransport function returns transport for the upstream service.
+// When the upstream service is not configured, transport with default
+// settings (no retries, no circuit breaker) is created.
+func UpstreamTransport(upstream string) *Transport {
+	transportsMutex.RLock()
+	transport, found := transports[upstream]
+	transportsMutex.RUnlock()
+	if found {
+		return transport
+	}
+
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if transport, found := transports[upstream]; found {
+		return transport
+	}
+	transport = NewTransport(upstream, withDefaults(upstream, Configuration{}), nil)
+	transports[upstream] = transport
+	return transport
+}
+
+// Upstream function returns HTTP client for the upstream service
+func Upstream(upstream string) *http.Client {
+	return &http.Client{Transport: UpstreamTransport(upstream)}
+}
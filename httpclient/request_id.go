@@ -0,0 +1,39 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpclient
+
+import (
+	"context"
+)
+
+// RequestIDHeader is the header carrying the correlation ID of the request
+// made to the smart proxy. It is forwarded to all upstream services.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the key of context value with the request ID
+type requestIDKey struct{}
+
+// ContextWithRequestID function returns a copy of the context that carries
+// the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext function returns the request ID stored in the
+// context or an empty string when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
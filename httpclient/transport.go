@@ -0,0 +1,242 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+)
+
+// maxRetryBackoff limits the delay between retries
+const maxRetryBackoff = 10 * time.Second
+
+// Transport is an http.RoundTripper that applies timeouts, retries and the
+// circuit breaker configured for the upstream service
+type Transport struct {
+	upstream string
+	conf     Configuration
+	breaker  *circuitBreaker
+	next     http.RoundTripper
+}
+
+// NewTransport function constructs transport for the upstream service. When
+// next is nil, http.DefaultTransport is used.
+func NewTransport(upstream string, conf Configuration, next http.RoundTripper) *Transport {
+	var breaker *circuitBreaker
+	if conf.FailureThreshold > 0 {
+		breaker = newCircuitBreaker(upstream, conf.FailureThreshold, conf.OpenTimeout)
+	}
+
+	return &Transport{
+		upstream: upstream,
+		conf:     conf,
+		breaker:  breaker,
+		next:     next,
+	}
+}
+
+// Wrap method returns transport that sends requests using the given round
+// tripper and shares the circuit breaker with this transport
+func (transport *Transport) Wrap(next http.RoundTripper) *Transport {
+	return &Transport{
+		upstream: transport.upstream,
+		conf:     transport.conf,
+		breaker:  transport.breaker,
+		next:     next,
+	}
+}
+
+// BreakerState method returns state of the circuit breaker. StateClosed is
+// returned when the circuit breaker is disabled.
+func (transport *Transport) BreakerState() int {
+	if transport.breaker == nil {
+		return StateClosed
+	}
+	return transport.breaker.currentState()
+}
+
+// RoundTrip method sends the request to the upstream service. Idempotent
+// requests are retried when the upstream service is not reachable or it is
+// temporarily unavailable.
+func (transport *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	retries := 0
+	if isRetryable(request) {
+		retries = transport.conf.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := transport.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		response, err := transport.send(request)
+		if request.Context().Err() != nil {
+			// request cancelled by the client or its deadline exceeded
+			// says nothing about health of the upstream service
+			transport.breaker.release()
+		} else {
+			transport.breaker.record(err == nil && response.StatusCode < http.StatusInternalServerError)
+		}
+
+		if attempt >= retries || !shouldRetry(response, err) || request.Context().Err() != nil {
+			return response, err
+		}
+
+		if response != nil {
+			drainAndClose(response)
+		}
+
+		// the request is cloned, because round tripper must not modify the
+		// original one
+		request = request.Clone(request.Context())
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		metrics.UpstreamRequestsRetried.WithLabelValues(transport.upstream).Inc()
+		log.Debug().Str("upstream", transport.upstream).Int("attempt", attempt+1).Msg("Retrying request to upstream service")
+
+		select {
+		case <-time.After(transport.backoff(attempt)):
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// send method sends one attempt of the request with configured timeout. The
+// timeout covers reading of the response body as well. Each attempt is
+// traced as a client span and the trace context is propagated to the
+// upstream service.
+func (transport *Transport) send(request *http.Request) (*http.Response, error) {
+	next := transport.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	ctx, span := tracing.Tracer().Start(request.Context(), "HTTP "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("upstream", transport.upstream),
+			semconv.HTTPRequestMethodKey.String(request.Method),
+			semconv.URLFull(request.URL.String()),
+			semconv.ServerAddress(request.URL.Hostname()),
+		),
+	)
+
+	cancel := context.CancelFunc(func() {})
+	if transport.conf.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, transport.conf.Timeout)
+	}
+
+	request = request.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+	if requestID := RequestIDFromContext(ctx); requestID != "" && request.Header.Get(RequestIDHeader) == "" {
+		request.Header.Set(RequestIDHeader, requestID)
+	}
+
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		cancel()
+		tracing.EndSpan(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+	if response.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, response.Status)
+	}
+	span.End()
+
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// backoff method computes randomized delay before next retry
+func (transport *Transport) backoff(attempt int) time.Duration {
+	if transport.conf.RetryBackoff <= 0 {
+		return 0
+	}
+
+	backoff := transport.conf.RetryBackoff << attempt
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	// full jitter spreads retries from multiple replicas over time
+	// #nosec G404
+	return time.Duration(rand.Int64N(int64(backoff))) + 1
+}
+
+// isRetryable function checks if the request can be safely sent again
+func isRetryable(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// shouldRetry function checks if the result of the request means that the
+// upstream service is temporarily unavailable
+func shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// drainAndClose function reads rest of the response body, so the connection
+// can be reused
+func drainAndClose(response *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	_ = response.Body.Close()
+}
+
+// cancelOnClose cancels the request context when the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnClose) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
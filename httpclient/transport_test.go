@@ -0,0 +1,218 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// upstreamServer starts test server that responds with given status codes
+// in sequence, the last status code is then used for all other requests
+func upstreamServer(t *testing.T, statusCodes ...int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = io.Copy(io.Discard, request.Body)
+		index := int(requests.Add(1)) - 1
+		if index >= len(statusCodes) {
+			index = len(statusCodes) - 1
+		}
+		writer.WriteHeader(statusCodes[index])
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newClient(conf httpclient.Configuration) (*http.Client, *httpclient.Transport) {
+	transport := httpclient.NewTransport("test", conf, nil)
+	return &http.Client{Transport: transport}, transport
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	client, _ := newClient(httpclient.Configuration{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestRetryRequestWithBody(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	client, _ := newClient(httpclient.Configuration{MaxRetries: 1})
+
+	request, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"clusters": []}`))
+	helpers.FailOnError(t, err)
+
+	response, err := client.Do(request)
+	helpers.FailOnError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusServiceUnavailable)
+	client, _ := newClient(httpclient.Configuration{MaxRetries: 2})
+
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestNoRetryForNonIdempotentRequest(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	client, _ := newClient(httpclient.Configuration{MaxRetries: 2})
+
+	response, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	helpers.FailOnError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestNoRetryForClientError(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusNotFound, http.StatusOK)
+	client, _ := newClient(httpclient.Configuration{MaxRetries: 2})
+
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-request.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newClient(httpclient.Configuration{Timeout: 10 * time.Millisecond})
+
+	_, err := client.Get(server.URL) //nolint:bodyclose
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+	client, transport := newClient(httpclient.Configuration{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(server.URL)
+		helpers.FailOnError(t, err)
+		response.Body.Close()
+	}
+	assert.Equal(t, httpclient.StateOpen, transport.BreakerState())
+
+	// requests are rejected without reaching the upstream service
+	_, err := client.Get(server.URL) //nolint:bodyclose
+	var circuitOpenError *httpclient.CircuitOpenError
+	assert.True(t, errors.As(err, &circuitOpenError))
+	assert.Equal(t, "test", circuitOpenError.Upstream)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// successful probe closes the circuit breaker
+	time.Sleep(60 * time.Millisecond)
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, httpclient.StateClosed, transport.BreakerState())
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	server, requests := upstreamServer(t, http.StatusInternalServerError)
+	client, transport := newClient(httpclient.Configuration{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	response.Body.Close()
+	assert.Equal(t, httpclient.StateOpen, transport.BreakerState())
+
+	time.Sleep(30 * time.Millisecond)
+	response, err = client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, httpclient.StateOpen, transport.BreakerState())
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestCircuitBreakerIgnoresCancelledRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	}))
+	defer server.Close()
+
+	client, transport := newClient(httpclient.Configuration{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+		helpers.FailOnError(t, err)
+
+		_, err = client.Do(request) //nolint:bodyclose
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		cancel()
+	}
+
+	assert.Equal(t, httpclient.StateClosed, transport.BreakerState())
+}
+
+func TestWrappedTransportSharesCircuitBreaker(t *testing.T) {
+	server, _ := upstreamServer(t, http.StatusInternalServerError)
+	client, transport := newClient(httpclient.Configuration{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	response, err := client.Get(server.URL)
+	helpers.FailOnError(t, err)
+	response.Body.Close()
+
+	wrapped := &http.Client{Transport: transport.Wrap(http.DefaultTransport)}
+	_, err = wrapped.Get(server.URL) //nolint:bodyclose
+	var circuitOpenError *httpclient.CircuitOpenError
+	assert.True(t, errors.As(err, &circuitOpenError))
+}
+
+func TestUpstreamDefaults(t *testing.T) {
+	httpclient.Configure(httpclient.UpstreamsConfiguration{})
+	assert.Same(t, httpclient.UpstreamTransport(httpclient.AggregatorUpstream), httpclient.UpstreamTransport(httpclient.AggregatorUpstream))
+	assert.Equal(t, httpclient.StateClosed, httpclient.UpstreamTransport("unknown").BreakerState())
+}
@@ -21,6 +21,38 @@ var (
 		Name: "api_endpoints_user_agent",
 		Help: "The total number of requests per endpoint with user agent information",
 	}
+	responseCacheHitsOps = prometheus.CounterOpts{
+		Name: "response_cache_hits",
+		Help: "The total number of aggregator responses read from cache",
+	}
+	responseCacheMissesOps = prometheus.CounterOpts{
+		Name: "response_cache_misses",
+		Help: "The total number of aggregator responses not found in cache",
+	}
+	amsClusterListCacheOps = prometheus.CounterOpts{
+		Name: "ams_cluster_list_cache",
+		Help: "The total number of cluster lists read from AMS cache by result",
+	}
+	upstreamCircuitBreakerStateOps = prometheus.GaugeOpts{
+		Name: "upstream_circuit_breaker_state",
+		Help: "State of the circuit breaker for upstream service (0 closed, 1 half-open, 2 open)",
+	}
+	upstreamRequestsRetriedOps = prometheus.CounterOpts{
+		Name: "upstream_requests_retried",
+		Help: "The total number of retried requests to upstream service",
+	}
+	upstreamRequestsRejectedOps = prometheus.CounterOpts{
+		Name: "upstream_requests_rejected",
+		Help: "The total number of requests rejected by open circuit breaker",
+	}
+	rateLimitedRequestsOps = prometheus.CounterOpts{
+		Name: "rate_limited_requests",
+		Help: "The total number of requests rejected because of exhausted rate limit",
+	}
+	webhookDeliveriesOps = prometheus.CounterOpts{
+		Name: "webhook_deliveries",
+		Help: "The total number of webhook notifications by result",
+	}
 )
 
 // RBACIdentityType shows number of requesters by identity type. For example
@@ -34,6 +66,38 @@ var RBACServiceAccountRejected = promauto.NewCounter(rbacServiceAccountRejectedO
 // APIEndpointsRequestsWithUserAgent shows the total number of requests per endpoint with user agent
 var APIEndpointsRequestsWithUserAgent = promauto.NewCounterVec(apiEndpointsRequestsWithUserAgentOps, []string{"endpoint", "user_agent"})
 
+// ResponseCacheHits shows number of aggregator responses read from the
+// response cache. Label "kind" contains type of the cached response.
+var ResponseCacheHits = promauto.NewCounterVec(responseCacheHitsOps, []string{"kind"})
+
+// ResponseCacheMisses shows number of aggregator responses that had to be
+// retrieved from aggregator because they were not found in the cache.
+var ResponseCacheMisses = promauto.NewCounterVec(responseCacheMissesOps, []string{"kind"})
+
+// AMSClusterListCache shows number of cluster lists requested from AMS cache.
+// Label "result" is one of "hit", "stale" or "miss".
+var AMSClusterListCache = promauto.NewCounterVec(amsClusterListCacheOps, []string{"result"})
+
+// UpstreamCircuitBreakerState shows state of the circuit breaker for each
+// upstream service. Label "upstream" contains name of the service.
+var UpstreamCircuitBreakerState = promauto.NewGaugeVec(upstreamCircuitBreakerStateOps, []string{"upstream"})
+
+// UpstreamRequestsRetried shows number of retried requests to upstream
+// services
+var UpstreamRequestsRetried = promauto.NewCounterVec(upstreamRequestsRetriedOps, []string{"upstream"})
+
+// UpstreamRequestsRejected shows number of requests that were not sent to
+// upstream services because their circuit breaker was open
+var UpstreamRequestsRejected = promauto.NewCounterVec(upstreamRequestsRejectedOps, []string{"upstream"})
+
+// RateLimitedRequests shows number of requests rejected by the rate limiter
+// by endpoint class and identity type
+var RateLimitedRequests = promauto.NewCounterVec(rateLimitedRequestsOps, []string{"class", "identity_type"})
+
+// WebhookDeliveries shows number of notifications sent to webhooks. Label
+// "result" is one of "delivered", "retried" or "failed".
+var WebhookDeliveries = promauto.NewCounterVec(webhookDeliveriesOps, []string{"result"})
+
 // AddAPIMetricsWithNamespace registers API and RBAC metrics under the
 // given Prometheus namespace.
 func AddAPIMetricsWithNamespace(namespace string) {
@@ -47,4 +111,28 @@ func AddAPIMetricsWithNamespace(namespace string) {
 
 	apiEndpointsRequestsWithUserAgentOps.Namespace = namespace
 	APIEndpointsRequestsWithUserAgent = promauto.NewCounterVec(apiEndpointsRequestsWithUserAgentOps, []string{"endpoint", "user_agent"})
+
+	responseCacheHitsOps.Namespace = namespace
+	ResponseCacheHits = promauto.NewCounterVec(responseCacheHitsOps, []string{"kind"})
+
+	responseCacheMissesOps.Namespace = namespace
+	ResponseCacheMisses = promauto.NewCounterVec(responseCacheMissesOps, []string{"kind"})
+
+	amsClusterListCacheOps.Namespace = namespace
+	AMSClusterListCache = promauto.NewCounterVec(amsClusterListCacheOps, []string{"result"})
+
+	upstreamCircuitBreakerStateOps.Namespace = namespace
+	UpstreamCircuitBreakerState = promauto.NewGaugeVec(upstreamCircuitBreakerStateOps, []string{"upstream"})
+
+	upstreamRequestsRetriedOps.Namespace = namespace
+	UpstreamRequestsRetried = promauto.NewCounterVec(upstreamRequestsRetriedOps, []string{"upstream"})
+
+	upstreamRequestsRejectedOps.Namespace = namespace
+	UpstreamRequestsRejected = promauto.NewCounterVec(upstreamRequestsRejectedOps, []string{"upstream"})
+
+	rateLimitedRequestsOps.Namespace = namespace
+	RateLimitedRequests = promauto.NewCounterVec(rateLimitedRequestsOps, []string{"class", "identity_type"})
+
+	webhookDeliveriesOps.Namespace = namespace
+	WebhookDeliveries = promauto.NewCounterVec(webhookDeliveriesOps, []string{"result"})
 }
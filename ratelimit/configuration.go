@@ -0,0 +1,43 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"time"
+)
+
+// Configuration represents the configuration of rate limiting of incoming
+// requests
+type Configuration struct {
+	// Enabled turns on the rate limiting
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Storage selects where the state of limiters is stored: "memory"
+	// (each instance has its own limits) or "redis" (limits are shared by
+	// all instances)
+	Storage string `mapstructure:"storage" toml:"storage"`
+	// Classes contains budgets for endpoint classes
+	Classes map[string]ClassConfiguration `mapstructure:"classes" toml:"classes"`
+	// Endpoints maps endpoint templates (without API prefix) to endpoint
+	// classes. It overrides the default assignment of endpoints.
+	Endpoints map[string]string `mapstructure:"endpoints" toml:"endpoints"`
+}
+
+// ClassConfiguration represents budget for one endpoint class
+type ClassConfiguration struct {
+	// Requests is the number of requests allowed in one period
+	Requests int `mapstructure:"requests" toml:"requests"`
+	// Period is the length of the period
+	Period time.Duration `mapstructure:"period" toml:"period"`
+}
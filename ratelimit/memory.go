@@ -0,0 +1,74 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// window represents counter of requests in one time window
+type window struct {
+	end   time.Time
+	count int
+}
+
+// MemoryStore is a Store implementation that keeps counters in memory of
+// the service instance
+type MemoryStore struct {
+	mutex   sync.Mutex
+	windows map[string]*window
+	// time of the next removal of expired windows
+	nextCleanup time.Time
+}
+
+// NewMemoryStore function constructs new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		windows: make(map[string]*window),
+	}
+}
+
+// Increment method increments the counter in the current window
+func (store *MemoryStore) Increment(key string, period time.Duration) (int, time.Duration, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	store.removeExpired(now, period)
+
+	current, found := store.windows[key]
+	if !found || !now.Before(current.end) {
+		current = &window{end: now.Truncate(period).Add(period)}
+		store.windows[key] = current
+	}
+	current.count++
+
+	return current.count, current.end.Sub(now), nil
+}
+
+// removeExpired method removes windows that have already ended, so the
+// memory used by organizations that stopped sending requests is released
+func (store *MemoryStore) removeExpired(now time.Time, period time.Duration) {
+	if now.Before(store.nextCleanup) {
+		return
+	}
+	for key, w := range store.windows {
+		if !now.Before(w.end) {
+			delete(store.windows, key)
+		}
+	}
+	store.nextCleanup = now.Add(period)
+}
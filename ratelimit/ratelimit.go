@@ -0,0 +1,151 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit contains implementation of rate limiting of requests
+// made by organizations. Each organization (and each identity type within
+// the organization, so service accounts don't exhaust the budget of users)
+// has a budget of requests for each class of endpoints. Budgets are counted
+// in fixed time windows.
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// MemoryStorage selects limiter state stored in memory of the service
+	// instance
+	MemoryStorage = "memory"
+	// RedisStorage selects limiter state stored in Redis, shared by all
+	// instances
+	RedisStorage = "redis"
+
+	// DefaultClass is used for endpoints without assigned class
+	DefaultClass = "default"
+	// ExpensiveClass is used for endpoints that make many or slow calls to
+	// upstream services
+	ExpensiveClass = "expensive"
+)
+
+// defaultClasses contains budgets used when the class is not configured
+var defaultClasses = map[string]ClassConfiguration{
+	DefaultClass:   {Requests: 600, Period: time.Minute},
+	ExpensiveClass: {Requests: 30, Period: time.Minute},
+}
+
+// Store represents storage of request counters
+type Store interface {
+	// Increment increments the counter for given key in the current
+	// window of given length. It returns the new value of the counter and
+	// time remaining to the end of the window.
+	Increment(key string, period time.Duration) (int, time.Duration, error)
+}
+
+// Result represents the decision made by the rate limiter
+type Result struct {
+	// Allowed is true when the request fits into the budget
+	Allowed bool
+	// Limit is the number of requests allowed in one period
+	Limit int
+	// Remaining is the number of requests remaining in the current period
+	Remaining int
+	// RetryAfter is the time remaining to the end of the current period
+	RetryAfter time.Duration
+}
+
+// RateLimiter checks requests against the budgets configured for endpoint
+// classes
+type RateLimiter struct {
+	store     Store
+	classes   map[string]ClassConfiguration
+	endpoints map[string]string
+}
+
+// NewRateLimiter function constructs rate limiter that uses the given store
+func NewRateLimiter(store Store, conf Configuration) *RateLimiter {
+	classes := make(map[string]ClassConfiguration, len(defaultClasses)+len(conf.Classes))
+	for name, class := range defaultClasses {
+		classes[name] = class
+	}
+	for name, class := range conf.Classes {
+		classes[name] = class
+	}
+
+	return &RateLimiter{
+		store:     store,
+		classes:   classes,
+		endpoints: conf.Endpoints,
+	}
+}
+
+// New function constructs the rate limiter with the storage selected in
+// configuration. Redis connection is used only for the Redis storage.
+// Nil is returned when rate limiting is disabled.
+func New(conf Configuration, connection redisV9.UniversalClient) (*RateLimiter, error) {
+	if !conf.Enabled {
+		log.Info().Msg("Rate limiting is disabled")
+		return nil, nil
+	}
+
+	switch conf.Storage {
+	case "", MemoryStorage:
+		log.Info().Msg("Using in-memory rate limiter")
+		return NewRateLimiter(NewMemoryStore(), conf), nil
+	case RedisStorage:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Msg("Using Redis rate limiter")
+		return NewRateLimiter(NewRedisStore(connection), conf), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limiter storage '%s'", conf.Storage)
+	}
+}
+
+// EndpointClass method returns the class configured for the endpoint
+// template. Second return value is false when no class is configured.
+func (limiter *RateLimiter) EndpointClass(endpoint string) (string, bool) {
+	class, found := limiter.endpoints[endpoint]
+	return class, found
+}
+
+// Allow method counts the request made by the organization and identity type
+// to an endpoint of given class and decides if it fits into the budget.
+// Requests to classes without a budget are always allowed.
+func (limiter *RateLimiter) Allow(class string, orgID types.OrgID, identityType string) (Result, error) {
+	budget, found := limiter.classes[class]
+	if !found || budget.Requests <= 0 || budget.Period <= 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	key := fmt.Sprintf("%s:%v:%s", class, orgID, identityType)
+	count, retryAfter, err := limiter.store.Increment(key, budget.Period)
+	if err != nil {
+		return Result{Allowed: true}, err
+	}
+
+	return Result{
+		Allowed:    count <= budget.Requests,
+		Limit:      budget.Requests,
+		Remaining:  max(budget.Requests-count, 0),
+		RetryAfter: retryAfter,
+	}, nil
+}
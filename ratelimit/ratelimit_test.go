@@ -0,0 +1,158 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+const testOrgID = 42
+
+// testConfiguration allows two requests per hour for the default class
+var testConfiguration = ratelimit.Configuration{
+	Enabled: true,
+	Classes: map[string]ratelimit.ClassConfiguration{
+		ratelimit.DefaultClass: {Requests: 2, Period: time.Hour},
+		"unlimited":            {},
+	},
+	Endpoints: map[string]string{
+		"clusters": "unlimited",
+	},
+}
+
+// TestNewRateLimiter checks that the storage selected in configuration is
+// used
+func TestNewRateLimiter(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	limiter, err := ratelimit.New(ratelimit.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.Nil(t, limiter)
+
+	limiter, err = ratelimit.New(testConfiguration, client.Connection)
+	assert.NoError(t, err)
+	assert.NotNil(t, limiter)
+
+	limiter, err = ratelimit.New(ratelimit.Configuration{Enabled: true, Storage: ratelimit.RedisStorage}, client.Connection)
+	assert.NoError(t, err)
+	assert.NotNil(t, limiter)
+
+	_, err = ratelimit.New(ratelimit.Configuration{Enabled: true, Storage: ratelimit.RedisStorage}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = ratelimit.New(ratelimit.Configuration{Enabled: true, Storage: "disk"}, client.Connection)
+	assert.EqualError(t, err, "unknown rate limiter storage 'disk'")
+}
+
+// TestRateLimiterAllow checks that requests over the budget are rejected and
+// that organizations and identity types have separate budgets
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := ratelimit.NewRateLimiter(ratelimit.NewMemoryStore(), testConfiguration)
+
+	for expectedRemaining := 1; expectedRemaining >= 0; expectedRemaining-- {
+		result, err := limiter.Allow(ratelimit.DefaultClass, testOrgID, "User")
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, expectedRemaining, result.Remaining)
+	}
+
+	result, err := limiter.Allow(ratelimit.DefaultClass, testOrgID, "User")
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.True(t, result.RetryAfter > 0 && result.RetryAfter <= time.Hour)
+
+	result, err = limiter.Allow(ratelimit.DefaultClass, testOrgID, "ServiceAccount")
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = limiter.Allow(ratelimit.DefaultClass, testOrgID+1, "User")
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+// TestRateLimiterClasses checks budgets of endpoint classes
+func TestRateLimiterClasses(t *testing.T) {
+	limiter := ratelimit.NewRateLimiter(ratelimit.NewMemoryStore(), testConfiguration)
+
+	class, found := limiter.EndpointClass("clusters")
+	assert.True(t, found)
+	assert.Equal(t, "unlimited", class)
+
+	_, found = limiter.EndpointClass("groups")
+	assert.False(t, found)
+
+	// class without budget is not limited at all
+	for range 5 {
+		result, err := limiter.Allow("unlimited", testOrgID, "User")
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	// default budget is used for expensive class that is not configured
+	result, err := limiter.Allow(ratelimit.ExpensiveClass, testOrgID, "User")
+	assert.NoError(t, err)
+	assert.Equal(t, 30, result.Limit)
+}
+
+// TestRedisStoreIncrement checks that counters are stored in Redis and
+// expire together with the window
+func TestRedisStoreIncrement(t *testing.T) {
+	const period = 24 * time.Hour
+
+	client, server := redismock.NewClientMock()
+	limiter := ratelimit.NewRateLimiter(ratelimit.NewRedisStore(client), ratelimit.Configuration{
+		Classes: map[string]ratelimit.ClassConfiguration{
+			ratelimit.DefaultClass: {Requests: 2, Period: period},
+		},
+	})
+
+	key := fmt.Sprintf(ratelimit.RedisCounterKey, "default:42:User", time.Now().Truncate(period).Unix())
+	server.ExpectTxPipeline()
+	server.ExpectIncr(key).SetVal(3)
+	server.ExpectExpire(key, period).SetVal(true)
+	server.ExpectTxPipelineExec()
+
+	result, err := limiter.Allow(ratelimit.DefaultClass, testOrgID, "User")
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestRedisStoreError checks that requests are allowed when Redis is not
+// available
+func TestRedisStoreError(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	limiter := ratelimit.NewRateLimiter(ratelimit.NewRedisStore(client), testConfiguration)
+
+	server.ExpectTxPipeline()
+	server.Regexp().ExpectIncr(".*").SetErr(errors.New("connection refused"))
+
+	result, err := limiter.Allow(ratelimit.DefaultClass, testOrgID, "User")
+	assert.Error(t, err)
+	assert.True(t, result.Allowed)
+}
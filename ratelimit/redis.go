@@ -0,0 +1,60 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+)
+
+// RedisCounterKey is a key pattern for the counters. Limiter key and start
+// of the time window (Unix time) are used as parameters.
+const RedisCounterKey = "smart-proxy:ratelimit:%v:%d"
+
+// RedisStore is a Store implementation that keeps counters in Redis, so the
+// limits are shared by all instances of the service
+type RedisStore struct {
+	connection redisV9.Cmdable
+}
+
+// NewRedisStore function constructs new Redis store
+func NewRedisStore(connection redisV9.Cmdable) *RedisStore {
+	return &RedisStore{
+		connection: connection,
+	}
+}
+
+// Increment method increments the counter for the current window stored in
+// Redis. The counter expires together with the window.
+func (store *RedisStore) Increment(key string, period time.Duration) (int, time.Duration, error) {
+	now := time.Now()
+	start := now.Truncate(period)
+	counterKey := fmt.Sprintf(RedisCounterKey, key, start.Unix())
+
+	var incr *redisV9.IntCmd
+	_, err := store.connection.TxPipelined(context.Background(), func(pipe redisV9.Pipeliner) error {
+		incr = pipe.Incr(context.Background(), counterKey)
+		pipe.Expire(context.Background(), counterKey, period)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(incr.Val()), start.Add(period).Sub(now), nil
+}
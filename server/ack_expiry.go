@@ -0,0 +1,156 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/RedHatInsights/insights-operator-utils/parsers"
+	types "github.com/RedHatInsights/insights-results-types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	sptypes "github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// ExpiresAtParam is the name of the attribute with expiration of
+// acknowledgement
+const ExpiresAtParam = "expires_at"
+
+// SetAckExpiryStore method sets the store with expirations of
+// acknowledgements
+func (server *HTTPServer) SetAckExpiryStore(store ackexpiry.Store) {
+	server.ackExpiryStore = store
+}
+
+// ackRuleSelector function constructs rule selector used as key of
+// acknowledgement expirations
+func ackRuleSelector(ruleID types.RuleID, errorKey types.ErrorKey) types.RuleSelector {
+	return types.RuleSelector(string(ruleID) + "|" + string(errorKey))
+}
+
+// readAckExpirations method returns expirations of acknowledgements of the
+// organization. Acknowledgements are treated as permanent when expirations
+// can't be read, so failures are just logged.
+func (server *HTTPServer) readAckExpirations(orgID types.OrgID) ackexpiry.Expirations {
+	if server.ackExpiryStore == nil {
+		return nil
+	}
+
+	expirations, err := server.ackExpiryStore.List(orgID)
+	if err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).Msg("Unable to read expirations of acknowledgements")
+		return nil
+	}
+	return expirations
+}
+
+// checkAckExpiration method checks expiration of acknowledgement requested
+// by client
+func (server *HTTPServer) checkAckExpiration(expiresAt *time.Time) error {
+	if expiresAt == nil {
+		return nil
+	}
+
+	if server.ackExpiryStore == nil {
+		return &RouterParsingError{
+			ParamName:  ExpiresAtParam,
+			ParamValue: expiresAt.Format(time.RFC3339),
+			ErrString:  "expiration of acknowledgements is disabled",
+		}
+	}
+
+	if !expiresAt.After(time.Now()) {
+		return &RouterParsingError{
+			ParamName:  ExpiresAtParam,
+			ParamValue: expiresAt.Format(time.RFC3339),
+			ErrString:  "expiration must be in the future",
+		}
+	}
+
+	return nil
+}
+
+// storeAckExpiration method stores expiration of acknowledgement requested by
+// client. Expiration left from previous ack is removed when permanent ack is
+// created. Expiration of existing ack is kept when not provided by client.
+func (server *HTTPServer) storeAckExpiration(
+	orgID types.OrgID, selector types.RuleSelector,
+	expiresAt *time.Time, previouslyAcked bool,
+) error {
+	if server.ackExpiryStore == nil {
+		return nil
+	}
+
+	if expiresAt != nil {
+		return server.ackExpiryStore.Set(orgID, selector, *expiresAt)
+	}
+
+	if !previouslyAcked {
+		return server.ackExpiryStore.Delete(orgID, selector)
+	}
+
+	return nil
+}
+
+// deleteAckExpiration method deletes expiration of acknowledgement. Failures
+// are just logged, because the acknowledgement itself has been deleted
+// already.
+func (server *HTTPServer) deleteAckExpiration(orgID types.OrgID, selector types.RuleSelector) {
+	if server.ackExpiryStore == nil {
+		return
+	}
+
+	err := server.ackExpiryStore.Delete(orgID, selector)
+	if err != nil {
+		log.Error().Err(err).Uint32(orgIDTag, uint32(orgID)).
+			Str("rule", string(selector)).Msg("Unable to delete expiration of acknowledgement")
+	}
+}
+
+// withExpiration function adds expiration to the acknowledgement returned to
+// client
+func withExpiration(ack types.Acknowledgement, expirations ackexpiry.Expirations) sptypes.Acknowledgement {
+	acknowledgement := sptypes.Acknowledgement{Acknowledgement: ack}
+	if expiresAt, found := expirations[types.RuleSelector(ack.Rule)]; found {
+		acknowledgement.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+	return acknowledgement
+}
+
+// DeleteExpiredAck method deletes the acknowledgement via Insights
+// Aggregator REST API. It is called by the sweeper of expired
+// acknowledgements.
+func (server *HTTPServer) DeleteExpiredAck(ctx context.Context, orgID types.OrgID, selector types.RuleSelector) error {
+	ruleID, errorKey, err := parsers.ParseRuleSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	err = server.deleteAckRuleSystemWide(ctx, ruleID, errorKey, orgID)
+	if err != nil {
+		return err
+	}
+
+	server.invalidateResponseCache(orgID)
+	server.recordAuditEvent(&audit.Event{
+		Action:       audit.AckExpired,
+		OrgID:        orgID,
+		RuleSelector: selector,
+	})
+	return nil
+}
@@ -0,0 +1,340 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// ackedRuleResponse is a response from aggregator with rule acked by user
+const ackedRuleResponse = `{
+	"disabledRule": {
+		"rule_id": "%v",
+		"error_key": "%v",
+		"justification": "incident",
+		"created_at": {"Time": "%v", "Valid": true},
+		"updated_at": {"Time": "%v", "Valid": true}
+	},
+	"status": "ok"
+}`
+
+// ackExpiryTestServer constructs server with in-memory expirations store
+func ackExpiryTestServer() (*server.HTTPServer, ackexpiry.Store) {
+	store := ackexpiry.NewMemoryStore()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetAckExpiryStore(store)
+	return testServer, store
+}
+
+// TestAcknowledgePostWithExpiration checks that expiration of new ack is
+// stored and returned to client
+func TestAcknowledgePostWithExpiration(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, store := ackExpiryTestServer()
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	expiresAtRFC := expiresAt.Format(time.RFC3339)
+
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ReadRuleSystemWide,
+		EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusNotFound,
+		Body:       `{"disabledRule": {}, "status": "ok"}`,
+	})
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodPut,
+		Endpoint:     ira_server.DisableRuleSystemWide,
+		EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+		Body:         `{"justification":"incident"}`,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ReadRuleSystemWide,
+		EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       fmt.Sprintf(ackedRuleResponse, testdata.Rule1ID, testdata.ErrorKey1, createdAt, createdAt),
+	})
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.AckAcknowledgePostEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+		Body: fmt.Sprintf(`{"rule_id": "%v", "justification": "incident", "expires_at": "%v"}`,
+			testdata.Rule1CompositeID, expiresAtRFC),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusCreated,
+		Body: fmt.Sprintf(`{
+			"rule": "%v",
+			"justification": "incident",
+			"created_by": "",
+			"created_at": "%v",
+			"updated_at": "%v",
+			"expires_at": "%v"
+		}`, testdata.Rule1CompositeID, createdAt, createdAt, expiresAtRFC),
+	})
+
+	expirations, err := store.List(testdata.OrgID)
+	helpers.FailOnError(t, err)
+	assert.True(t, expiresAt.Equal(expirations[types.RuleSelector(testdata.Rule1CompositeID)]))
+}
+
+// TestAcknowledgePostImproperExpiration checks that expiration in the past
+// and expiration used when the feature is disabled are refused
+func TestAcknowledgePostImproperExpiration(t *testing.T) {
+	testServer, _ := ackExpiryTestServer()
+	disabledServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	expiresAt := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	body := fmt.Sprintf(`{"rule_id": "%v", "justification": "incident", "expires_at": "%v"}`,
+		testdata.Rule1CompositeID, expiresAt)
+
+	for _, testCase := range []struct {
+		server   *server.HTTPServer
+		errorMsg string
+	}{
+		{testServer, "expiration must be in the future"},
+		{disabledServer, "expiration of acknowledgements is disabled"},
+	} {
+		iou_helpers.AssertAPIRequest(t, testCase.server, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodPost,
+			Endpoint:     server.AckAcknowledgePostEndpoint,
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
+			Body:         body,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusBadRequest,
+			Body: fmt.Sprintf(`{
+				"status": "Error during parsing param 'expires_at' with value '%v'. Error: '%v'",
+				"request_id": "test-request-id"
+			}`, expiresAt, testCase.errorMsg),
+		})
+	}
+}
+
+// TestReadAckListWithExpiration checks that expiration is returned in the
+// list of acks
+func TestReadAckListWithExpiration(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, store := ackExpiryTestServer()
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	helpers.FailOnError(t, store.Set(testdata.OrgID, types.RuleSelector(testdata.Rule1CompositeID), expiresAt))
+
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+		EndpointArgs: []interface{}{testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(`{
+			"disabledRules": [
+				{"rule_id": "%v", "error_key": "%v", "justification": "incident", "created_at": {"Time": "%v", "Valid": true}},
+				{"rule_id": "%v", "error_key": "%v", "justification": "forever", "created_at": {"Time": "%v", "Valid": true}}
+			],
+			"status": "ok"
+		}`, testdata.Rule1ID, testdata.ErrorKey1, createdAt, testdata.Rule2ID, testdata.ErrorKey2, createdAt),
+	})
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.AckListEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(`{
+			"meta": {"count": 2},
+			"data": [
+				{
+					"rule": "%v",
+					"justification": "incident",
+					"created_by": "",
+					"created_at": "%v",
+					"updated_at": "",
+					"expires_at": "%v"
+				},
+				{
+					"rule": "%v",
+					"justification": "forever",
+					"created_by": "",
+					"created_at": "%v",
+					"updated_at": ""
+				}
+			]
+		}`, testdata.Rule1CompositeID, createdAt, expiresAt.Format(time.RFC3339),
+			testdata.Rule2CompositeID, createdAt),
+	})
+}
+
+// TestGenerateRuleAckMapExpiredAcks checks that expired acks are ignored
+func TestGenerateRuleAckMapExpiredAcks(t *testing.T) {
+	acks := []ctypes.SystemWideRuleDisable{
+		{RuleID: testdata.Rule1ID, ErrorKey: testdata.ErrorKey1},
+		{RuleID: testdata.Rule2ID, ErrorKey: testdata.ErrorKey2},
+		{RuleID: testdata.Rule3ID, ErrorKey: testdata.ErrorKey3},
+	}
+	expirations := ackexpiry.Expirations{
+		types.RuleSelector(testdata.Rule1CompositeID): time.Now().Add(-time.Minute),
+		types.RuleSelector(testdata.Rule2CompositeID): time.Now().Add(time.Hour),
+	}
+
+	assert.Equal(t, map[ctypes.RuleID]bool{
+		testdata.Rule2CompositeID: true,
+		testdata.Rule3CompositeID: true,
+	}, server.GenerateRuleAckMap(acks, expirations))
+}
+
+// TestDeleteExpiredAck checks that expired ack is deleted via aggregator
+func TestDeleteExpiredAck(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, _ := ackExpiryTestServer()
+
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodPut,
+		Endpoint:     ira_server.EnableRuleSystemWide,
+		EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+
+	err := testServer.DeleteExpiredAck(context.Background(), testdata.OrgID, types.RuleSelector(testdata.Rule1CompositeID))
+	assert.NoError(t, err)
+
+	err = testServer.DeleteExpiredAck(context.Background(), testdata.OrgID, "improper selector")
+	assert.Error(t, err)
+}
+
+// bulkAckTestServer constructs server with in-memory expirations store that
+// sends requests to aggregator one by one
+func bulkAckTestServer() (*server.HTTPServer, ackexpiry.Store) {
+	config := helpers.DefaultServerConfig
+	config.BulkConcurrency = 1
+
+	store := ackexpiry.NewMemoryStore()
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetAckExpiryStore(store)
+	return testServer, store
+}
+
+// expectBulkAcks mocks aggregator responses for bulk request acknowledging
+// already acked Rule1 and new Rule2
+func expectBulkAcks(t *testing.T) {
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+		EndpointArgs: []interface{}{testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(
+			`{"disabledRules":[{"rule_id":"%v","error_key":"%v","justification":"old"}],"status":"ok"}`,
+			testdata.Rule1ID, testdata.ErrorKey1,
+		),
+	})
+	expectAggregatorCall(t, http.MethodPost, ira_server.UpdateRuleSystemWide,
+		[]interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID}, `{"justification":"incident"}`, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPut, ira_server.DisableRuleSystemWide,
+		[]interface{}{testdata.Rule2ID, testdata.ErrorKey2, testdata.OrgID}, `{"justification":"incident"}`, http.StatusOK)
+}
+
+// TestBulkAcknowledgeWithExpiration checks that expiration sent in bulk
+// request is stored for all acknowledgements
+func TestBulkAcknowledgeWithExpiration(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, store := bulkAckTestServer()
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	expectBulkAcks(t)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.AckBulkEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+		Body: fmt.Sprintf(`{"justification": "incident", "expires_at": "%v", "rule_selectors": ["%v", "%v"]}`,
+			expiresAt.Format(time.RFC3339), testdata.Rule1CompositeID, testdata.Rule2CompositeID),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+
+	expirations, err := store.List(testdata.OrgID)
+	helpers.FailOnError(t, err)
+	assert.Len(t, expirations, 2)
+	assert.True(t, expiresAt.Equal(expirations[types.RuleSelector(testdata.Rule1CompositeID)]))
+	assert.True(t, expiresAt.Equal(expirations[types.RuleSelector(testdata.Rule2CompositeID)]))
+}
+
+// TestBulkAcknowledgeWithoutExpiration checks that expiration of updated ack
+// is kept and that stale expiration is removed from new permanent ack
+func TestBulkAcknowledgeWithoutExpiration(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, store := bulkAckTestServer()
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	helpers.FailOnError(t, store.Set(testdata.OrgID, types.RuleSelector(testdata.Rule1CompositeID), expiresAt))
+	helpers.FailOnError(t, store.Set(testdata.OrgID, types.RuleSelector(testdata.Rule2CompositeID), expiresAt))
+	expectBulkAcks(t)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.AckBulkEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+		Body: fmt.Sprintf(`{"justification": "incident", "rule_selectors": ["%v", "%v"]}`,
+			testdata.Rule1CompositeID, testdata.Rule2CompositeID),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+
+	expirations, err := store.List(testdata.OrgID)
+	helpers.FailOnError(t, err)
+	assert.Len(t, expirations, 1)
+	assert.True(t, expiresAt.Equal(expirations[types.RuleSelector(testdata.Rule1CompositeID)]))
+}
+
+// TestBulkAcknowledgeImproperExpiration checks that bulk request with
+// expiration in the past is refused
+func TestBulkAcknowledgeImproperExpiration(t *testing.T) {
+	testServer, _ := bulkAckTestServer()
+	expiresAt := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.AckBulkEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+		Body: fmt.Sprintf(`{"justification": "incident", "expires_at": "%v", "rule_selectors": ["%v"]}`,
+			expiresAt, testdata.Rule1CompositeID),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/RedHatInsights/insights-operator-utils/generators"
 	utypes "github.com/RedHatInsights/insights-operator-utils/types"
@@ -28,6 +29,9 @@ import (
 
 	"github.com/RedHatInsights/insights-operator-utils/parsers"
 	types "github.com/RedHatInsights/insights-results-types"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 )
 
 // HTTP response-related constants
@@ -54,26 +58,28 @@ const (
 //	      "justification": "string",
 //	      "created_by": "string",
 //	      "created_at": "2021-09-04T17:11:35.130Z",
-//	      "updated_at": "2021-09-04T17:11:35.130Z"
+//	      "updated_at": "2021-09-04T17:11:35.130Z",
+//	      "expires_at": "2021-09-05T17:11:35Z"  <- only for acks with expiration
 //	    }
 //	  ]
 //	}
 func (server *HTTPServer) readAckList(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
-	acks, err := server.readListOfAckedRules(orgID)
+	acks, err := server.readListOfAckedRules(request.Context(), orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(ackedRulesError)
+		logger.Error().Err(err).Msg(ackedRulesError)
 		handleServerError(writer, err)
 		return
 	}
 
-	responseBody := prepareAckList(acks)
+	responseBody := prepareAckList(acks, server.readAckExpirations(orgID))
 
 	// serialize the above data structure into JSON format
 	bytes, err := json.MarshalIndent(responseBody, "", "\t")
@@ -106,18 +112,19 @@ func (server *HTTPServer) readAckList(writer http.ResponseWriter, request *http.
 //	  "updated_at": "2021-09-04T17:52:48.976Z"
 //	}
 func (server *HTTPServer) getAcknowledge(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	writer.Header().Set(contentTypeHeader, JSONContentType)
 
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
 	ruleID, errorKey, err := readRuleIDWithErrorKey(writer, request)
 	if err != nil {
-		log.Warn().Err(err).Msg(improperRuleSelectorFormat)
+		logger.Warn().Err(err).Msg(improperRuleSelectorFormat)
 		// server error has been handled already
 		return
 	}
@@ -126,9 +133,9 @@ func (server *HTTPServer) getAcknowledge(writer http.ResponseWriter, request *ht
 	logFullRuleSelector(orgID, ruleID, errorKey)
 
 	// test if the rule has been acknowledged already
-	ruleAck, found, err := server.readRuleDisableStatus(types.Component(ruleID), errorKey, orgID)
+	ruleAck, found, err := server.readRuleDisableStatus(request.Context(), types.Component(ruleID), errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleStatusError)
+		logger.Error().Err(err).Msg(readRuleStatusError)
 		err = errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
@@ -137,13 +144,13 @@ func (server *HTTPServer) getAcknowledge(writer http.ResponseWriter, request *ht
 	// rule was not acked -> nothing to return
 	if !found {
 		writer.WriteHeader(http.StatusNotFound)
-		log.Debug().Msg("Rule has not been disabled previously -> nothing to return!")
+		logger.Debug().Msg("Rule has not been disabled previously -> nothing to return!")
 		return
 	}
 
 	// we have the metadata about rule, let's send it into client in
 	// response payload
-	returnRuleAckToClient(writer, ruleAck)
+	returnRuleAckToClient(writer, withExpiration(ruleAck, server.readAckExpirations(orgID)))
 }
 
 // method acknowledgePost acknowledges (and therefore hides) a rule from view
@@ -154,7 +161,8 @@ func (server *HTTPServer) getAcknowledge(writer http.ResponseWriter, request *ht
 //
 //	{
 //	  "rule_id": "string",
-//	  "justification": "string"
+//	  "justification": "string",
+//	  "expires_at": "2021-09-05T17:52:48Z"  <- optional
 //	}
 //
 // An example response:
@@ -170,11 +178,12 @@ func (server *HTTPServer) getAcknowledge(writer http.ResponseWriter, request *ht
 // HTTP/1.1 200 OK is returned if rule has been already acked
 // HTTP/1.1 201 Created is returned if rule has been acked by this call
 func (server *HTTPServer) acknowledgePost(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	writer.Header().Set(contentTypeHeader, JSONContentType)
 
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -186,7 +195,7 @@ func (server *HTTPServer) acknowledgePost(writer http.ResponseWriter, request *h
 	}
 
 	// we seem to have all data -> let's display them
-	log.Debug().
+	logger.Debug().
 		Int("org", int(orgID)).
 		Str("rule", string(parameters.RuleSelector)).
 		Str("value", parameters.Value).
@@ -195,48 +204,72 @@ func (server *HTTPServer) acknowledgePost(writer http.ResponseWriter, request *h
 	// check if rule selector has the proper format
 	ruleID, errorKey, err := parsers.ParseRuleSelector(parameters.RuleSelector)
 	if err != nil {
-		log.Warn().Err(err).Msg(improperRuleSelectorFormat)
+		logger.Warn().Err(err).Msg(improperRuleSelectorFormat)
 		// return HTTP code 400 to client
 		http.Error(writer, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// display parsed rule ID and error key
-	log.Debug().
+	logger.Debug().
 		Str(ruleIDStr, string(ruleID)).
 		Str(errorKeyStr, string(errorKey)).
 		Msg("Parsed rule selector")
 
+	// expiration is optional, but it needs to be usable when provided
+	err = server.checkAckExpiration(parameters.ExpiresAt)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Improper expiration of acknowledgement")
+		handleServerError(writer, err)
+		return
+	}
+
 	// test if the rule has been acknowledged already
-	_, previouslyAcked, err := server.readRuleDisableStatus(ruleID, errorKey, orgID)
+	_, previouslyAcked, err := server.readRuleDisableStatus(request.Context(), ruleID, errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleStatusError)
+		logger.Error().Err(err).Msg(readRuleStatusError)
 		err = errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
 	}
 
+	// expiration is stored before the rule is acked so the ack can't
+	// become permanent by accident
+	err = server.storeAckExpiration(orgID, ackRuleSelector(types.RuleID(ruleID), errorKey), parameters.ExpiresAt, previouslyAcked)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to store expiration of acknowledgement")
+		handleServerError(writer, err)
+		return
+	}
+
 	// if acknowledgement has been found -> return 200 OK with the existing rule ack
 	// if acknowledgement has NOT been found -> return 201 Created with the created rule ack
 	if previouslyAcked {
-		log.Debug().Msg("Rule has been already disabled")
+		logger.Debug().Msg("Rule has been already disabled")
 	} else {
-		log.Debug().Msg("Rule has not been disabled previously")
+		logger.Debug().Msg("Rule has not been disabled previously")
 
 		// acknowledge rule
-		err := server.ackRuleSystemWide(ruleID, errorKey, orgID, parameters.Value)
+		err := server.ackRuleSystemWide(request.Context(), ruleID, errorKey, orgID, parameters.Value)
 		if err != nil {
-			log.Error().Err(err).Msg(readRuleJustificationError)
+			logger.Error().Err(err).Msg(readRuleJustificationError)
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
+
+		server.recordAuditRequest(request, &audit.Event{
+			Action:           audit.AckCreated,
+			OrgID:            orgID,
+			RuleSelector:     ackRuleSelector(types.RuleID(ruleID), errorKey),
+			NewJustification: parameters.Value,
+		})
 	}
 
 	// Aggregator REST API is source of truth - let's re-read rule status
 	// from it
-	updatedAcknowledgement, _, err := server.readRuleDisableStatus(ruleID, errorKey, orgID)
+	updatedAcknowledgement, _, err := server.readRuleDisableStatus(request.Context(), ruleID, errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleJustificationError)
+		logger.Error().Err(err).Msg(readRuleJustificationError)
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
@@ -249,7 +282,7 @@ func (server *HTTPServer) acknowledgePost(writer http.ResponseWriter, request *h
 
 	// we have the metadata about rule, let's send it into client in
 	// response payload
-	returnRuleAckToClient(writer, updatedAcknowledgement)
+	returnRuleAckToClient(writer, withExpiration(updatedAcknowledgement, server.readAckExpirations(orgID)))
 }
 
 // method updateAcknowledge updates an acknowledgement for a rule, by rule ID.
@@ -275,16 +308,17 @@ func (server *HTTPServer) acknowledgePost(writer http.ResponseWriter, request *h
 // Additionally, if rule is not found, 404 is returned (not mentioned in
 // original REST API specification).
 func (server *HTTPServer) updateAcknowledge(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
 	ruleID, errorKey, err := readRuleIDWithErrorKey(writer, request)
 	if err != nil {
-		log.Warn().Err(err).Msg(improperRuleSelectorFormat)
+		logger.Warn().Err(err).Msg(improperRuleSelectorFormat)
 		// server error has been handled already
 		return
 	}
@@ -297,14 +331,14 @@ func (server *HTTPServer) updateAcknowledge(writer http.ResponseWriter, request
 
 	// we seem to have all data -> let's display them
 	logFullRuleSelector(orgID, ruleID, errorKey)
-	log.Debug().
+	logger.Debug().
 		Str("justification", parameters.Value).
 		Msg("Justification to be set")
 
 	// test if the rule has been acknowledged already
-	_, found, err := server.readRuleDisableStatus(types.Component(ruleID), errorKey, orgID)
+	previousAck, found, err := server.readRuleDisableStatus(request.Context(), types.Component(ruleID), errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleStatusError)
+		logger.Error().Err(err).Msg(readRuleStatusError)
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
@@ -312,26 +346,34 @@ func (server *HTTPServer) updateAcknowledge(writer http.ResponseWriter, request
 
 	// if acknowledgement has NOT been found -> return 404 NotFound
 	if !found {
-		log.Debug().Msg("Rule ack can not be found")
+		logger.Debug().Msg("Rule ack can not be found")
 		err := &utypes.ItemNotFoundError{ItemID: (ruleID + "|" + types.RuleID(errorKey))}
 		handleServerError(writer, err)
 		return
 	}
 
 	// ok, rule has been found, so update it
-	err = server.updateAckRuleSystemWide(types.Component(ruleID), errorKey, orgID, parameters.Value)
+	err = server.updateAckRuleSystemWide(request.Context(), types.Component(ruleID), errorKey, orgID, parameters.Value)
 	if err != nil {
-		log.Error().Err(err).Msg("Unable to update justification for rule acknowledgement")
+		logger.Error().Err(err).Msg("Unable to update justification for rule acknowledgement")
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
 	}
 
+	server.recordAuditRequest(request, &audit.Event{
+		Action:           audit.AckUpdated,
+		OrgID:            orgID,
+		RuleSelector:     ackRuleSelector(ruleID, errorKey),
+		OldJustification: previousAck.Justification,
+		NewJustification: parameters.Value,
+	})
+
 	// Aggregator REST API is source of truth - let's re-read rule status
 	// from it
-	updatedAcknowledgement, _, err := server.readRuleDisableStatus(types.Component(ruleID), errorKey, orgID)
+	updatedAcknowledgement, _, err := server.readRuleDisableStatus(request.Context(), types.Component(ruleID), errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleJustificationError)
+		logger.Error().Err(err).Msg(readRuleJustificationError)
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
@@ -339,23 +381,24 @@ func (server *HTTPServer) updateAcknowledge(writer http.ResponseWriter, request
 
 	// we have the metadata about rule, let's send it into client in
 	// response payload
-	returnRuleAckToClient(writer, updatedAcknowledgement)
+	returnRuleAckToClient(writer, withExpiration(updatedAcknowledgement, server.readAckExpirations(orgID)))
 }
 
 // method deleteAcknowledge deletes an acknowledgement for a rule, by its rule
 // ID. If the ack existed, it is deleted and a 204 is returned. Otherwise, a
 // 404 is returned.
 func (server *HTTPServer) deleteAcknowledge(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
 	ruleID, errorKey, err := readRuleIDWithErrorKey(writer, request)
 	if err != nil {
-		log.Warn().Err(err).Msg(improperRuleSelectorFormat)
+		logger.Warn().Err(err).Msg(improperRuleSelectorFormat)
 		// server error has been handled already
 		return
 	}
@@ -364,9 +407,9 @@ func (server *HTTPServer) deleteAcknowledge(writer http.ResponseWriter, request
 	logFullRuleSelector(orgID, ruleID, errorKey)
 
 	// test if the rule has been acknowledged already
-	_, found, err := server.readRuleDisableStatus(types.Component(ruleID), errorKey, orgID)
+	previousAck, found, err := server.readRuleDisableStatus(request.Context(), types.Component(ruleID), errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(readRuleStatusError)
+		logger.Error().Err(err).Msg(readRuleStatusError)
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
@@ -374,29 +417,45 @@ func (server *HTTPServer) deleteAcknowledge(writer http.ResponseWriter, request
 
 	if !found {
 		writer.WriteHeader(http.StatusNotFound)
-		log.Debug().Msg("Rule has not been disabled previously -> ACK won't be deleted")
+		logger.Debug().Msg("Rule has not been disabled previously -> ACK won't be deleted")
 		return
 	}
 
 	// rule has been found -> let's delete the ACK
 	// delete acknowledgement for a rule
-	log.Debug().Msg("About to delete ACK for a rule")
-	err = server.deleteAckRuleSystemWide(types.Component(ruleID), errorKey, orgID)
+	logger.Debug().Msg("About to delete ACK for a rule")
+	err = server.deleteAckRuleSystemWide(request.Context(), types.Component(ruleID), errorKey, orgID)
 	if err != nil {
-		log.Error().Err(err).Msg("Unable to delete rule acknowledgement")
+		logger.Error().Err(err).Msg("Unable to delete rule acknowledgement")
 		err := errors.New(aggregatorResponseError)
 		handleServerError(writer, err)
 		return
 	}
 
+	// ack does not exist anymore, so its expiration is not needed too
+	server.deleteAckExpiration(orgID, ackRuleSelector(ruleID, errorKey))
+
+	server.recordAuditRequest(request, &audit.Event{
+		Action:           audit.AckDeleted,
+		OrgID:            orgID,
+		RuleSelector:     ackRuleSelector(ruleID, errorKey),
+		OldJustification: previousAck.Justification,
+	})
+
 	// return 204 -> rule ack has been deleted
 	writer.WriteHeader(http.StatusNoContent)
 }
 
-func generateRuleAckMap(acks []types.SystemWideRuleDisable) (ruleAcksMap map[types.RuleID]bool) {
+// generateRuleAckMap function constructs map of acked rules. Acks that have
+// already expired are skipped, even if the sweeper has not deleted them yet.
+func generateRuleAckMap(acks []types.SystemWideRuleDisable, expirations ackexpiry.Expirations) (ruleAcksMap map[types.RuleID]bool) {
 	ruleAcksMap = make(map[types.RuleID]bool)
+	now := time.Now()
 	for i := range acks {
 		ack := &acks[i]
+		if expirations.Expired(ackRuleSelector(ack.RuleID, ack.ErrorKey), now) {
+			continue
+		}
 		compositeRuleID, err := generators.GenerateCompositeRuleID(types.RuleFQDN(ack.RuleID), ack.ErrorKey)
 		if err == nil {
 			ruleAcksMap[compositeRuleID] = true
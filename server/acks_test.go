@@ -279,13 +279,15 @@ func TestHTTPServer_TestReadAckListInvalidToken(t *testing.T) {
 
 	ackListResponse := `
 	{
+		"request_id": "test-request-id",
 		"status": "Malformed authentication token"
 	}
 	`
 	helpers.AssertAPIv2Request(t, nil, nil, nil, nil, nil, &helpers.APIRequest{
-		Method:      http.MethodGet,
-		Endpoint:    server.AckListEndpoint,
-		XRHIdentity: invalidXRHAuthToken,
+		Method:       http.MethodGet,
+		Endpoint:     server.AckListEndpoint,
+		XRHIdentity:  invalidXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
 	}, &helpers.APIResponse{
 		StatusCode: http.StatusForbidden,
 		Body:       ackListResponse,
@@ -21,10 +21,12 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -32,7 +34,9 @@ import (
 
 	httputils "github.com/RedHatInsights/insights-operator-utils/http"
 	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	sptypes "github.com/RedHatInsights/insights-results-smart-proxy/types"
 	types "github.com/RedHatInsights/insights-results-types"
 )
 
@@ -59,14 +63,20 @@ func readJustificationFromBody(request *http.Request) (
 	return parameters, nil
 }
 
+// ackRequest represents payload of request to acknowledge rule. Expiration
+// is optional, acknowledgement without it is permanent.
+type ackRequest struct {
+	types.AcknowledgementRuleSelectorJustification
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
 // readRuleSelectorAndJustificationFromBody function tries to read data
-// structure types.AcknowledgementRuleSelectorJustification from response
-// payload (body)
+// structure ackRequest from response payload (body)
 func readRuleSelectorAndJustificationFromBody(writer http.ResponseWriter, request *http.Request) (
-	types.AcknowledgementRuleSelectorJustification, error,
+	ackRequest, error,
 ) {
 	// try to read request body
-	var parameters types.AcknowledgementRuleSelectorJustification
+	var parameters ackRequest
 	err := json.NewDecoder(request.Body).Decode(&parameters)
 
 	if err != nil {
@@ -82,7 +92,7 @@ func readRuleSelectorAndJustificationFromBody(writer http.ResponseWriter, reques
 
 // returnRuleAckToClient returns information about selected rule ack to client.
 // This function also tries to process all errors.
-func returnRuleAckToClient(writer http.ResponseWriter, ack types.Acknowledgement) {
+func returnRuleAckToClient(writer http.ResponseWriter, ack sptypes.Acknowledgement) {
 	// serialize the above data structure into JSON format
 	serializedAck, err := json.MarshalIndent(ack, "", "\t")
 	if err != nil {
@@ -99,6 +109,7 @@ func returnRuleAckToClient(writer http.ResponseWriter, ack types.Acknowledgement
 
 // ackRuleSystemWide method acknowledges rule via Insights Aggregator REST API
 func (server *HTTPServer) ackRuleSystemWide(
+	ctx context.Context,
 	ruleID types.Component, errorKey types.ErrorKey,
 	orgID types.OrgID, justification string,
 ) error {
@@ -119,14 +130,13 @@ func (server *HTTPServer) ackRuleSystemWide(
 	}
 
 	// call PUT method, provide the required data in payload
-	req, err := http.NewRequest(http.MethodPut, aggregatorURL, bytes.NewBuffer(jsonReq))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, aggregatorURL, bytes.NewBuffer(jsonReq))
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set(contentTypeHeader, JSONContentType)
-	client := &http.Client{}
-	response, err := client.Do(req) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
+	response, err := aggregatorClient().Do(req) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
 	if err != nil {
 		return err
 	}
@@ -145,6 +155,7 @@ func (server *HTTPServer) ackRuleSystemWide(
 // updateAckRuleSystemWide method updates rule ACK via Insights Aggregator REST
 // API
 func (server *HTTPServer) updateAckRuleSystemWide(
+	ctx context.Context,
 	ruleID types.Component, errorKey types.ErrorKey,
 	orgID types.OrgID, justification string,
 ) error {
@@ -166,8 +177,7 @@ func (server *HTTPServer) updateAckRuleSystemWide(
 
 	// do POST request and read response from Insights Aggregator
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	response, err := http.Post(aggregatorURL, JSONContentType,
-		bytes.NewBuffer(jsonData)) // #nosec G107
+	response, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(jsonData)) // #nosec G107
 	if err != nil {
 		return err
 	}
@@ -187,6 +197,7 @@ func (server *HTTPServer) updateAckRuleSystemWide(
 // deleteAckRuleSystemWide method deletes the acknowledgement of a rule via
 // Insights Aggregator REST API
 func (server *HTTPServer) deleteAckRuleSystemWide(
+	ctx context.Context,
 	ruleID types.Component, errorKey types.ErrorKey,
 	orgID types.OrgID,
 ) error {
@@ -198,14 +209,13 @@ func (server *HTTPServer) deleteAckRuleSystemWide(
 	)
 
 	// call PUT method
-	req, err := http.NewRequest(http.MethodPut, aggregatorURL, http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, aggregatorURL, http.NoBody)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set(contentTypeHeader, JSONContentType)
-	client := &http.Client{}
-	response, err := client.Do(req) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
+	response, err := aggregatorClient().Do(req) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
 	if err != nil {
 		return err
 	}
@@ -223,6 +233,7 @@ func (server *HTTPServer) deleteAckRuleSystemWide(
 
 // Method readListOfAckedRules reads all rules that has been acked system-wide
 func (server *HTTPServer) readListOfAckedRules(
+	ctx context.Context,
 	orgID types.OrgID,
 ) ([]types.SystemWideRuleDisable, error) {
 	// wont be used anywhere else
@@ -231,6 +242,32 @@ func (server *HTTPServer) readListOfAckedRules(
 		RuleDisable []types.SystemWideRuleDisable `json:"disabledRules"`
 	}
 
+	responseBytes, found := server.readFromResponseCache(orgID, ackedRulesCacheKind, "")
+	if !found {
+		var err error
+		responseBytes, err = server.readRawListOfAckedRules(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		server.storeToResponseCache(orgID, ackedRulesCacheKind, "", responseBytes)
+	}
+
+	var payload responsePayload
+
+	// decode the response payload
+	err := json.Unmarshal(responseBytes, &payload)
+	if err != nil {
+		err = errors.New("problem unmarshalling JSON response from aggregator endpoint")
+		return nil, err
+	}
+
+	log.Debug().Int("#rules", len(payload.RuleDisable)).Msg("Read disabled rules")
+	return payload.RuleDisable, nil
+}
+
+// Method readRawListOfAckedRules reads response with all rules that has been
+// acked system-wide from Insights Aggregator
+func (server *HTTPServer) readRawListOfAckedRules(ctx context.Context, orgID types.OrgID) ([]byte, error) {
 	// try to read rule list from Insights Aggregator
 	aggregatorURL := httputils.MakeURLToEndpoint(
 		server.ServicesConfig.AggregatorBaseEndpoint,
@@ -239,7 +276,7 @@ func (server *HTTPServer) readListOfAckedRules(
 	)
 
 	// #nosec G107
-	response, err := http.Get(aggregatorURL) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
+	response, err := aggregatorGet(ctx, aggregatorURL) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
 	if err != nil {
 		return nil, err
 	}
@@ -252,22 +289,13 @@ func (server *HTTPServer) readListOfAckedRules(
 		return nil, err
 	}
 
-	var payload responsePayload
-
-	// decode the response payload
-	err = json.NewDecoder(response.Body).Decode(&payload)
-	if err != nil {
-		err = errors.New("problem unmarshalling JSON response from aggregator endpoint")
-		return nil, err
-	}
-
-	log.Debug().Int("#rules", len(payload.RuleDisable)).Msg("Read disabled rules")
-	return payload.RuleDisable, nil
+	return io.ReadAll(response.Body)
 }
 
 // readRuleDisableStatus method read system-wide rule disable status from
 // Insights Results Aggregator via REST API
 func (server *HTTPServer) readRuleDisableStatus(
+	ctx context.Context,
 	ruleID types.Component, errorKey types.ErrorKey,
 	orgID types.OrgID,
 ) (types.Acknowledgement, bool, error) {
@@ -287,7 +315,7 @@ func (server *HTTPServer) readRuleDisableStatus(
 	)
 
 	// #nosec G107
-	response, err := http.Get(aggregatorURL) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
+	response, err := aggregatorGet(ctx, aggregatorURL) //nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
 	if err != nil {
 		return acknowledgement, false, err
 	}
@@ -335,14 +363,14 @@ func logFullRuleSelector(orgID types.OrgID, ruleID types.RuleID, errorKey types.
 }
 
 // prepareAckList converts data to format accepted by Insights Advisor
-func prepareAckList(acks []types.SystemWideRuleDisable) types.AcknowledgementsResponse {
-	var responseBody types.AcknowledgementsResponse
+func prepareAckList(acks []types.SystemWideRuleDisable, expirations ackexpiry.Expirations) sptypes.AcknowledgementsResponse {
+	var responseBody sptypes.AcknowledgementsResponse
 
 	// fill-in metadata part of response body
 	responseBody.Metadata.Count = len(acks)
 
 	// fill-in data part of response body
-	responseBody.Data = make([]types.Acknowledgement, len(acks))
+	responseBody.Data = make([]sptypes.Acknowledgement, len(acks))
 
 	// perform conversion item-by-item
 	i := 0
@@ -353,7 +381,7 @@ func prepareAckList(acks []types.SystemWideRuleDisable) types.AcknowledgementsRe
 		acknowledgement.CreatedBy = string(ack.UserID)
 		acknowledgement.CreatedAt = formatNullTime(ack.CreatedAt)
 		acknowledgement.UpdatedAt = formatNullTime(ack.UpdatedAT)
-		responseBody.Data[i] = acknowledgement
+		responseBody.Data[i] = withExpiration(acknowledgement, expirations)
 		i++
 	}
 
@@ -0,0 +1,186 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	ira_types "github.com/RedHatInsights/insights-results-aggregator/types"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// defaultAuditPageSize is the number of audit events returned when
+	// the limit is not specified by client
+	defaultAuditPageSize = 100
+	// maxAuditPageSize is the maximum number of audit events returned in
+	// one page
+	maxAuditPageSize = 1000
+)
+
+// names of votes used in audit events
+const (
+	auditVoteLike    = "like"
+	auditVoteDislike = "dislike"
+	auditVoteNone    = "none"
+)
+
+// auditVotes maps votes on rules to their names used in audit events
+var auditVotes = map[types.UserVote]string{
+	types.UserVoteLike:    auditVoteLike,
+	types.UserVoteDislike: auditVoteDislike,
+	types.UserVoteNone:    auditVoteNone,
+}
+
+// SetAuditStore method sets the store with audit events
+func (server *HTTPServer) SetAuditStore(store audit.Store) {
+	server.auditStore = store
+}
+
+// recordAuditEvent method stores the event into the audit log. The change
+// has been made already, so failures are only logged.
+func (server *HTTPServer) recordAuditEvent(event *audit.Event) {
+	if server.auditStore == nil {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	if err := server.auditStore.Record(event); err != nil {
+		log.Error().Err(err).
+			Str("action", string(event.Action)).
+			Uint32(orgIDTag, uint32(event.OrgID)).
+			Msg("Unable to record audit event")
+	}
+}
+
+// recordAuditRequest method stores the event made by user authenticated in
+// the request into the audit log
+func (server *HTTPServer) recordAuditRequest(request *http.Request, event *audit.Event) {
+	if server.auditStore == nil {
+		return
+	}
+
+	// user ID is not mandatory in all tokens, the event is useful anyway
+	event.UserID, _ = server.GetCurrentUserID(request)
+	server.recordAuditEvent(event)
+}
+
+// auditingProxy method records the change of rule for cluster proxied to
+// Insights Aggregator. Organization, user, cluster and rule are filled into
+// the event template from the request. The event is recorded only when
+// aggregator accepted the change. Justification of rule disabled for cluster
+// is read from the request payload before it is proxied.
+func (server *HTTPServer) auditingProxy(template audit.Event, handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if server.auditStore == nil || request.Method == http.MethodOptions {
+			handler(writer, request)
+			return
+		}
+
+		event := template
+		if event.Action == audit.ClusterRuleJustified && request.Body != nil {
+			body, err := io.ReadAll(request.Body)
+			if err != nil {
+				handleServerError(writer, err)
+				return
+			}
+			var feedback ira_types.FeedbackRequest
+			if json.Unmarshal(body, &feedback) == nil {
+				event.NewJustification = feedback.Message
+			}
+			request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		recorder := &responseWriter{ResponseWriter: writer, statusCode: http.StatusOK}
+		handler(recorder, request)
+
+		if recorder.statusCode != http.StatusOK {
+			return
+		}
+
+		orgID, err := server.GetCurrentOrgID(request)
+		if err != nil {
+			log.Debug().Err(err).Msg("Unable to get organization for audit event")
+			return
+		}
+
+		vars := mux.Vars(request)
+		event.OrgID = orgID
+		event.RuleSelector = types.RuleSelector(vars[RuleIDParamName] + "|" + vars["error_key"])
+		event.ClusterID = types.ClusterName(vars["cluster"])
+		server.recordAuditRequest(request, &event)
+	}
+}
+
+// getAuditEvents method returns page of audit events of the organization
+// ordered from the newest one
+func (server *HTTPServer) getAuditEvents(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	if server.auditStore == nil {
+		if err := responses.SendNotFound(writer, "Audit log is disabled"); err != nil {
+			logger.Error().Err(err).Msg(responseDataError)
+		}
+		return
+	}
+
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg(orgIDTokenError)
+		handleServerError(writer, err)
+		return
+	}
+
+	pagination, err := readListPagination(request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+	if pagination.limit == 0 {
+		pagination.limit = defaultAuditPageSize
+	}
+	pagination.limit = min(pagination.limit, maxAuditPageSize)
+
+	events, total, err := server.auditStore.List(orgID, pagination.offset, pagination.limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read audit events")
+		handleServerError(writer, err)
+		return
+	}
+
+	resp := make(map[string]interface{})
+	resp["status"] = OkMsg
+	resp["meta"] = types.ListMeta{
+		Count:  len(events),
+		Total:  total,
+		Limit:  pagination.limit,
+		Offset: pagination.offset,
+	}
+	resp["events"] = events
+
+	if err = responses.SendOK(writer, resp); err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
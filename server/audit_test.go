@@ -0,0 +1,263 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// ruleSelector is selector of rule1 recorded into audit log
+var ruleSelector = types.RuleSelector(testdata.Rule1CompositeID)
+
+// auditTestServer constructs server with in-memory audit store
+func auditTestServer() (*server.HTTPServer, audit.Store) {
+	store := audit.NewMemoryStore(audit.DefaultMaxEvents)
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetAuditStore(store)
+	return testServer, store
+}
+
+// recordedEvents returns all events of the test organization without their
+// time
+func recordedEvents(t *testing.T, store audit.Store) []audit.Event {
+	events, _, err := store.List(testdata.OrgID, 0, audit.DefaultMaxEvents)
+	helpers.FailOnError(t, err)
+	for i := range events {
+		assert.False(t, events[i].Time.IsZero())
+		events[i].Time = time.Time{}
+	}
+	return events
+}
+
+// expectReadAckedRule mocks reading of acked rule1 from aggregator
+func expectReadAckedRule(t *testing.T, justification string) {
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ReadRuleSystemWide,
+		EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(`{
+			"disabledRule": {
+				"rule_id": "%v",
+				"error_key": "%v",
+				"justification": "%v",
+				"created_at": {"Time": "%v", "Valid": true}
+			},
+			"status": "ok"
+		}`, testdata.Rule1ID, testdata.ErrorKey1, justification, createdAt),
+	})
+}
+
+// TestAuditEndpointDisabled checks that audit endpoint is not available when
+// the audit log is disabled
+func TestAuditEndpointDisabled(t *testing.T) {
+	helpers.AssertAPIv2Request(t, nil, nil, nil, nil, nil, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.AuditEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusNotFound,
+		Body:       `{"status": "Audit log is disabled"}`,
+	})
+}
+
+// TestAuditEndpoint checks that events are returned from the newest one
+func TestAuditEndpoint(t *testing.T) {
+	testServer, store := auditTestServer()
+	eventTime := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, justification := range []string{"first", "second", "third"} {
+		helpers.FailOnError(t, store.Record(&audit.Event{
+			Time:             eventTime,
+			Action:           audit.AckCreated,
+			OrgID:            testdata.OrgID,
+			UserID:           testdata.UserID,
+			RuleSelector:     ruleSelector,
+			NewJustification: justification,
+		}))
+	}
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.AuditEndpoint + "?limit=1&offset=1",
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(`{
+			"status": "ok",
+			"meta": {"count": 1, "total": 3, "limit": 1, "offset": 1},
+			"events": [
+				{
+					"time": "2026-01-01T10:00:00Z",
+					"action": "ack_created",
+					"org_id": %v,
+					"user_id": "%v",
+					"rule_selector": "%v",
+					"new_justification": "second"
+				}
+			]
+		}`, testdata.OrgID, testdata.UserID, testdata.Rule1CompositeID),
+	})
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.AuditEndpoint + "?limit=-1",
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+		Body: `{
+			"status": "Error during parsing param 'limit' with value '-1'. Error: 'non-negative integer expected'",
+			"request_id": "test-request-id"
+		}`,
+	})
+}
+
+// TestAuditAcknowledgeUpdateAndDelete checks that changes of acks are
+// recorded together with the previous justification
+func TestAuditAcknowledgeUpdateAndDelete(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	testServer, store := auditTestServer()
+	ruleArgs := []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID}
+
+	expectReadAckedRule(t, "old")
+	expectAggregatorCall(t, http.MethodPost, ira_server.UpdateRuleSystemWide, ruleArgs, `{"justification":"new"}`, http.StatusOK)
+	expectReadAckedRule(t, "new")
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodPut,
+		Endpoint:     server.AckUpdateEndpoint,
+		EndpointArgs: []interface{}{testdata.Rule1CompositeID},
+		XRHIdentity:  goodXRHAuthToken,
+		Body:         `{"justification": "new"}`,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+
+	expectReadAckedRule(t, "new")
+	expectAggregatorCall(t, http.MethodPut, ira_server.EnableRuleSystemWide, ruleArgs, nil, http.StatusOK)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodDelete,
+		Endpoint:     server.AckDeleteEndpoint,
+		EndpointArgs: []interface{}{testdata.Rule1CompositeID},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusNoContent,
+	})
+
+	assert.Equal(t, []audit.Event{
+		{
+			Action:           audit.AckDeleted,
+			OrgID:            testdata.OrgID,
+			UserID:           testdata.UserID,
+			RuleSelector:     ruleSelector,
+			OldJustification: "new",
+		},
+		{
+			Action:           audit.AckUpdated,
+			OrgID:            testdata.OrgID,
+			UserID:           testdata.UserID,
+			RuleSelector:     ruleSelector,
+			OldJustification: "old",
+			NewJustification: "new",
+		},
+	}, recordedEvents(t, store))
+}
+
+// TestAuditClusterRuleChanges checks that changes of rules for clusters
+// proxied to aggregator are recorded only when they are accepted
+func TestAuditClusterRuleChanges(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+	helpers.FailOnError(t, err)
+
+	testServer, store := auditTestServer()
+	clusterArgs := []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1}
+
+	expectAggregatorCall(t, http.MethodPut, ira_server.DisableRuleForClusterEndpoint,
+		append(clusterArgs, testdata.OrgID), nil, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPost, ira_server.DisableRuleFeedbackEndpoint,
+		append(clusterArgs, testdata.OrgID, testdata.UserID), `{"message":"not needed"}`, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPut, ira_server.LikeRuleEndpoint,
+		append(clusterArgs, testdata.OrgID, testdata.UserID), nil, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPut, ira_server.EnableRuleForClusterEndpoint,
+		append(clusterArgs, testdata.OrgID), nil, http.StatusInternalServerError)
+
+	for _, request := range []struct {
+		method     string
+		endpoint   string
+		body       interface{}
+		statusCode int
+	}{
+		{http.MethodPut, server.DisableRuleForClusterEndpoint, nil, http.StatusOK},
+		{http.MethodPost, server.DisableRuleFeedbackEndpoint, `{"message":"not needed"}`, http.StatusOK},
+		{http.MethodPut, server.LikeRuleEndpoint, nil, http.StatusOK},
+		{http.MethodPut, server.EnableRuleForClusterEndpoint, nil, http.StatusInternalServerError},
+	} {
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:       request.method,
+			Endpoint:     request.endpoint,
+			EndpointArgs: clusterArgs,
+			XRHIdentity:  goodXRHAuthToken,
+			Body:         request.body,
+		}, &helpers.APIResponse{
+			StatusCode: request.statusCode,
+		})
+	}
+
+	cluster := types.ClusterName(testdata.ClusterName)
+	assert.Equal(t, []audit.Event{
+		{
+			Action:       audit.VoteChanged,
+			OrgID:        testdata.OrgID,
+			UserID:       testdata.UserID,
+			RuleSelector: ruleSelector,
+			ClusterID:    cluster,
+			Vote:         "like",
+		},
+		{
+			Action:           audit.ClusterRuleJustified,
+			OrgID:            testdata.OrgID,
+			UserID:           testdata.UserID,
+			RuleSelector:     ruleSelector,
+			ClusterID:        cluster,
+			NewJustification: "not needed",
+		},
+		{
+			Action:       audit.ClusterRuleDisabled,
+			OrgID:        testdata.OrgID,
+			UserID:       testdata.UserID,
+			RuleSelector: ruleSelector,
+			ClusterID:    cluster,
+		},
+	}, recordedEvents(t, store))
+}
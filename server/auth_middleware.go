@@ -25,6 +25,7 @@ import (
 	"github.com/RedHatInsights/insights-operator-utils/collections"
 	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
 	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
 	types "github.com/RedHatInsights/insights-results-types"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
@@ -45,6 +46,10 @@ func (server *HTTPServer) setupAuthMiddleware(router *mux.Router) {
 	openAPIv2URL := server.Config.APIv2Prefix + filepath.Base(server.Config.APIv2SpecFile)
 	infoV1URL := apiPrefix + InfoEndpoint
 	infoV2URL := server.Config.APIv2Prefix + InfoEndpoint
+	liveV1URL := apiPrefix + LivenessEndpoint
+	liveV2URL := server.Config.APIv2Prefix + LivenessEndpoint
+	readyV1URL := apiPrefix + ReadinessEndpoint
+	readyV2URL := server.Config.APIv2Prefix + ReadinessEndpoint
 
 	// Define noAuthURLs for use in authentication and authorization middleware
 	noAuthURLs := []string{
@@ -53,6 +58,10 @@ func (server *HTTPServer) setupAuthMiddleware(router *mux.Router) {
 		openAPIv2URL,
 		infoV1URL,
 		infoV2URL,
+		liveV1URL,
+		liveV2URL,
+		readyV1URL,
+		readyV2URL,
 		metricsURL + "?",   // to be able to test using Frisby
 		openAPIv1URL + "?", // to be able to test using Frisby
 		openAPIv2URL + "?", // to be able to test using Frisby
@@ -108,6 +117,9 @@ func (server *HTTPServer) Authentication(next http.Handler, noAuthURLs []string)
 			tk.Identity.User.UserID = "0"
 		}
 
+		tracing.SetAttributes(r.Context(), tracing.OrgID(tk.Identity.OrgID))
+		setRequestOrgID(r.Context(), tk.Identity.OrgID)
+
 		// Everything went well, proceed with the request and set the
 		// caller to the user retrieved from the parsed token
 		ctx := context.WithValue(r.Context(), types.ContextKeyUser, tk.Identity)
@@ -228,8 +228,9 @@ func TestUnsupportedAuthType(t *testing.T) {
 // Tests for authorization middleware
 // MockRBACClient is a mock implementation of the RBAC client for testing
 type MockRBACClient struct {
-	authorized bool
-	enforcing  bool
+	authorized       bool
+	enforcing        bool
+	healthCheckError error
 }
 
 func (m *MockRBACClient) IsAuthorized(token string) bool {
@@ -240,6 +241,10 @@ func (m *MockRBACClient) IsEnforcing() bool {
 	return m.enforcing
 }
 
+func (m *MockRBACClient) HealthCheck(context.Context) error {
+	return m.healthCheckError
+}
+
 func TestAuthorizationMiddleware(t *testing.T) {
 	testCases := []struct {
 		name           string
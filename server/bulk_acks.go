@@ -0,0 +1,393 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/parsers"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ira_types "github.com/RedHatInsights/insights-results-aggregator/types"
+	types "github.com/RedHatInsights/insights-results-types"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+)
+
+const (
+	// MaxBulkItems is the maximum number of rule selectors and cluster
+	// disables accepted in one bulk request
+	MaxBulkItems = 1000
+
+	// defaultBulkConcurrency is used when the number of concurrent requests
+	// to aggregator is not configured
+	defaultBulkConcurrency = 10
+
+	// BulkItemCreated means that the rule has been acknowledged by the
+	// bulk request
+	BulkItemCreated = "created"
+	// BulkItemUpdated means that the rule has been acknowledged already and
+	// its justification has been updated
+	BulkItemUpdated = "updated"
+	// BulkItemDisabled means that the rule has been disabled for the
+	// cluster
+	BulkItemDisabled = "disabled"
+	// BulkItemFailed means that the item could not be processed, the reason
+	// is stored in the error attribute
+	BulkItemFailed = "failed"
+)
+
+// BulkClusterDisable represents one rule to be disabled for one cluster
+type BulkClusterDisable struct {
+	ClusterID    types.ClusterName  `json:"cluster"`
+	RuleSelector types.RuleSelector `json:"rule_selector"`
+}
+
+// BulkAckRequest represents payload of bulk acknowledge request. Rules
+// listed in rule_selectors are acknowledged for all clusters, rules listed
+// in cluster_disables are disabled just for given clusters. The
+// justification is used for all items, the optional expiration is used for
+// all acknowledgements.
+type BulkAckRequest struct {
+	Justification   string               `json:"justification"`
+	ExpiresAt       *time.Time           `json:"expires_at,omitempty"`
+	RuleSelectors   []types.RuleSelector `json:"rule_selectors"`
+	ClusterDisables []BulkClusterDisable `json:"cluster_disables"`
+}
+
+// BulkItemResult represents result of processing one item of bulk request
+type BulkItemResult struct {
+	RuleSelector types.RuleSelector `json:"rule_selector"`
+	ClusterID    types.ClusterName  `json:"cluster,omitempty"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error,omitempty"`
+}
+
+// readBulkAckRequest function reads and checks payload of bulk acknowledge
+// request
+func readBulkAckRequest(request *http.Request) (BulkAckRequest, error) {
+	var bulkRequest BulkAckRequest
+
+	if request.ContentLength == 0 {
+		return bulkRequest, &NoBodyError{}
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&bulkRequest)
+	if err != nil {
+		return bulkRequest, err
+	}
+
+	itemsCount := len(bulkRequest.RuleSelectors) + len(bulkRequest.ClusterDisables)
+	if itemsCount == 0 {
+		return bulkRequest, &BadBodyContent{}
+	}
+	if itemsCount > MaxBulkItems {
+		return bulkRequest, &TooManyBulkItemsError{}
+	}
+
+	return bulkRequest, nil
+}
+
+// forEachConcurrently function calls the function for all indexes lower than
+// count. At most concurrency calls are running at the same time.
+func forEachConcurrently(count, concurrency int, call func(i int)) {
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			call(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// failedBulkItem function constructs result of item that could not be
+// processed
+func failedBulkItem(result BulkItemResult, err error) BulkItemResult {
+	result.Status = BulkItemFailed
+	result.Error = err.Error()
+	return result
+}
+
+// ackedRuleSelectors function converts list of acked rules to map of their
+// rule selectors to justifications
+func ackedRuleSelectors(acks []types.SystemWideRuleDisable) map[types.RuleSelector]string {
+	selectors := make(map[types.RuleSelector]string, len(acks))
+	for i := range acks {
+		selector := types.RuleSelector(fmt.Sprintf("%v|%v", acks[i].RuleID, acks[i].ErrorKey))
+		selectors[selector] = acks[i].Justification
+	}
+	return selectors
+}
+
+// bulkAckRule method acknowledges one rule system-wide. The justification is
+// updated for rules that have been acknowledged already. Expiration is
+// handled the same way as by the ack endpoint.
+func (server *HTTPServer) bulkAckRule(
+	ctx context.Context, orgID types.OrgID, userID types.UserID, selector types.RuleSelector,
+	justification string, expiresAt *time.Time, acked map[types.RuleSelector]string,
+) BulkItemResult {
+	result := BulkItemResult{RuleSelector: selector}
+
+	ruleID, errorKey, err := parsers.ParseRuleSelector(selector)
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	// expiration is stored before the rule is acked so the ack can't
+	// become permanent by accident, stale expiration is removed from new
+	// permanent ack
+	_, previouslyAcked := acked[selector]
+	err = server.storeAckExpiration(orgID, ackRuleSelector(types.RuleID(ruleID), errorKey), expiresAt, previouslyAcked)
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	event := audit.Event{
+		OrgID:            orgID,
+		UserID:           userID,
+		RuleSelector:     selector,
+		NewJustification: justification,
+	}
+	if oldJustification, found := acked[selector]; found {
+		err = server.updateAckRuleSystemWide(ctx, ruleID, errorKey, orgID, justification)
+		result.Status = BulkItemUpdated
+		event.Action = audit.AckUpdated
+		event.OldJustification = oldJustification
+	} else {
+		err = server.ackRuleSystemWide(ctx, ruleID, errorKey, orgID, justification)
+		result.Status = BulkItemCreated
+		event.Action = audit.AckCreated
+	}
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	server.recordAuditEvent(&event)
+	return result
+}
+
+// bulkDisableRuleForCluster method disables one rule for one cluster via
+// Insights Aggregator REST API. The justification is stored as the disable
+// feedback.
+func (server *HTTPServer) bulkDisableRuleForCluster(
+	ctx context.Context, orgID types.OrgID, userID types.UserID,
+	item BulkClusterDisable, justification string,
+) BulkItemResult {
+	result := BulkItemResult{RuleSelector: item.RuleSelector, ClusterID: item.ClusterID}
+
+	clusterID, err := httputils.ValidateClusterName(string(item.ClusterID))
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	ruleID, errorKey, err := parsers.ParseRuleSelector(item.RuleSelector)
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	aggregatorURL := httputils.MakeURLToEndpoint(
+		server.ServicesConfig.AggregatorBaseEndpoint,
+		ira_server.DisableRuleForClusterEndpoint,
+		clusterID, ruleID, errorKey, orgID,
+	)
+	err = callAggregator(ctx, http.MethodPut, aggregatorURL, nil)
+	if err != nil {
+		return failedBulkItem(result, err)
+	}
+
+	if justification != "" {
+		aggregatorURL = httputils.MakeURLToEndpoint(
+			server.ServicesConfig.AggregatorBaseEndpoint,
+			ira_server.DisableRuleFeedbackEndpoint,
+			clusterID, ruleID, errorKey, orgID, userID,
+		)
+		err = callAggregator(ctx, http.MethodPost, aggregatorURL, ira_types.FeedbackRequest{Message: justification})
+		if err != nil {
+			return failedBulkItem(result, err)
+		}
+	}
+
+	server.recordAuditEvent(&audit.Event{
+		Action:           audit.ClusterRuleDisabled,
+		OrgID:            orgID,
+		UserID:           userID,
+		RuleSelector:     item.RuleSelector,
+		ClusterID:        clusterID,
+		NewJustification: justification,
+	})
+
+	result.Status = BulkItemDisabled
+	return result
+}
+
+// callAggregator function sends PUT or POST request with optional JSON
+// payload to Insights Aggregator and checks the response code
+func callAggregator(ctx context.Context, method, aggregatorURL string, payload interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return err
+		}
+	}
+
+	send := aggregatorPut
+	if method == http.MethodPost {
+		send = aggregatorPost
+	}
+
+	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
+	response, err := send(ctx, aggregatorURL, &body)
+	if err != nil {
+		return err
+	}
+	defer services.CloseResponseBody(response)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(aggregatorImproperCodeMessage, response.StatusCode)
+	}
+
+	return nil
+}
+
+// bulkAcknowledge method acknowledges many rules and disables many rules for
+// selected clusters in one request. Items are processed by aggregator
+// concurrently and result of each item is returned, so partial failures are
+// visible to the client.
+//
+// An example request:
+//
+//	{
+//	  "justification": "string",
+//	  "rule_selectors": ["rule.module|ERROR_KEY"],
+//	  "cluster_disables": [
+//	    {"cluster": "uuid", "rule_selector": "rule.module|ERROR_KEY"}
+//	  ]
+//	}
+//
+// An example response:
+//
+//	{
+//	  "meta": {"count": 2, "failed": 0},
+//	  "acks": [{"rule_selector": "rule.module|ERROR_KEY", "status": "created"}],
+//	  "cluster_disables": [
+//	    {"rule_selector": "rule.module|ERROR_KEY", "cluster": "uuid", "status": "disabled"}
+//	  ],
+//	  "status": "ok"
+//	}
+func (server *HTTPServer) bulkAcknowledge(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+
+	orgID, userID, err := server.GetCurrentOrgIDUserIDFromToken(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	bulkRequest, err := readBulkAckRequest(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Improper bulk acknowledge request")
+		handleServerError(writer, err)
+		return
+	}
+
+	// expiration is optional, but it needs to be usable when provided
+	err = server.checkAckExpiration(bulkRequest.ExpiresAt)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Improper expiration of acknowledgements")
+		handleServerError(writer, err)
+		return
+	}
+
+	acked := map[types.RuleSelector]string{}
+	if len(bulkRequest.RuleSelectors) > 0 {
+		acks, err := server.readListOfAckedRules(request.Context(), orgID)
+		if err != nil {
+			logger.Error().Err(err).Msg(ackedRulesError)
+			handleServerError(writer, err)
+			return
+		}
+		acked = ackedRuleSelectors(acks)
+	}
+
+	concurrency := server.Config.BulkConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	ackResults := make([]BulkItemResult, len(bulkRequest.RuleSelectors))
+	disableResults := make([]BulkItemResult, len(bulkRequest.ClusterDisables))
+
+	// results are stored by index, so no locking is needed
+	forEachConcurrently(len(ackResults)+len(disableResults), concurrency, func(i int) {
+		if i < len(ackResults) {
+			ackResults[i] = server.bulkAckRule(
+				request.Context(), orgID, userID, bulkRequest.RuleSelectors[i],
+				bulkRequest.Justification, bulkRequest.ExpiresAt, acked,
+			)
+			return
+		}
+		i -= len(ackResults)
+		disableResults[i] = server.bulkDisableRuleForCluster(
+			request.Context(), orgID, userID, bulkRequest.ClusterDisables[i], bulkRequest.Justification,
+		)
+	})
+
+	failed := 0
+	for _, results := range [][]BulkItemResult{ackResults, disableResults} {
+		for i := range results {
+			if results[i].Status == BulkItemFailed {
+				failed++
+				logger.Warn().
+					Str("ruleSelector", string(results[i].RuleSelector)).
+					Str("cluster", string(results[i].ClusterID)).
+					Str("error", results[i].Error).
+					Msg("Bulk acknowledge item failed")
+			}
+		}
+	}
+
+	logger.Info().
+		Int("count", len(ackResults)+len(disableResults)).
+		Int("failed", failed).
+		Msg("Bulk acknowledge request processed")
+
+	response := responses.BuildOkResponse()
+	response["meta"] = map[string]int{
+		"count":  len(ackResults) + len(disableResults),
+		"failed": failed,
+	}
+	response["acks"] = ackResults
+	response["cluster_disables"] = disableResults
+
+	err = responses.SendOK(writer, response)
+	if err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
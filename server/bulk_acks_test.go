@@ -0,0 +1,159 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+const bulkJustification = "rolled out by policy"
+
+// expectAggregatorCall mocks one call to aggregator that changes data
+func expectAggregatorCall(t testing.TB, method, endpoint string, args []interface{}, body interface{}, statusCode int) {
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       method,
+		Endpoint:     endpoint,
+		EndpointArgs: args,
+		Body:         body,
+	}, &helpers.APIResponse{
+		StatusCode: statusCode,
+	})
+}
+
+// TestBulkAcknowledge checks that all items of bulk request are sent to
+// aggregator and that failures of individual items are reported
+func TestBulkAcknowledge(t *testing.T) {
+	defer helpers.CleanAfterGock(t)
+
+	// requests to aggregator have to be sent in the order expected by gock
+	config := serverConfigXRH
+	config.BulkConcurrency = 1
+
+	// rule1 has been acked already
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+		EndpointArgs: []interface{}{testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(
+			`{"disabledRules":[{"rule_id":"%v","error_key":"%v","justification":"old"}],"status":"ok"}`,
+			testdata.Rule1ID, testdata.ErrorKey1,
+		),
+	})
+
+	justificationBody := fmt.Sprintf(`{"justification":"%v"}`, bulkJustification)
+	expectAggregatorCall(t, http.MethodPost, ira_server.UpdateRuleSystemWide,
+		[]interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID}, justificationBody, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPut, ira_server.DisableRuleSystemWide,
+		[]interface{}{testdata.Rule2ID, testdata.ErrorKey2, testdata.OrgID}, justificationBody, http.StatusOK)
+
+	expectAggregatorCall(t, http.MethodPut, ira_server.DisableRuleForClusterEndpoint,
+		[]interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID}, nil, http.StatusOK)
+	expectAggregatorCall(t, http.MethodPost, ira_server.DisableRuleFeedbackEndpoint,
+		[]interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID, userIDInGoodAuthToken},
+		fmt.Sprintf(`{"message":"%v"}`, bulkJustification), http.StatusOK)
+	expectAggregatorCall(t, http.MethodPut, ira_server.DisableRuleForClusterEndpoint,
+		[]interface{}{testdata.ClusterName, testdata.Rule2ID, testdata.ErrorKey2, testdata.OrgID}, nil, http.StatusInternalServerError)
+
+	reqBody := fmt.Sprintf(`{
+		"justification": "%v",
+		"rule_selectors": ["%v", "%v", "not-a-selector"],
+		"cluster_disables": [
+			{"cluster": "%v", "rule_selector": "%v"},
+			{"cluster": "%v", "rule_selector": "%v"},
+			{"cluster": "not-a-cluster", "rule_selector": "%v"}
+		]
+	}`, bulkJustification, testdata.Rule1CompositeID, testdata.Rule2CompositeID,
+		testdata.ClusterName, testdata.Rule1CompositeID,
+		testdata.ClusterName, testdata.Rule2CompositeID,
+		testdata.Rule1CompositeID,
+	)
+
+	expectedBody := fmt.Sprintf(`{
+		"status": "ok",
+		"meta": {"count": 6, "failed": 3},
+		"acks": [
+			{"rule_selector": "%v", "status": "updated"},
+			{"rule_selector": "%v", "status": "created"},
+			{"rule_selector": "not-a-selector", "status": "failed", "error": "%v"}
+		],
+		"cluster_disables": [
+			{"rule_selector": "%v", "cluster": "%v", "status": "disabled"},
+			{"rule_selector": "%v", "cluster": "%v", "status": "failed", "error": "aggregator responded with improper HTTP code: 500"},
+			{"rule_selector": "%v", "cluster": "not-a-cluster", "status": "failed", "error": "%v"}
+		]
+	}`, testdata.Rule1CompositeID, testdata.Rule2CompositeID, "invalid rule ID, it must contain only rule ID and error key separated by |",
+		testdata.Rule1CompositeID, testdata.ClusterName,
+		testdata.Rule2CompositeID, testdata.ClusterName,
+		testdata.Rule1CompositeID, "Error during parsing param 'cluster' with value 'not-a-cluster'. Error: 'invalid UUID length: 13'",
+	)
+
+	helpers.AssertAPIv2Request(t, &config, nil, nil, nil, nil, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.AckBulkEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+		Body:        reqBody,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       expectedBody,
+	})
+}
+
+// TestBulkAcknowledgeBadRequest checks that improper bulk requests are
+// refused without calling aggregator
+func TestBulkAcknowledgeBadRequest(t *testing.T) {
+	tooManySelectors := make([]string, server.MaxBulkItems+1)
+	for i := range tooManySelectors {
+		tooManySelectors[i] = fmt.Sprintf(`"rule%d|KEY"`, i)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		body         string
+		expectedBody string
+	}{
+		{"empty body", "", `{"request_id":"test-request-id","status":"client didn't provide request body"}`},
+		{"no items", `{"justification": "x"}`, `{"request_id":"test-request-id","status":"client didn't provide a valid request body"}`},
+		{"not JSON", "not JSON", `{"request_id":"test-request-id","status":"invalid character 'o' in literal null (expecting 'u')"}`},
+		{
+			"too many items",
+			`{"rule_selectors": [` + strings.Join(tooManySelectors, ",") + `]}`,
+			fmt.Sprintf(`{"request_id":"test-request-id","status":"the maximum amount of items allowed in bulk request is %d"}`, server.MaxBulkItems),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			helpers.AssertAPIv2Request(t, &serverConfigXRH, nil, nil, nil, nil, &helpers.APIRequest{
+				Method:       http.MethodPost,
+				Endpoint:     server.AckBulkEndpoint,
+				XRHIdentity:  goodXRHAuthToken,
+				Body:         tc.body,
+				ExtraHeaders: requestIDHeader,
+			}, &helpers.APIResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       tc.expectedBody,
+			})
+		})
+	}
+}
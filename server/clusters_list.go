@@ -0,0 +1,192 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"cmp"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// ManagedParam parameter used to return only managed or only
+	// non-managed clusters
+	ManagedParam = "managed"
+	// VersionParam parameter with prefix of version of returned clusters
+	VersionParam = "version"
+	// ClusterNameParam parameter with text searched in display name of
+	// clusters
+	ClusterNameParam = "cluster_name"
+	// HitsTotalRiskParam parameter with total risk of hitting
+	// recommendations counted by HitsMinParam
+	HitsTotalRiskParam = "hits_total_risk"
+	// HitsMinParam parameter with the minimum number of hitting
+	// recommendations of returned clusters. Only recommendations with total
+	// risk given by HitsTotalRiskParam are counted, if provided
+	HitsMinParam = "hits_min"
+)
+
+// clusterComparators contains fields the clusters list can be sorted by
+var clusterComparators = listComparators[types.ClusterListView]{
+	"last_checked_at": func(a, b *types.ClusterListView) int {
+		// timestamps are in RFC3339 format and can be compared as strings
+		return cmp.Compare(a.LastCheckedAt, b.LastCheckedAt)
+	},
+	"total_hit_count": func(a, b *types.ClusterListView) int {
+		return cmp.Compare(a.TotalHitCount, b.TotalHitCount)
+	},
+	"cluster_name": func(a, b *types.ClusterListView) int {
+		return compareStringsFold(a.ClusterName, b.ClusterName)
+	},
+	"cluster_version": func(a, b *types.ClusterListView) int {
+		return compareVersions(string(a.Version), string(b.Version))
+	},
+}
+
+// clusterFilter contains conditions clusters returned by /clusters endpoint
+// must meet
+type clusterFilter struct {
+	managed       *bool
+	versionPrefix string
+	name          string
+	hitsTotalRisk uint8
+	hitsMin       int
+}
+
+// clusterListParams contains parameters of /clusters endpoint that control
+// content of the returned list
+type clusterListParams struct {
+	filter     clusterFilter
+	sorting    listSorting
+	pagination listPagination
+}
+
+// compareVersions function compares versions numerically by their dot
+// separated parts, so 4.10 is greater than 4.9. Parts that are not numbers
+// are compared as strings.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+
+		var result int
+		if aErr == nil && bErr == nil {
+			result = cmp.Compare(aNumber, bNumber)
+		} else {
+			result = cmp.Compare(aParts[i], bParts[i])
+		}
+		if result != 0 {
+			return result
+		}
+	}
+
+	return cmp.Compare(len(aParts), len(bParts))
+}
+
+// readClusterFilter returns filter constructed from the parameters in query
+func readClusterFilter(request *http.Request) (filter clusterFilter, err error) {
+	query := request.URL.Query()
+
+	filter.versionPrefix = query.Get(VersionParam)
+	filter.name = strings.ToLower(query.Get(ClusterNameParam))
+
+	if value := query.Get(ManagedParam); value != "" {
+		managed, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			err = &RouterParsingError{
+				ParamName:  ManagedParam,
+				ParamValue: value,
+				ErrString:  "Unparsable boolean value",
+			}
+			return
+		}
+		filter.managed = &managed
+	}
+
+	filter.hitsTotalRisk, err = readTotalRiskParam(HitsTotalRiskParam, 0, request)
+	if err != nil {
+		return
+	}
+
+	filter.hitsMin, err = readNonNegativeIntParam(HitsMinParam, request)
+	if err != nil {
+		return
+	}
+	if filter.hitsTotalRisk != 0 && query.Get(HitsMinParam) == "" {
+		// clusters hit by at least one recommendation with given total
+		// risk are requested
+		filter.hitsMin = 1
+	}
+	return
+}
+
+// readClusterListParams reads filter, sorting and pagination of the
+// clusters list. If it's not possible, it writes http error to the writer
+// and returns error
+func readClusterListParams(writer http.ResponseWriter, request *http.Request) (
+	params clusterListParams, err error,
+) {
+	params.filter, err = readClusterFilter(request)
+	if err == nil {
+		params.sorting, err = readListSorting(request, clusterComparators)
+	}
+	if err == nil {
+		params.pagination, err = readListPagination(request)
+	}
+
+	if err != nil {
+		handleServerError(writer, err)
+	}
+	return
+}
+
+// matches method checks if the cluster meets all conditions of the filter
+func (filter *clusterFilter) matches(cluster *types.ClusterListView) bool {
+	if filter.managed != nil && cluster.Managed != *filter.managed {
+		return false
+	}
+
+	if !strings.HasPrefix(string(cluster.Version), filter.versionPrefix) {
+		return false
+	}
+
+	if filter.name != "" && !strings.Contains(strings.ToLower(cluster.ClusterName), filter.name) {
+		return false
+	}
+
+	hits := int(cluster.TotalHitCount)
+	if filter.hitsTotalRisk != 0 {
+		hits = cluster.HitsByTotalRisk[int(filter.hitsTotalRisk)]
+	}
+	return hits >= filter.hitsMin
+}
+
+// filterClusterList function returns clusters meeting all conditions of the
+// filter
+func filterClusterList(clusters []types.ClusterListView, filter *clusterFilter) []types.ClusterListView {
+	filtered := make([]types.ClusterListView, 0, len(clusters))
+	for i := range clusters {
+		if filter.matches(&clusters[i]) {
+			filtered = append(filtered, clusters[i])
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// clusterListChecker returns body checker that compares display names of
+// returned clusters including their order and the list metadata
+func clusterListChecker(expectedNames []string, expectedMeta types.ClusterListMeta) iou_helpers.BodyChecker {
+	return func(t testing.TB, _, got []byte) {
+		var resp struct {
+			Meta     types.ClusterListMeta   `json:"meta"`
+			Clusters []types.ClusterListView `json:"data"`
+		}
+		helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+		names := []string{}
+		for i := range resp.Clusters {
+			names = append(names, resp.Clusters[i].ClusterName)
+		}
+		assert.Equal(t, expectedNames, names)
+		assert.Equal(t, expectedMeta, resp.Meta)
+	}
+}
+
+// expectClustersView mocks responses from AMS and aggregator for three
+// clusters:
+//   - "alpha prod" hit by the first and the second rule,
+//   - managed "Beta" hit by the first rule,
+//   - "gamma prod" without any report.
+func expectClustersView(t testing.TB) *server.HTTPServer {
+	clusterInfoList := []types.ClusterInfo{
+		data.GetRandomClusterInfo(), data.GetRandomClusterInfo(), data.GetRandomClusterInfo(),
+	}
+	clusterInfoList[0].DisplayName, clusterInfoList[0].Managed = "alpha prod", false
+	clusterInfoList[1].DisplayName, clusterInfoList[1].Managed = "Beta", true
+	clusterInfoList[2].DisplayName, clusterInfoList[2].Managed = "gamma prod", false
+
+	clusterList := types.GetClusterNames(clusterInfoList)
+	reqBody, _ := json.Marshal(clusterList)
+
+	respBody := fmt.Sprintf(`{
+		"clusters": {
+			"%v": {
+				"created_at": "2026-01-02T10:00:00Z",
+				"meta": {"cluster_version": "4.10.3"},
+				"recommendations": ["%v", "%v"]
+			},
+			"%v": {
+				"created_at": "2026-01-01T10:00:00Z",
+				"meta": {"cluster_version": "4.9.1"},
+				"recommendations": ["%v"]
+			}
+		}
+	}`,
+		clusterList[0], testdata.Rule1CompositeID, testdata.Rule2CompositeID,
+		clusterList[1], testdata.Rule1CompositeID,
+	)
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+		&helpers.APIRequest{
+			Method:       http.MethodPost,
+			Endpoint:     ira_server.ClustersRecommendationsListEndpoint,
+			EndpointArgs: []interface{}{testdata.OrgID, userIDInGoodAuthToken},
+			Body:         reqBody,
+		},
+		&helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       respBody,
+		},
+	)
+
+	expectNoRulesDisabledSystemWide(&t, testdata.OrgID)
+	expectNoRulesDisabledPerCluster(&t, testdata.OrgID)
+
+	amsClientMock := helpers.AMSClientWithOrgResults(testdata.OrgID, clusterInfoList)
+	return helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
+}
+
+// TestHTTPServer_ClustersViewFilterSortPaginate checks that filters, sorting
+// and pagination are applied to the clusters list
+func TestHTTPServer_ClustersViewFilterSortPaginate(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{testdata.RuleContent1, testdata.RuleContent2},
+		),
+	)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedNames []string
+		expectedMeta  types.ClusterListMeta
+	}{
+		{
+			name:          "sorted by display name",
+			query:         "?sort=cluster_name",
+			expectedNames: []string{"alpha prod", "Beta", "gamma prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 3, Total: 3, Filtered: 3},
+		},
+		{
+			name:          "sorted by hits descending",
+			query:         "?sort=-total_hit_count",
+			expectedNames: []string{"alpha prod", "Beta", "gamma prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 3, Total: 3, Filtered: 3},
+		},
+		{
+			name:          "sorted by version",
+			query:         "?sort=cluster_version",
+			expectedNames: []string{"gamma prod", "Beta", "alpha prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 3, Total: 3, Filtered: 3},
+		},
+		{
+			name:          "sorted by last check with pagination",
+			query:         "?sort=-last_checked_at&limit=2&offset=1",
+			expectedNames: []string{"Beta", "gamma prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 2, Total: 3, Filtered: 3, Limit: 2, Offset: 1},
+		},
+		{
+			name:          "managed clusters",
+			query:         "?managed=true",
+			expectedNames: []string{"Beta"},
+			expectedMeta:  types.ClusterListMeta{Count: 1, Total: 3, Filtered: 1},
+		},
+		{
+			name:          "version prefix",
+			query:         "?version=4.1",
+			expectedNames: []string{"alpha prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 1, Total: 3, Filtered: 1},
+		},
+		{
+			name:          "display name",
+			query:         "?cluster_name=PROD&sort=-cluster_name",
+			expectedNames: []string{"gamma prod", "alpha prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 2, Total: 3, Filtered: 2},
+		},
+		{
+			name:          "hits with total risk",
+			query:         "?hits_total_risk=2",
+			expectedNames: []string{"alpha prod"},
+			expectedMeta:  types.ClusterListMeta{Count: 1, Total: 3, Filtered: 1},
+		},
+		{
+			name:          "minimum hits",
+			query:         "?hits_min=1&sort=cluster_name",
+			expectedNames: []string{"alpha prod", "Beta"},
+			expectedMeta:  types.ClusterListMeta{Count: 2, Total: 3, Filtered: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			helpers.RunTestWithTimeout(t, func(t testing.TB) {
+				defer helpers.CleanAfterGock(t)
+
+				testServer := expectClustersView(t)
+				iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+					Method:      http.MethodGet,
+					Endpoint:    server.ClustersRecommendationsEndpoint + tc.query,
+					XRHIdentity: goodXRHAuthToken,
+				}, &helpers.APIResponse{
+					StatusCode:  http.StatusOK,
+					Body:        "",
+					BodyChecker: clusterListChecker(tc.expectedNames, tc.expectedMeta),
+				})
+			}, testTimeout)
+		})
+	}
+}
+
+// TestHTTPServer_ClustersViewBadParams checks that improper list parameters
+// are refused
+func TestHTTPServer_ClustersViewBadParams(t *testing.T) {
+	for _, query := range []string{
+		"?limit=many",
+		"?sort=managed",
+		"?managed=maybe",
+		"?hits_total_risk=0",
+		"?hits_min=-2",
+	} {
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodGet,
+			Endpoint:    server.ClustersRecommendationsEndpoint + query,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusBadRequest,
+			Body:        "",
+			BodyChecker: func(testing.TB, []byte, []byte) {},
+		})
+	}
+}
@@ -17,25 +17,44 @@ limitations under the License.
 package server
 
 import (
+	"time"
+
 	types "github.com/RedHatInsights/insights-results-types"
 )
 
 // Configuration represents configuration of REST API HTTP server
 type Configuration struct {
-	Address                          string        `mapstructure:"address" toml:"address"`
-	APIdbgPrefix                     string        `mapstructure:"api_dbg_prefix" toml:"api_dbg_prefix"`
-	APIv1Prefix                      string        `mapstructure:"api_v1_prefix" toml:"api_v1_prefix"`
-	APIv2Prefix                      string        `mapstructure:"api_v2_prefix" toml:"api_v2_prefix"`
-	APIv1SpecFile                    string        `mapstructure:"api_v1_spec_file" toml:"api_v1_spec_file"`
-	APIv2SpecFile                    string        `mapstructure:"api_v2_spec_file" toml:"api_v2_spec_file"`
-	Debug                            bool          `mapstructure:"debug" toml:"debug"`
-	Auth                             bool          `mapstructure:"auth" toml:"auth"`
-	AuthType                         string        `mapstructure:"auth_type" toml:"auth_type"`
-	UseHTTPS                         bool          `mapstructure:"use_https" toml:"use_https"`
-	EnableCORS                       bool          `mapstructure:"enable_cors" toml:"enable_cors"`
-	EnableInternalRulesOrganizations bool          `mapstructure:"enable_internal_rules_organizations" toml:"enable_internal_rules_organizations"`
-	InternalRulesOrganizations       []types.OrgID `mapstructure:"internal_rules_organizations" toml:"internal_rules_organizations"`
-	LogAuthToken                     bool          `mapstructure:"log_auth_token" toml:"log_auth_token"`
-	UseOrgClustersFallback           bool          `mapstructure:"org_clusters_fallback" toml:"org_clusters_fallback"`
-	UseRBAC                          bool          `mapstructure:"use_rbac" toml:"use_rbac"`
+	Address                          string                   `mapstructure:"address" toml:"address"`
+	APIdbgPrefix                     string                   `mapstructure:"api_dbg_prefix" toml:"api_dbg_prefix"`
+	APIv1Prefix                      string                   `mapstructure:"api_v1_prefix" toml:"api_v1_prefix"`
+	APIv2Prefix                      string                   `mapstructure:"api_v2_prefix" toml:"api_v2_prefix"`
+	APIv1SpecFile                    string                   `mapstructure:"api_v1_spec_file" toml:"api_v1_spec_file"`
+	APIv2SpecFile                    string                   `mapstructure:"api_v2_spec_file" toml:"api_v2_spec_file"`
+	Debug                            bool                     `mapstructure:"debug" toml:"debug"`
+	Auth                             bool                     `mapstructure:"auth" toml:"auth"`
+	AuthType                         string                   `mapstructure:"auth_type" toml:"auth_type"`
+	UseHTTPS                         bool                     `mapstructure:"use_https" toml:"use_https"`
+	TLSCertFile                      string                   `mapstructure:"tls_cert_file" toml:"tls_cert_file"`
+	TLSKeyFile                       string                   `mapstructure:"tls_key_file" toml:"tls_key_file"`
+	TLSClientCAFile                  string                   `mapstructure:"tls_client_ca_file" toml:"tls_client_ca_file"`
+	TLSMinVersion                    string                   `mapstructure:"tls_min_version" toml:"tls_min_version"`
+	TLSCipherSuites                  []string                 `mapstructure:"tls_cipher_suites" toml:"tls_cipher_suites"`
+	EnableCORS                       bool                     `mapstructure:"enable_cors" toml:"enable_cors"`
+	EnableInternalRulesOrganizations bool                     `mapstructure:"enable_internal_rules_organizations" toml:"enable_internal_rules_organizations"`
+	InternalRulesOrganizations       []types.OrgID            `mapstructure:"internal_rules_organizations" toml:"internal_rules_organizations"`
+	LogAuthToken                     bool                     `mapstructure:"log_auth_token" toml:"log_auth_token"`
+	UseOrgClustersFallback           bool                     `mapstructure:"org_clusters_fallback" toml:"org_clusters_fallback"`
+	UseRBAC                          bool                     `mapstructure:"use_rbac" toml:"use_rbac"`
+	ShutdownDelay                    time.Duration            `mapstructure:"shutdown_delay" toml:"shutdown_delay"`
+	ShutdownTimeout                  time.Duration            `mapstructure:"shutdown_timeout" toml:"shutdown_timeout"`
+	ReadTimeout                      time.Duration            `mapstructure:"read_timeout" toml:"read_timeout"`
+	ReadHeaderTimeout                time.Duration            `mapstructure:"read_header_timeout" toml:"read_header_timeout"`
+	WriteTimeout                     time.Duration            `mapstructure:"write_timeout" toml:"write_timeout"`
+	IdleTimeout                      time.Duration            `mapstructure:"idle_timeout" toml:"idle_timeout"`
+	MaxHeaderBytes                   int                      `mapstructure:"max_header_bytes" toml:"max_header_bytes"`
+	MaxConnections                   int                      `mapstructure:"max_connections" toml:"max_connections"`
+	BulkConcurrency                  int                      `mapstructure:"bulk_concurrency" toml:"bulk_concurrency"`
+	RouteWriteTimeouts               map[string]time.Duration `mapstructure:"route_write_timeouts" toml:"route_write_timeouts"`
+	RequestStreamPollInterval        time.Duration            `mapstructure:"request_stream_poll_interval" toml:"request_stream_poll_interval"`
+	RequestStreamDuration            time.Duration            `mapstructure:"request_stream_duration" toml:"request_stream_duration"`
 }
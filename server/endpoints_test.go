@@ -98,8 +98,9 @@ func TestHTTPServer_ProxyTo_VoteEndpointBadCharacter(t *testing.T) {
 		UserID:       testdata.UserID,
 		OrgID:        testdata.OrgID,
 		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
 	}, &helpers.APIResponse{
 		StatusCode: http.StatusBadRequest,
-		Body:       `{"status":"the parameters contains invalid characters and cannot be used"}`,
+		Body:       `{"request_id":"test-request-id","status":"the parameters contains invalid characters and cannot be used"}`,
 	})
 }
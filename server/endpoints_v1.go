@@ -22,6 +22,8 @@ import (
 	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 )
 
 const (
@@ -83,6 +85,12 @@ const (
 	// InfoEndpoint returns basic information about content service
 	// version, utils repository version, commit hash etc.
 	InfoEndpoint = "info"
+
+	// LivenessEndpoint reports whether the service process is alive
+	LivenessEndpoint = "live"
+	// ReadinessEndpoint reports whether the service is able to serve
+	// requests, including state of all its dependencies
+	ReadinessEndpoint = "ready"
 )
 
 // addV1EndpointsToRouter adds API V1 specific endpoints to the router
@@ -102,6 +110,8 @@ func (server *HTTPServer) addV1EndpointsToRouter(router *mux.Router) {
 	router.HandleFunc(apiPrefix+OverviewEndpoint, server.overviewEndpoint).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+OverviewEndpoint, server.overviewEndpointWithClusterIDs).Methods(http.MethodPost)
 	router.HandleFunc(apiPrefix+InfoEndpoint, server.infoMap).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiPrefix+LivenessEndpoint, server.livenessEndpoint).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+ReadinessEndpoint, server.readinessEndpoint).Methods(http.MethodGet)
 
 	// Reports endpoints
 	server.addV1ReportsEndpointsToRouter(router, apiPrefix)
@@ -151,52 +161,70 @@ func (server *HTTPServer) addV1DebugEndpointsToRouter(router *mux.Router, apiPre
 func (server *HTTPServer) addV1RuleEndpointsToRouter(router *mux.Router, apiPrefix, aggregatorBaseEndpoint string) {
 	router.HandleFunc(apiPrefix+SingleRuleEndpoint, server.singleRuleEndpoint).Methods(http.MethodGet, http.MethodOptions)
 
-	router.HandleFunc(apiPrefix+LikeRuleEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.LikeRuleEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
-	)).Methods(http.MethodPut, http.MethodOptions)
-
-	router.HandleFunc(apiPrefix+DislikeRuleEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.DislikeRuleEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
-	)).Methods(http.MethodPut, http.MethodOptions)
-
-	router.HandleFunc(apiPrefix+ResetVoteOnRuleEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.ResetVoteOnRuleEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
-	)).Methods(http.MethodPut, http.MethodOptions)
-
-	router.HandleFunc(apiPrefix+DisableRuleForClusterEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractOrgIDFromTokenToURLRequestModifier(ira_server.DisableRuleForClusterEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
-	)).Methods(http.MethodPut, http.MethodOptions)
-
-	router.HandleFunc(apiPrefix+EnableRuleForClusterEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractOrgIDFromTokenToURLRequestModifier(ira_server.EnableRuleForClusterEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
-	)).Methods(http.MethodPut, http.MethodOptions)
-
-	router.HandleFunc(apiPrefix+DisableRuleFeedbackEndpoint, server.proxyTo(
-		aggregatorBaseEndpoint,
-		&ProxyOptions{RequestModifiers: []RequestModifier{
-			server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.DisableRuleFeedbackEndpoint),
-			checkRuleIDAndErrorKeyAreValid(),
-		}},
+	router.HandleFunc(apiPrefix+LikeRuleEndpoint, server.invalidatingResponseCache(server.auditingProxy(
+		audit.Event{Action: audit.VoteChanged, Vote: auditVoteLike},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.LikeRuleEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
+	))).Methods(http.MethodPut, http.MethodOptions)
+
+	router.HandleFunc(apiPrefix+DislikeRuleEndpoint, server.invalidatingResponseCache(server.auditingProxy(
+		audit.Event{Action: audit.VoteChanged, Vote: auditVoteDislike},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.DislikeRuleEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
+	))).Methods(http.MethodPut, http.MethodOptions)
+
+	router.HandleFunc(apiPrefix+ResetVoteOnRuleEndpoint, server.invalidatingResponseCache(server.auditingProxy(
+		audit.Event{Action: audit.VoteChanged, Vote: auditVoteNone},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.ResetVoteOnRuleEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
+	))).Methods(http.MethodPut, http.MethodOptions)
+
+	router.HandleFunc(apiPrefix+DisableRuleForClusterEndpoint, server.invalidatingResponseCache(server.auditingProxy(
+		audit.Event{Action: audit.ClusterRuleDisabled},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractOrgIDFromTokenToURLRequestModifier(ira_server.DisableRuleForClusterEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
+	))).Methods(http.MethodPut, http.MethodOptions)
+
+	router.HandleFunc(apiPrefix+EnableRuleForClusterEndpoint, server.invalidatingResponseCache(server.auditingProxy(
+		audit.Event{Action: audit.ClusterRuleEnabled},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractOrgIDFromTokenToURLRequestModifier(ira_server.EnableRuleForClusterEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
+	))).Methods(http.MethodPut, http.MethodOptions)
+
+	router.HandleFunc(apiPrefix+DisableRuleFeedbackEndpoint, server.auditingProxy(
+		audit.Event{Action: audit.ClusterRuleJustified},
+		server.proxyTo(
+			aggregatorBaseEndpoint,
+			&ProxyOptions{RequestModifiers: []RequestModifier{
+				server.extractUserIDOrgIDFromTokenToURLRequestModifier(ira_server.DisableRuleFeedbackEndpoint),
+				checkRuleIDAndErrorKeyAreValid(),
+			}},
+		),
 	)).Methods(http.MethodPost, http.MethodOptions)
 }
 
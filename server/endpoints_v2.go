@@ -66,6 +66,26 @@ const (
 	// are forgotten after 24 hours
 	ListAllRequestIDs = "cluster/{cluster}/requests"
 
+	// DeleteRequestsForCluster deletes all requests of given cluster
+	// together with their simplified results
+	DeleteRequestsForCluster = "cluster/{cluster}/requests"
+
+	// RequestsForOrganization should return list of requests of all
+	// clusters of the organization received in the given time range
+	RequestsForOrganization = "requests"
+
+	// LatestRequestsForClusters should return status and summary of rule
+	// hits of the most recent request of each given cluster
+	LatestRequestsForClusters = "requests/latest"
+
+	// LatestReportForCluster should return simplified results for the most
+	// recent processed request of given cluster
+	LatestReportForCluster = "cluster/{cluster}/requests/latest"
+
+	// RequestStatusStream should stream changes of statuses of requests for
+	// given cluster as server-sent events
+	RequestStatusStream = "cluster/{cluster}/requests/stream"
+
 	// StatusOfRequestID should return status of processing one given
 	// request ID
 	StatusOfRequestID = "cluster/{cluster}/request/{request_id}/status"
@@ -74,6 +94,11 @@ const (
 	// cluster and requestID
 	RuleHitsForRequestID = "cluster/{cluster}/request/{request_id}/report"
 
+	// RuleHitsDiffForRequestIDs should return rules that appeared,
+	// disappeared and persisted in simplified results for given request ID
+	// when compared with results for previous request ID
+	RuleHitsDiffForRequestIDs = "cluster/{cluster}/request/{request_id}/diff/{previous_request_id}"
+
 	// Endpoints to acknowledge rule and to manipulate with
 	// acknowledgements.
 
@@ -102,6 +127,10 @@ const (
 	// Otherwise, a 404 is returned.
 	AckDeleteEndpoint = "ack/{rule_id}"
 
+	// AckBulkEndpoint acknowledges many rules and disables many rules for
+	// selected clusters in one request. Result of each item is returned.
+	AckBulkEndpoint = "ack/bulk"
+
 	// Rating endpoint will get/modify the vote for a rule id by the user
 	Rating = "rating"
 
@@ -125,6 +154,22 @@ const (
 	// BDD scenarios for this endpoint:
 	// https://github.com/RedHatInsights/insights-behavioral-spec/blob/main/features/DVO_Recommendations/Smart_Proxy_REST_API.feature
 	DVONamespaceListEndpoint = "namespaces/dvo"
+
+	// TrendsEndpoint returns daily snapshots of the organization overview:
+	// number of clusters hit, hits per total risk and hits per rule
+	TrendsEndpoint = "trends"
+
+	// WebhooksEndpoint lists webhooks registered by the organization and
+	// registers new webhooks notified about new recommendations hitting
+	// clusters of the organization
+	WebhooksEndpoint = "webhooks"
+
+	// WebhookEndpoint deletes webhook registered by the organization
+	WebhookEndpoint = "webhooks/{webhook_id}"
+
+	// AuditEndpoint returns audit log of changes of acknowledgements, rules
+	// disabled for clusters and votes made by users of the organization
+	AuditEndpoint = "audit"
 )
 
 // addV2EndpointsToRouter adds API V2 specific endpoints to the router
@@ -151,10 +196,15 @@ func (server *HTTPServer) addV2EndpointsToRouter(router *mux.Router) {
 	// Endpoints related to DVO workload recommendations
 	server.addV2DVOEndpointsToRouter(router, apiV2Prefix)
 
+	// Webhook notifications
+	server.addV2WebhooksEndpointsToRouter(router, apiV2Prefix)
+
 	// Prometheus metrics
 	router.Handle(apiV2Prefix+MetricsEndpoint, promhttp.Handler()).Methods(http.MethodGet)
 
 	router.HandleFunc(apiV2Prefix+InfoEndpoint, server.infoMap).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc(apiV2Prefix+LivenessEndpoint, server.livenessEndpoint).Methods(http.MethodGet)
+	router.HandleFunc(apiV2Prefix+ReadinessEndpoint, server.readinessEndpoint).Methods(http.MethodGet)
 	router.HandleFunc(apiV2Prefix+UpgradeRisksPredictionEndpoint, server.upgradeRisksPrediction).Methods(http.MethodGet)
 	router.HandleFunc(apiV2Prefix+UpgradeRisksPredictionMultiClusterEndpoint, server.upgradeRisksPredictionMultiCluster).Methods(http.MethodPost)
 
@@ -171,8 +221,14 @@ func (server *HTTPServer) addV2EndpointsToRouter(router *mux.Router) {
 func (server *HTTPServer) addV2RedisEndpointsToRouter(router *mux.Router, apiPrefix string) {
 	router.HandleFunc(apiPrefix+ListAllRequestIDs, server.getRequestsForCluster).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+ListAllRequestIDs, server.getRequestsForClusterPostVariant).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+DeleteRequestsForCluster, server.deleteRequestsForCluster).Methods(http.MethodDelete)
+	router.HandleFunc(apiPrefix+RequestsForOrganization, server.getRequestsForOrganization).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+LatestRequestsForClusters, server.getLatestRequestsForClusters).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+LatestReportForCluster, server.getLatestReportForCluster).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+RequestStatusStream, server.streamRequestStatuses).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+StatusOfRequestID, server.getRequestStatusForCluster).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+RuleHitsForRequestID, server.getReportForRequest).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+RuleHitsDiffForRequestIDs, server.getReportDiffForRequests).Methods(http.MethodGet)
 }
 
 // addV2DVOEndpointsToRouter method registers handlers for endpoints related to DVO workloads
@@ -181,6 +237,14 @@ func (server *HTTPServer) addV2DVOEndpointsToRouter(router *mux.Router, apiPrefi
 	router.HandleFunc(apiPrefix+DVONamespaceListEndpoint, server.getDVONamespaceList).Methods(http.MethodGet)
 }
 
+// addV2WebhooksEndpointsToRouter method registers handlers for endpoints used
+// to manage webhooks
+func (server *HTTPServer) addV2WebhooksEndpointsToRouter(router *mux.Router, apiPrefix string) {
+	router.HandleFunc(apiPrefix+WebhooksEndpoint, server.getWebhooks).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+WebhooksEndpoint, server.registerWebhook).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+WebhookEndpoint, server.deleteWebhook).Methods(http.MethodDelete)
+}
+
 // addV2ReportsEndpointsToRouter method registers handlers for endpoints that
 // return cluster report or reports to client
 func (server *HTTPServer) addV2ReportsEndpointsToRouter(router *mux.Router, apiPrefix string) {
@@ -188,6 +252,7 @@ func (server *HTTPServer) addV2ReportsEndpointsToRouter(router *mux.Router, apiP
 	router.HandleFunc(apiPrefix+ClusterInfoEndpoint, server.getSingleClusterInfo).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+RecommendationsListEndpoint, server.getRecommendations).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+ClustersRecommendationsEndpoint, server.getClustersView).Methods(http.MethodGet)
+	router.HandleFunc(apiPrefix+TrendsEndpoint, server.getTrends).Methods(http.MethodGet)
 }
 
 // addV2RuleEndpointsToRouter method registers handlers for endpoints that handle
@@ -198,10 +263,12 @@ func (server *HTTPServer) addV2RuleEndpointsToRouter(router *mux.Router, apiPref
 	// prepared to be compatible with RHEL Insights Advisor.
 	router.HandleFunc(apiPrefix+AckListEndpoint, server.readAckList).Methods(http.MethodGet)
 	router.HandleFunc(apiPrefix+AckGetEndpoint, server.getAcknowledge).Methods(http.MethodGet)
-	router.HandleFunc(apiPrefix+AckAcknowledgePostEndpoint, server.acknowledgePost).Methods(http.MethodPost)
-	router.HandleFunc(apiPrefix+AckUpdateEndpoint, server.updateAcknowledge).Methods(http.MethodPut)
-	router.HandleFunc(apiPrefix+AckDeleteEndpoint, server.deleteAcknowledge).Methods(http.MethodDelete)
+	router.HandleFunc(apiPrefix+AckAcknowledgePostEndpoint, server.invalidatingResponseCache(server.acknowledgePost)).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+AckBulkEndpoint, server.invalidatingResponseCache(server.bulkAcknowledge)).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+AckUpdateEndpoint, server.invalidatingResponseCache(server.updateAcknowledge)).Methods(http.MethodPut)
+	router.HandleFunc(apiPrefix+AckDeleteEndpoint, server.invalidatingResponseCache(server.deleteAcknowledge)).Methods(http.MethodDelete)
 	router.HandleFunc(apiPrefix+Rating, server.postRating).Methods(http.MethodPost)
+	router.HandleFunc(apiPrefix+AuditEndpoint, server.getAuditEvents).Methods(http.MethodGet)
 	// Clusters for given recommendation endpoint
 	router.HandleFunc(apiPrefix+ClustersDetail, server.getClustersDetailForRule).Methods(http.MethodGet)
 }
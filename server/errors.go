@@ -21,6 +21,8 @@ import (
 
 	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
 	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
 
 	"github.com/RedHatInsights/insights-operator-utils/responses"
 	"github.com/RedHatInsights/insights-operator-utils/types"
@@ -73,8 +75,9 @@ func (*BadBodyContent) Error() string {
 }
 
 // TooManyClustersError error meaning that client is asking for too many clusters.
-// It is used in the URP endpoints, where using a big number of clusters may end up
-// in too slow and big requests.
+// It is used in the URP endpoints and in the endpoint returning the latest requests
+// of clusters, where using a big number of clusters may end up in too slow and big
+// requests.
 type TooManyClustersError struct {
 }
 
@@ -82,6 +85,22 @@ func (*TooManyClustersError) Error() string {
 	return fmt.Sprintf("the maximum amount of clusters allowed are %d", MaxAllowedClusters)
 }
 
+// TooManyWebhooksError error meaning that organization tries to register
+// more webhooks than allowed
+type TooManyWebhooksError struct{}
+
+func (*TooManyWebhooksError) Error() string {
+	return fmt.Sprintf("the maximum amount of webhooks allowed is %d", webhooks.MaxWebhooksPerOrganization)
+}
+
+// TooManyBulkItemsError error meaning that client is asking to acknowledge
+// or disable too many rules in one bulk request
+type TooManyBulkItemsError struct{}
+
+func (*TooManyBulkItemsError) Error() string {
+	return fmt.Sprintf("the maximum amount of items allowed in bulk request is %d", MaxBulkItems)
+}
+
 // ContentServiceUnavailableError error is used when the content service cannot be reached
 type ContentServiceUnavailableError struct{}
 
@@ -117,34 +136,65 @@ func (*ParamsParsingError) Error() string {
 	return "the parameters contains invalid characters and cannot be used"
 }
 
-// handleServerError handles separate server errors and sends appropriate responses
+// TooManyRequestsError error is used when the organization exhausted its
+// budget of requests
+type TooManyRequestsError struct{}
+
+func (*TooManyRequestsError) Error() string {
+	return "Too many requests, try again later"
+}
+
+// handleServerError handles separate server errors and sends appropriate
+// responses. Request ID (already set in response headers) is included in the
+// response body, so users can refer to the failed request.
 func handleServerError(writer http.ResponseWriter, err error) {
 	handleServerErrorStr := "handleServerError()"
 	var level = log.Warn // set the default log level for most HTTP responses
 
-	var respErr error
+	var statusCode int
+	var message string
+	if err != nil {
+		message = err.Error()
+	}
 
-	switch err := err.(type) {
-	case *RouterMissingParamError, *RouterParsingError, *json.SyntaxError, *NoBodyError, *ParamsParsingError, *BadBodyContent, *TooManyClustersError:
-		respErr = responses.SendBadRequest(writer, err.Error())
+	switch err.(type) {
+	case *RouterMissingParamError, *RouterParsingError, *json.SyntaxError, *NoBodyError, *ParamsParsingError, *BadBodyContent, *TooManyClustersError,
+		*TooManyWebhooksError, *TooManyBulkItemsError:
+		statusCode = http.StatusBadRequest
 	case *json.UnmarshalTypeError:
-		respErr = responses.SendBadRequest(writer, "bad type in json data")
+		statusCode = http.StatusBadRequest
+		message = "bad type in json data"
 	case *types.ItemNotFoundError:
-		respErr = responses.SendNotFound(writer, err.Error())
+		statusCode = http.StatusNotFound
 	case *types.NoContentError:
-		respErr = responses.SendNoContent(writer)
+		statusCode = http.StatusNoContent
 	case *auth.AuthenticationError, *auth.AuthorizationError:
-		respErr = responses.SendForbidden(writer, err.Error())
+		statusCode = http.StatusForbidden
 	case *ContentServiceUnavailableError, *AggregatorServiceUnavailableError,
 		*AMSAPIUnavailableError, *content.RuleContentDirectoryTimeoutError,
 		*UpgradesDataEngServiceUnavailableError:
-		respErr = responses.SendServiceUnavailable(writer, err.Error())
+		statusCode = http.StatusServiceUnavailable
+	case *TooManyRequestsError:
+		statusCode = http.StatusTooManyRequests
 	default:
 		level = log.Error
-		respErr = responses.SendInternalServerError(writer, "Internal Server Error")
+		statusCode = http.StatusInternalServerError
+		message = "Internal Server Error"
 	}
 
-	level().Err(err).Msg(handleServerErrorStr)
+	requestID := writer.Header().Get(httpclient.RequestIDHeader)
+	level().Err(err).Str(requestIDTag, requestID).Msg(handleServerErrorStr)
+
+	var respErr error
+	if statusCode == http.StatusNoContent {
+		respErr = responses.SendNoContent(writer)
+	} else {
+		body := responses.BuildResponse(message)
+		if requestID != "" {
+			body[requestIDTag] = requestID
+		}
+		respErr = responses.Send(statusCode, writer, body)
+	}
 
 	if respErr != nil {
 		log.Error().Err(respErr).Msg(responseDataError)
@@ -27,8 +27,18 @@ package server
 // to see why this trick is needed.
 
 var (
-	FillImpacted      = fillImpacted
-	HandleServerError = handleServerError
-	AcmUserAgent      = acmUserAgent
-	ComposeEndpoint   = (*HTTPServer).composeEndpoint
+	FillImpacted       = fillImpacted
+	HandleServerError  = handleServerError
+	AcmUserAgent       = acmUserAgent
+	ComposeEndpoint    = (*HTTPServer).composeEndpoint
+	NewTLSConfig       = newTLSConfig
+	NewHTTPServer      = (*HTTPServer).newHTTPServer
+	RouteWriteTimeout  = (*HTTPServer).routeWriteTimeout
+	GenerateRuleAckMap = generateRuleAckMap
+	ETagMatches        = etagMatches
+
+	NegotiateExportFormat = negotiateExportFormat
+	JSONExportFormat      = jsonExportFormat
+	CSVExportFormat       = csvExportFormat
+	NDJSONExportFormat    = ndjsonExportFormat
 )
@@ -359,6 +359,7 @@ func TestHTTPServer_ReportEndpoint_UnavailableContentService(t *testing.T) {
 
 	expectedBody := `
 		{
+		   "request_id" : "test-request-id",
 		   "status" : "Content directory cache has been empty for too long time; timeout triggered"
 		}
 	`
@@ -382,6 +383,7 @@ func TestHTTPServer_ReportEndpoint_UnavailableContentService(t *testing.T) {
 			UserID:       testdata.UserID,
 			OrgID:        testdata.OrgID,
 			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusServiceUnavailable,
 			Body:       expectedBody,
@@ -1040,6 +1042,7 @@ func TestHTTPServer_ReportMetainfoEndpointImproperJSON(t *testing.T) {
 			UserID:       testdata.UserID,
 			OrgID:        testdata.OrgID,
 			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusBadRequest,
 			Body:       helpers.ToJSONString(ReportMetainfoAPIResponseInvalidJSON),
@@ -1137,6 +1140,7 @@ func TestHTTPServer_RuleEndpoint_UnavailableContentService(t *testing.T) {
 
 	expectedBody := `
 		{
+		   "request_id" : "test-request-id",
 		   "status" : "Content directory cache has been empty for too long time; timeout triggered"
 		}
 	`
@@ -1163,9 +1167,10 @@ func TestHTTPServer_RuleEndpoint_UnavailableContentService(t *testing.T) {
 			EndpointArgs: []interface{}{
 				testdata.ClusterName, fmt.Sprintf("%v|%v", testdata.RuleErrorKey1.RuleModule, testdata.RuleErrorKey1.ErrorKey),
 			},
-			UserID:      testdata.UserID,
-			OrgID:       testdata.OrgID,
-			XRHIdentity: goodXRHAuthToken,
+			UserID:       testdata.UserID,
+			OrgID:        testdata.OrgID,
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusServiceUnavailable,
 			Body:       expectedBody,
@@ -1432,6 +1437,7 @@ func TestHTTPServer_OverviewEndpoint_UnavailableContentService(t *testing.T) {
 
 	expectedBody := `
 		{
+		   "request_id" : "test-request-id",
 		   "status" : "Content directory cache has been empty for too long time; timeout triggered"
 		}
 	`
@@ -1487,9 +1493,10 @@ func TestHTTPServer_OverviewEndpoint_UnavailableContentService(t *testing.T) {
 
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
 		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
-			Method:      http.MethodGet,
-			Endpoint:    server.OverviewEndpoint,
-			XRHIdentity: goodXRHAuthToken,
+			Method:       http.MethodGet,
+			Endpoint:     server.OverviewEndpoint,
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusServiceUnavailable,
 			Body:       expectedBody,
@@ -1883,6 +1890,7 @@ func TestHTTPServer_OverviewWithClusterIDsEndpoint_UnavailableContentService(t *
 
 	expectedBody := `
 		{
+		   "request_id" : "test-request-id",
 		   "status" : "Content directory cache has been empty for too long time; timeout triggered"
 		}`
 
@@ -1905,12 +1913,13 @@ func TestHTTPServer_OverviewWithClusterIDsEndpoint_UnavailableContentService(t *
 		expectNoRulesDisabledSystemWide(&t, testdata.OrgID)
 
 		helpers.AssertAPIRequest(t, nil, nil, nil, nil, nil, &helpers.APIRequest{
-			Method:      http.MethodPost,
-			Endpoint:    server.OverviewEndpoint,
-			OrgID:       testdata.OrgID,
-			UserID:      testdata.UserID,
-			Body:        helpers.ToJSONString(data.ClusterIDListInReq),
-			XRHIdentity: goodXRHAuthToken,
+			Method:       http.MethodPost,
+			Endpoint:     server.OverviewEndpoint,
+			OrgID:        testdata.OrgID,
+			UserID:       testdata.UserID,
+			Body:         helpers.ToJSONString(data.ClusterIDListInReq),
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusServiceUnavailable,
 			Body:       expectedBody,
@@ -3836,6 +3845,7 @@ func TestHTTPServer_GroupsEndpoint_UnavailableContentService(t *testing.T) {
 
 	expectedBody := `
 		{
+			"request_id" : "test-request-id",
 			"status" : "Content directory cache has been empty for too long time; timeout triggered"
 		}`
 
@@ -3914,7 +3924,7 @@ func TestHTTPServer_GetClustersForOrganizationOk(t *testing.T) {
 			amsMockClusterList: []types.ClusterInfo{},
 			orgID:              fmt.Sprint(testdata.OrgID),
 			token:              invalidXRHAuthToken,
-			expectedResponse:   `{"status":"Malformed authentication token"}`,
+			expectedResponse:   `{"request_id":"test-request-id","status":"Malformed authentication token"}`,
 			expectedStatusCode: http.StatusForbidden,
 		},
 		{
@@ -3945,6 +3955,7 @@ func TestHTTPServer_GetClustersForOrganizationOk(t *testing.T) {
 					Endpoint:     server.ClustersForOrganizationEndpoint,
 					EndpointArgs: []interface{}{test.orgID},
 					XRHIdentity:  test.token,
+					ExtraHeaders: requestIDHeader,
 				}, &helpers.APIResponse{
 					StatusCode: test.expectedStatusCode,
 					Body:       test.expectedResponse,
@@ -4283,7 +4294,7 @@ func TestHTTPServer_ClustersRecommendationsEndpoint_MissingRuleContent(t *testin
 			StatusCode: http.StatusOK,
 			Body: helpers.ToJSONString(map[string]interface{}{
 				"status": "ok",
-				"meta":   map[string]int{"count": 1},
+				"meta":   map[string]int{"count": 1, "total": 1, "filtered": 1, "limit": 0, "offset": 0},
 				"data":   expectedResponse,
 			}),
 		})
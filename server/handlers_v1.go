@@ -63,6 +63,7 @@ func (server *HTTPServer) getGroups(writer http.ResponseWriter, _ *http.Request)
 
 // getContentForRule retrieves the static content for the given ruleID
 func (server HTTPServer) getContentForRuleV1(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	ruleID, successful := httputils.ReadRuleID(writer, request)
 	if !successful {
 		// already handled in readRuleID
@@ -79,7 +80,7 @@ func (server HTTPServer) getContentForRuleV1(writer http.ResponseWriter, request
 	if internal := content.IsRuleInternal(ruleID); internal {
 		err := server.checkInternalRulePermissions(request)
 		if err != nil {
-			log.Error().Err(err).Send()
+			logger.Error().Err(err).Send()
 			handleServerError(writer, err)
 			return
 		}
@@ -94,11 +95,12 @@ func (server HTTPServer) getContentForRuleV1(writer http.ResponseWriter, request
 
 // getContent retrieves all the static content
 func (server HTTPServer) getContentV1(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	// Generate an array of RuleContent
 	allRules, err := content.GetAllContentV1()
 
 	if err != nil {
-		log.Error().Err(err).Send()
+		logger.Error().Err(err).Send()
 		handleServerError(writer, err)
 		return
 	}
@@ -124,6 +126,7 @@ func (server HTTPServer) getContentV1(writer http.ResponseWriter, request *http.
 
 // getClustersForOrg retrieves the list of clusters belonging to this organization
 func (server HTTPServer) getClustersForOrg(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, successful := httputils.ReadOrganizationID(writer, request, server.Config.Auth)
 	if !successful {
 		// server error already handled in readOrganizationID
@@ -131,9 +134,9 @@ func (server HTTPServer) getClustersForOrg(writer http.ResponseWriter, request *
 	}
 
 	// try to get cluster list from AMS API because the aggregator way is unusable for large orgs
-	activeClustersInfo, err := server.readClusterInfoForOrgID(orgID)
+	activeClustersInfo, err := server.readClusterInfoForOrgID(request.Context(), orgID)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
 		handleServerError(writer, err)
 		return
 	}
@@ -141,16 +144,17 @@ func (server HTTPServer) getClustersForOrg(writer http.ResponseWriter, request *
 
 	err = responses.SendOK(writer, responses.BuildOkResponseWithData("clusters", clusterList))
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
 // getRuleIDs returns a list of the names of the rules
 func (server HTTPServer) getRuleIDs(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	allRuleIDs, err := content.GetRuleIDs()
 
 	if err != nil {
-		log.Error().Err(err).Send()
+		logger.Error().Err(err).Send()
 		handleServerError(writer, err)
 		return
 	}
@@ -168,7 +172,7 @@ func (server HTTPServer) getRuleIDs(writer http.ResponseWriter, request *http.Re
 	}
 
 	if err := responses.SendOK(writer, responses.BuildOkResponseWithData("rules", ruleIDs)); err != nil {
-		log.Error().Err(err).Send()
+		logger.Error().Err(err).Send()
 		handleServerError(writer, err)
 		return
 	}
@@ -180,12 +184,13 @@ func (server HTTPServer) getOrganizationOverview(
 	systemWideDisabledRules map[types.RuleID]bool,
 	disabledRulesPerCluster map[types.ClusterName][]types.RuleID,
 ) (
-	sptypes.OrgOverviewResponse, error,
+	overview sptypes.OrgOverviewResponse, hitsByRule map[types.RuleID]int, err error,
 ) {
-	overview := sptypes.OrgOverviewResponse{
+	overview = sptypes.OrgOverviewResponse{
 		ClustersHitByTotalRisk: make(map[int]int),
 		ClustersHitByTag:       make(map[string]int),
 	}
+	hitsByRule = make(map[types.RuleID]int)
 
 	// iterates over clusters and their hitting recommendations, accesses map to the get rule severity
 	for i := range clusterInfoList {
@@ -223,7 +228,7 @@ func (server HTTPServer) getOrganizationOverview(
 			ruleContent, err := content.GetContentForRecommendation(ruleID)
 			if err != nil {
 				if err, ok := err.(*content.RuleContentDirectoryTimeoutError); ok {
-					return overview, err
+					return overview, hitsByRule, err
 				}
 				// missing rule content, simply omit the rule as we can't display anything
 				log.Warn().Err(err).Interface(ruleIDStr, ruleID).Msg(ruleContentError)
@@ -238,6 +243,7 @@ func (server HTTPServer) getOrganizationOverview(
 			}
 
 			overview.ClustersHitByTotalRisk[ruleContent.TotalRisk]++
+			hitsByRule[ruleID]++
 
 			for _, tag := range ruleContent.Tags {
 				overview.ClustersHitByTag[tag]++
@@ -250,30 +256,34 @@ func (server HTTPServer) getOrganizationOverview(
 		}
 	}
 
-	return overview, nil
+	return overview, hitsByRule, nil
 }
 
 // overviewEndpoint returns a map with an overview of number of clusters hit by rules
 func (server HTTPServer) overviewEndpoint(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, userID, err := server.GetCurrentOrgIDUserIDFromToken(request)
 	if err != nil {
-		log.Warn().Err(err).Msg(orgIDTokenError)
+		logger.Warn().Err(err).Msg(orgIDTokenError)
 		handleServerError(writer, err)
 		return
 	}
 
 	clusterList, clusterRuleHits, ackedRulesMap, disabledRules := server.getClusterListAndUserData(
+		request.Context(),
 		writer,
 		orgID,
 		userID,
 	)
 
-	overview, err := server.getOrganizationOverview(clusterList, clusterRuleHits, ackedRulesMap, disabledRules)
+	overview, hitsByRule, err := server.getOrganizationOverview(clusterList, clusterRuleHits, ackedRulesMap, disabledRules)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
+	server.recordTrendsSnapshot(logger, orgID, &overview, hitsByRule)
+
 	if err = responses.SendOK(writer, responses.BuildOkResponseWithData("overview", overview)); err != nil {
 		handleServerError(writer, err)
 		return
@@ -282,6 +292,7 @@ func (server HTTPServer) overviewEndpoint(writer http.ResponseWriter, request *h
 
 // overviewEndpointWithClusterIDs returns a map with an overview of number of clusters hit by rules
 func (server HTTPServer) overviewEndpointWithClusterIDs(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
 		handleServerError(writer, err)
@@ -289,7 +300,7 @@ func (server HTTPServer) overviewEndpointWithClusterIDs(writer http.ResponseWrit
 	}
 
 	// get reports for the cluster list in body
-	log.Debug().Msg("Retrieving reports for clusters to generate org_overview")
+	logger.Debug().Msg("Retrieving reports for clusters to generate org_overview")
 	aggregatorResponse, ok := server.fetchAggregatorReportsUsingRequestBodyClusterList(writer, request)
 	if !ok {
 		// errors already handled
@@ -297,13 +308,13 @@ func (server HTTPServer) overviewEndpointWithClusterIDs(writer http.ResponseWrit
 	}
 
 	// retrieve rule acknowledgements (disable/enable)
-	acks, err := server.readListOfAckedRules(orgID)
+	acks, err := server.readListOfAckedRules(request.Context(), orgID)
 	if err != nil {
-		log.Error().Err(err).Msg(ackedRulesError)
+		logger.Error().Err(err).Msg(ackedRulesError)
 		// server error has been handled already
 		return
 	}
-	orgWideDisabledRules := generateRuleAckMap(acks)
+	orgWideDisabledRules := generateRuleAckMap(acks, server.readAckExpirations(orgID))
 
 	r, err := generateOrgOverview(aggregatorResponse, orgWideDisabledRules)
 
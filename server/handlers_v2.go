@@ -18,6 +18,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -39,6 +41,7 @@ import (
 
 	"github.com/RedHatInsights/insights-results-smart-proxy/content"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
 )
 
@@ -136,16 +139,17 @@ func (server HTTPServer) getRuleWithGroups(
 // getRecommendationContent retrieves the static content for the given ruleID tied
 // with groups info. rule ID is expected to be the composite rule ID (rule.module|ERROR_KEY)
 func (server HTTPServer) getRecommendationContent(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	ruleID, err := readCompositeRuleID(request)
 	if err != nil {
-		log.Warn().Err(err).Msgf("error retrieving rule ID from request")
+		logger.Warn().Err(err).Msgf("error retrieving rule ID from request")
 		handleServerError(writer, err)
 		return
 	}
 
 	ruleContent, ruleGroups, err := server.getRuleWithGroups(request, ruleID)
 	if err != nil {
-		log.Warn().Err(err).Msgf("error retrieving rule content and groups for rule ID %v", ruleID)
+		logger.Warn().Err(err).Msgf("error retrieving rule content and groups for rule ID %v", ruleID)
 		handleServerError(writer, err)
 		return
 	}
@@ -198,6 +202,7 @@ func (server HTTPServer) getRecommendationContent(writer http.ResponseWriter, re
 // getRecommendationContent retrieves the static content for the given ruleID tied
 // with groups info. rule ID is expected to be the composite rule ID (rule.module|ERROR_KEY)
 func (server HTTPServer) getRecommendationContentWithUserData(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
 		log.Err(err).Msg(orgIDTokenError)
@@ -207,25 +212,25 @@ func (server HTTPServer) getRecommendationContentWithUserData(writer http.Respon
 
 	ruleID, err := readCompositeRuleID(request)
 	if err != nil {
-		log.Warn().Err(err).Msg("error retrieving rule ID from request")
+		logger.Warn().Err(err).Msg("error retrieving rule ID from request")
 		handleServerError(writer, err)
 		return
 	}
 
 	ruleContent, ruleGroups, err := server.getRuleWithGroups(request, ruleID)
 	if err != nil {
-		log.Warn().Err(err).Interface(ruleIDStr, ruleID).Msg("error retrieving rule content and groups for rule")
+		logger.Warn().Err(err).Interface(ruleIDStr, ruleID).Msg("error retrieving rule content and groups for rule")
 		handleServerError(writer, err)
 		return
 	}
 
-	rating, err := server.getRatingForRecommendation(orgID, ruleID)
+	rating, err := server.getRatingForRecommendation(request.Context(), orgID, ruleID)
 	if err != nil {
 		switch err.(type) {
 		case *utypes.ItemNotFoundError:
 			break
 		case *url.Error:
-			log.Error().Err(err).Msg("aggregator is not responding")
+			logger.Error().Err(err).Msg("aggregator is not responding")
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
 			return
 		default:
@@ -243,6 +248,7 @@ func (server HTTPServer) getRecommendationContentWithUserData(writer http.Respon
 	// ignoring the response and the possible error.
 	// We are just interested on know if the rule is system disabled or not
 	_, ackFound, _ := server.readRuleDisableStatus(
+		request.Context(),
 		ctypes.Component(ruleModule),
 		errorKey,
 		orgID,
@@ -300,7 +306,7 @@ func (server HTTPServer) getRecommendationContentWithUserData(writer http.Respon
 	// send response to client
 	err = responses.SendOK(writer, responseContent)
 	if err != nil {
-		log.Error().Err(err).Msg(problemSendingResponseError)
+		logger.Error().Err(err).Msg(problemSendingResponseError)
 		handleServerError(writer, err)
 		return
 	}
@@ -308,100 +314,133 @@ func (server HTTPServer) getRecommendationContentWithUserData(writer http.Respon
 
 // getRecommendations retrieves all recommendations with a count of impacted clusters
 // By default returns only those recommendations that currently hit at least one cluster,
-// but it's possible to show all recommendations by passing a URL parameter `impacting`
+// but it's possible to show all recommendations by passing a URL parameter `impacting`.
+// The list can be filtered, sorted and paginated by other URL parameters and exported
+// as CSV or NDJSON based on the Accept header.
 func (server HTTPServer) getRecommendations(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	var recommendationList []types.RecommendationListView
 	tStart := time.Now()
 
 	userID, orgID, impactingFlag, err := server.readParamsGetRecommendations(writer, request)
 	if err != nil {
 		// everything handled
-		log.Error().Err(err).Msg("problem reading necessary params from request")
+		logger.Error().Err(err).Msg("problem reading necessary params from request")
 		return
 	}
 
-	activeClustersInfo, err := server.readClusterInfoForOrgID(orgID)
+	listParams, err := readRecommendationListParams(writer, request)
 	if err != nil {
-		log.Warn().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
+		// everything handled
+		logger.Warn().Err(err).Msg("problem reading recommendation list params from request")
+		return
+	}
+
+	activeClustersInfo, err := server.readClusterInfoForOrgID(request.Context(), orgID)
+	if err != nil {
+		logger.Warn().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
 		handleServerError(writer, err)
 		return
 	}
 	clusterIDList := types.GetClusterNames(activeClustersInfo)
+	tracing.SetAttributes(request.Context(), tracing.ClusterCount(len(clusterIDList)))
 
 	tStartImpacting := time.Now()
 	impactingRecommendations, err := server.getImpactingRecommendations(
+		request.Context(),
 		writer, orgID, userID, clusterIDList,
 	)
 	if err != nil {
 		// log cluster list in case of error even though message might be too large for Kibana/zerolog
-		log.Error().
+		logger.Error().
 			Err(err).
 			Int(orgIDTag, int(orgID)).
 			Msgf("problem getting impacting recommendations from aggregator for cluster list (# of clusters: %v)", len(clusterIDList))
 
 		return
 	}
-	log.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf(
+	logger.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf(
 		"getRecommendations get impacting recommendations from aggregator took %s", time.Since(tStartImpacting),
 	)
 
 	// get a map of acknowledged rules
-	ackedRulesMap, err := server.getRuleAcksMap(orgID)
+	ackedRulesMap, err := server.getRuleAcksMap(request.Context(), orgID)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
 	// retrieve user disabled rules for given list of active clusters
-	disabledClustersForRules := server.getRuleDisabledClusters(writer, orgID, clusterIDList)
+	disabledClustersForRules := server.getRuleDisabledClusters(request.Context(), writer, orgID, clusterIDList)
 
+	_, span := tracing.StartSpan(request.Context(), "content.GetFilteredRecommendationsList")
 	recommendationList, err = getFilteredRecommendationsList(
 		activeClustersInfo, impactingRecommendations, impactingFlag, ackedRulesMap, disabledClustersForRules,
+		&listParams.filter,
 	)
+	tracing.EndSpan(span, err)
 
 	if err != nil {
-		log.Error().Err(err).Msg("problem getting recommendation content")
+		logger.Error().Err(err).Msg("problem getting recommendation content")
 		handleServerError(writer, err)
 		return
 	}
-	log.Debug().
+	logger.Debug().
 		Int(orgIDTag, int(orgID)).
 		Str(userIDTag, string(userID)).
 		Msgf("number of final recommendations: %d", len(recommendationList))
+	tracing.SetAttributes(request.Context(), tracing.RuleCount(len(recommendationList)))
+
+	sortRecommendationList(recommendationList, listParams.sorting)
+	page := paginateList(recommendationList, listParams.pagination)
+
+	if format := negotiateExportFormat(request); format != jsonExportFormat {
+		if err = sendExport(writer, format, slices.Values(page), recommendationExportColumns); err != nil {
+			logger.Error().Err(err).Msg(problemSendingResponseError)
+		}
+		return
+	}
 
 	resp := make(map[string]interface{})
 	resp["status"] = OkMsg
-	resp["recommendations"] = recommendationList
+	resp["meta"] = types.ListMeta{
+		Count:  len(page),
+		Total:  len(recommendationList),
+		Limit:  listParams.pagination.limit,
+		Offset: listParams.pagination.offset,
+	}
+	resp["recommendations"] = page
 
-	log.Info().Uint32(orgIDTag, uint32(orgID)).Msgf(
+	logger.Info().Uint32(orgIDTag, uint32(orgID)).Msgf(
 		"getRecommendations took %s", time.Since(tStart),
 	)
 	err = responses.SendOK(writer, resp)
 	if err != nil {
-		log.Error().Err(err).Msg(problemSendingResponseError)
+		logger.Error().Err(err).Msg(problemSendingResponseError)
 		handleServerError(writer, err)
 		return
 	}
 }
 
-func (server HTTPServer) getRuleAcksMap(orgID types.OrgID) (
+func (server HTTPServer) getRuleAcksMap(ctx context.Context, orgID types.OrgID) (
 	ackedRulesMap map[ctypes.RuleID]bool, err error,
 ) {
 	ackedRulesMap = make(map[ctypes.RuleID]bool)
 
 	// retrieve rule acknowledgements (disable/enable for all clusters)
-	ackedRules, err := server.readListOfAckedRules(orgID)
+	ackedRules, err := server.readListOfAckedRules(ctx, orgID)
 	if err != nil {
 		log.Error().Err(err).Msg(ackedRulesError)
 		return
 	}
 	// put rule acks in a map so we only iterate over them once
-	ackedRulesMap = generateRuleAckMap(ackedRules)
+	ackedRulesMap = generateRuleAckMap(ackedRules, server.readAckExpirations(orgID))
 
 	return
 }
 
 func (server HTTPServer) getRuleDisabledClusters(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID types.OrgID,
 	clusterList []ctypes.ClusterName,
@@ -410,7 +449,7 @@ func (server HTTPServer) getRuleDisabledClusters(
 ) {
 	ruleDisabledClusters = make(map[types.RuleID][]types.ClusterName)
 
-	listOfDisabledRules, err := server.readListOfDisabledRulesForClusters(writer, orgID, clusterList)
+	listOfDisabledRules, err := server.readListOfDisabledRulesForClusters(ctx, writer, orgID, clusterList)
 	if err != nil {
 		log.Error().Err(err).Msg("error reading disabled rules from aggregator")
 		// server error has been handled already
@@ -433,18 +472,28 @@ func (server HTTPServer) getRuleDisabledClusters(
 
 // getClustersView retrieves all clusters for given organization, retrieves the impacting rules for each cluster
 // from aggregator and returns a list of clusters, total number of hitting rules and a count of impacting rules
-// by severity = total risk = critical, high, moderate, low
+// by severity = total risk = critical, high, moderate, low. The list can be filtered, sorted and
+// paginated by URL parameters and exported as CSV or NDJSON based on the Accept header.
 func (server HTTPServer) getClustersView(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	tStart := time.Now()
 
 	orgID, userID, err := server.GetCurrentOrgIDUserIDFromToken(request)
 	if err != nil {
-		log.Warn().Err(err).Msg(orgIDTokenError)
+		logger.Warn().Err(err).Msg(orgIDTokenError)
 		handleServerError(writer, err)
 		return
 	}
 
+	listParams, err := readClusterListParams(writer, request)
+	if err != nil {
+		// everything handled
+		logger.Warn().Err(err).Msg("problem reading cluster list params from request")
+		return
+	}
+
 	clusterList, clusterRuleHits, ackedRulesMap, disabledRules := server.getClusterListAndUserData(
+		request.Context(),
 		writer,
 		orgID,
 		userID,
@@ -454,24 +503,40 @@ func (server HTTPServer) getClustersView(writer http.ResponseWriter, request *ht
 		clusterList, clusterRuleHits, ackedRulesMap, disabledRules,
 	)
 	if err != nil {
-		log.Error().Uint32(orgIDTag, uint32(orgID)).Err(err).Msg("getClustersView error generating cluster list response")
+		logger.Error().Uint32(orgIDTag, uint32(orgID)).Err(err).Msg("getClustersView error generating cluster list response")
 		handleServerError(writer, err)
+		return
 	}
-	log.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf("getClustersView final number %v", len(clusterViewResponse))
+	logger.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf("getClustersView final number %v", len(clusterViewResponse))
+	tracing.SetAttributes(request.Context(), tracing.ClusterCount(len(clusterViewResponse)))
 
-	resp := make(map[string]interface{})
-	metaCount := map[string]int{
-		"count": len(clusterViewResponse),
+	filteredClusters := filterClusterList(clusterViewResponse, &listParams.filter)
+	sortList(filteredClusters, listParams.sorting, clusterComparators)
+	page := paginateList(filteredClusters, listParams.pagination)
+
+	if format := negotiateExportFormat(request); format != jsonExportFormat {
+		if err = sendExport(writer, format, slices.Values(page), clusterExportColumns); err != nil {
+			logger.Error().Err(err).Msg(problemSendingResponseError)
+		}
+		return
 	}
+
+	resp := make(map[string]interface{})
 	resp["status"] = OkMsg
-	resp["meta"] = metaCount
-	resp["data"] = clusterViewResponse
+	resp["meta"] = types.ClusterListMeta{
+		Count:    len(page),
+		Total:    len(clusterViewResponse),
+		Filtered: len(filteredClusters),
+		Limit:    listParams.pagination.limit,
+		Offset:   listParams.pagination.offset,
+	}
+	resp["data"] = page
 
-	log.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf("getClustersView took %s", time.Since(tStart))
+	logger.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf("getClustersView took %s", time.Since(tStart))
 
 	err = responses.SendOK(writer, resp)
 	if err != nil {
-		log.Error().Err(err).Msg(problemSendingResponseError)
+		logger.Error().Err(err).Msg(problemSendingResponseError)
 		handleServerError(writer, err)
 		return
 	}
@@ -479,8 +544,9 @@ func (server HTTPServer) getClustersView(writer http.ResponseWriter, request *ht
 
 // getSingleClusterInfo retrieves information about given cluster from AMS API, such as the user defined display name
 func (server HTTPServer) getSingleClusterInfo(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	if server.amsClient == nil {
-		log.Error().Msg(AMSApiNotInitializedErrorMessage)
+		logger.Error().Msg(AMSApiNotInitializedErrorMessage)
 		handleServerError(writer, &AMSAPIUnavailableError{})
 		return
 	}
@@ -499,7 +565,7 @@ func (server HTTPServer) getSingleClusterInfo(writer http.ResponseWriter, reques
 
 	clusterInfo, err := server.amsClient.GetSingleClusterInfoForOrganization(orgID, clusterID)
 	if err != nil {
-		log.Warn().Err(err).Msg("problem retrieving cluster info from AMS API")
+		logger.Warn().Err(err).Msg("problem retrieving cluster info from AMS API")
 		handleServerError(writer, err)
 		return
 	}
@@ -507,13 +573,13 @@ func (server HTTPServer) getSingleClusterInfo(writer http.ResponseWriter, reques
 	// retrieval failed, but error is nil
 	if clusterInfo.ID == "" {
 		err := &utypes.ItemNotFoundError{ItemID: clusterID}
-		log.Warn().Err(err).Msg("unexpected problem retrieving cluster info from AMS API")
+		logger.Warn().Err(err).Msg("unexpected problem retrieving cluster info from AMS API")
 		handleServerError(writer, err)
 		return
 	}
 
 	if err = responses.SendOK(writer, responses.BuildOkResponseWithData("cluster", clusterInfo)); err != nil {
-		log.Error().Err(err).Msg(problemSendingResponseError)
+		logger.Error().Err(err).Msg(problemSendingResponseError)
 		handleServerError(writer, err)
 		return
 	}
@@ -625,10 +691,10 @@ func filterOutDisabledRules(
 }
 
 // Method getUserDisabledRulesPerCluster returns a map of cluster IDs with a list of disabled rules for each cluster
-func (server *HTTPServer) getUserDisabledRulesPerCluster(orgID types.OrgID) (
+func (server *HTTPServer) getUserDisabledRulesPerCluster(ctx context.Context, orgID types.OrgID) (
 	disabledRulesPerCluster map[ctypes.ClusterName][]ctypes.RuleID,
 ) {
-	listOfDisabledRules, err := server.readListOfClusterDisabledRules(orgID)
+	listOfDisabledRules, err := server.readListOfClusterDisabledRules(ctx, orgID)
 	if err != nil {
 		log.Error().Err(err).Msg("error retrieving list of disabled rules")
 		return
@@ -693,6 +759,7 @@ func getFilteredRecommendationsList(
 	impactingFlag types.ImpactingFlag,
 	ruleAcksMap map[types.RuleID]bool,
 	disabledClustersForRules map[types.RuleID][]types.ClusterName,
+	filter *recommendationFilter,
 ) (
 	recommendationList []types.RecommendationListView,
 	err error,
@@ -764,6 +831,9 @@ func getFilteredRecommendationsList(
 		if err != nil {
 			return recommendationList, err
 		}
+		if !filter.matches(&recommendationListView) {
+			continue
+		}
 		recommendationList = append(recommendationList, recommendationListView)
 	}
 
@@ -812,6 +882,7 @@ func parseRecommendationListView(
 
 // getImpactingRecommendations retrieves a list of recommendations from aggregator based on the list of clusters
 func (server HTTPServer) getImpactingRecommendations(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID ctypes.OrgID,
 	userID ctypes.UserID,
@@ -824,6 +895,37 @@ func (server HTTPServer) getImpactingRecommendations(
 		Status          string                                `json:"status"`
 	}
 
+	cacheKey := string(userID) + ":" + clusterListCacheKey(clusterList)
+	responseBytes, found := server.readFromResponseCache(orgID, recommendationsCacheKind, cacheKey)
+	if !found {
+		var ok bool
+		responseBytes, ok = server.readImpactingRecommendations(ctx, writer, orgID, userID, clusterList)
+		if !ok {
+			return nil, errors.New("unable to retrieve recommendations from aggregator")
+		}
+		server.storeToResponseCache(orgID, recommendationsCacheKind, cacheKey, responseBytes)
+	}
+
+	err := json.Unmarshal(responseBytes, &aggregatorResponse)
+	if err != nil {
+		log.Error().Err(err).Msg("getImpactingRecommendations problem unmarshalling JSON response")
+		handleServerError(writer, err)
+		return nil, err
+	}
+
+	return aggregatorResponse.Recommendations, nil
+}
+
+// readImpactingRecommendations reads raw list of recommendations from
+// aggregator. Error response is sent to the client when aggregator is not
+// able to provide the list.
+func (server HTTPServer) readImpactingRecommendations(
+	ctx context.Context,
+	writer http.ResponseWriter,
+	orgID ctypes.OrgID,
+	userID ctypes.UserID,
+	clusterList []ctypes.ClusterName,
+) ([]byte, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
 		server.ServicesConfig.AggregatorBaseEndpoint,
 		ira_server.RecommendationsListEndpoint,
@@ -835,16 +937,16 @@ func (server HTTPServer) getImpactingRecommendations(
 	if err != nil {
 		log.Error().Err(err).Msg("getImpactingRecommendations problem unmarshalling cluster list")
 		handleServerError(writer, err)
-		return nil, err
+		return nil, false
 	}
 
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	aggregatorResp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(jsonMarshalled))
+	aggregatorResp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(jsonMarshalled))
 	if err != nil {
 		log.Error().Err(err).Msg("getImpactingRecommendations problem getting response from aggregator")
 		handleServerError(writer, err)
-		return nil, err
+		return nil, false
 	}
 
 	defer services.CloseResponseBody(aggregatorResp)
@@ -853,7 +955,7 @@ func (server HTTPServer) getImpactingRecommendations(
 	if err != nil {
 		log.Error().Err(err).Msg("getImpactingRecommendations problem reading response body")
 		handleServerError(writer, err)
-		return nil, err
+		return nil, false
 	}
 
 	if aggregatorResp.StatusCode != http.StatusOK {
@@ -862,21 +964,15 @@ func (server HTTPServer) getImpactingRecommendations(
 			log.Error().Err(err).Msg(problemSendingResponseError)
 			handleServerError(writer, err)
 		}
-		return nil, err
-	}
-
-	err = json.Unmarshal(responseBytes, &aggregatorResponse)
-	if err != nil {
-		log.Error().Err(err).Msg("getImpactingRecommendations problem unmarshalling JSON response")
-		handleServerError(writer, err)
-		return nil, err
+		return nil, false
 	}
 
-	return aggregatorResponse.Recommendations, nil
+	return responseBytes, true
 }
 
 // getClustersAndRecommendations retrieves a list of recommendations from aggregator based on the list of clusters
 func (server HTTPServer) getClustersAndRecommendations(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID ctypes.OrgID,
 	userID ctypes.UserID,
@@ -903,7 +999,7 @@ func (server HTTPServer) getClustersAndRecommendations(
 
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	aggregatorResp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(jsonMarshalled))
+	aggregatorResp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(jsonMarshalled))
 	if err != nil {
 		log.Error().Err(err).Msg("getClustersAndRecommendations problem getting response from aggregator")
 		if _, ok := err.(*url.Error); ok {
@@ -985,12 +1081,13 @@ func (server HTTPServer) getContentWithGroups(writer http.ResponseWriter, reques
 // getImpactedClustersFromAggregator sends GET to aggregator with or without content
 // depending on the list of active clusters provided by the AMS client.
 func getImpactedClustersFromAggregator(
+	ctx context.Context,
 	url string,
 	activeClusters []ctypes.ClusterName,
 ) (resp *http.Response, err error) {
 	if len(activeClusters) < 1 {
 		// #nosec G107
-		resp, err = http.Get(url)
+		resp, err = aggregatorGet(ctx, url)
 		return
 	}
 
@@ -1005,19 +1102,19 @@ func getImpactedClustersFromAggregator(
 
 	// GET method with list of active clusters in payload to avoid possible URL length problems
 	var req *http.Request
-	req, err = http.NewRequest(http.MethodGet, url, bytes.NewBuffer(jsonBody))
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return
 	}
 
 	req.Header.Set(contentTypeHeader, JSONContentType)
-	client := &http.Client{}
-	resp, err = client.Do(req)
+	resp, err = aggregatorClient().Do(req)
 	return
 }
 
 // getImpactedClusters retrieves a list of clusters affected by the given recommendation from aggregator
 func (server HTTPServer) getImpactedClusters(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID ctypes.OrgID,
 	userID ctypes.UserID,
@@ -1043,7 +1140,7 @@ func (server HTTPServer) getImpactedClusters(
 	)
 
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	aggregatorResp, err := getImpactedClustersFromAggregator(aggregatorURL, activeClusters)
+	aggregatorResp, err := getImpactedClustersFromAggregator(ctx, aggregatorURL, activeClusters)
 	// if http.Get fails for whatever reason
 	if err != nil {
 		handleServerError(writer, err)
@@ -1071,8 +1168,10 @@ func (server HTTPServer) getImpactedClusters(
 
 // getClustersDetailForRule retrieves all the clusters affected by the recommendation
 // By default returns only those recommendations that currently hit at least one cluster, but it's
-// possible to show all recommendations by passing a URL parameter `impacting`
+// possible to show all recommendations by passing a URL parameter `impacting`. The list can be
+// exported as CSV or NDJSON based on the Accept header.
 func (server HTTPServer) getClustersDetailForRule(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	var useAggregatorFallback bool
 
 	selector, successful := httputils.ReadRuleSelector(writer, request)
@@ -1081,7 +1180,7 @@ func (server HTTPServer) getClustersDetailForRule(writer http.ResponseWriter, re
 	}
 	orgID, userID, err := server.GetCurrentOrgIDUserIDFromToken(request)
 	if err != nil {
-		log.Warn().Err(err).Msg(orgIDTokenError)
+		logger.Warn().Err(err).Msg(orgIDTokenError)
 		handleServerError(writer, err)
 		return
 	}
@@ -1094,9 +1193,9 @@ func (server HTTPServer) getClustersDetailForRule(writer http.ResponseWriter, re
 	}
 
 	// Get list of clusters for given organization
-	activeClustersInfo, err := server.readClusterInfoForOrgID(orgID)
+	activeClustersInfo, err := server.readClusterInfoForOrgID(request.Context(), orgID)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg("Error retrieving cluster IDs from AMS API. Will retrieve cluster list from aggregator.")
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Msg("Error retrieving cluster IDs from AMS API. Will retrieve cluster list from aggregator.")
 		useAggregatorFallback = true
 	}
 
@@ -1115,25 +1214,27 @@ func (server HTTPServer) getClustersDetailForRule(writer http.ResponseWriter, re
 	}
 
 	// get the list of clusters affected by given rule from aggregator and
-	impactedClusters, err := server.getImpactedClusters(writer, orgID, userID, selector, activeClustersInfo, useAggregatorFallback)
+	impactedClusters, err := server.getImpactedClusters(request.Context(), writer, orgID, userID, selector, activeClustersInfo, useAggregatorFallback)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
 			Msg("Couldn't get impacted clusters for given rule selector")
 		handleServerError(writer, err)
 		return
 	}
 
-	disabledClusters, acknowledge, ackFound, err := server.getListOfDisabledClustersAndAck(orgID, selector)
+	disabledClusters, acknowledge, ackFound, err := server.getListOfDisabledClustersAndAck(request.Context(), orgID, selector)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
 			Msg("Couldn't retrieve disabled clusters or ack for given rule selector")
 		handleServerError(writer, err)
 		return
 	}
 
-	err = server.processClustersDetailResponse(impactedClusters, disabledClusters, activeClustersInfo, acknowledge, ackFound, writer)
+	err = server.processClustersDetailResponse(
+		impactedClusters, disabledClusters, activeClustersInfo, acknowledge, ackFound, writer, negotiateExportFormat(request),
+	)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Str(userIDTag, string(userID)).Str(selectorStr, string(selector)).
 			Msg("Couldn't process response for clusters detail")
 		handleServerError(writer, err)
 		return
@@ -1142,6 +1243,7 @@ func (server HTTPServer) getClustersDetailForRule(writer http.ResponseWriter, re
 
 // getListOfDisabledClusters reads list of disabled clusters from aggregator
 func (server *HTTPServer) getListOfDisabledClusters(
+	ctx context.Context,
 	orgID types.OrgID, ruleSelector ctypes.RuleSelector,
 ) ([]ctypes.DisabledClusterInfo, error) {
 	var response struct {
@@ -1165,7 +1267,7 @@ func (server *HTTPServer) getListOfDisabledClusters(
 
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	resp, err := http.Get(aggregatorURL)
+	resp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		return nil, err
 	}
@@ -1188,6 +1290,7 @@ func (server *HTTPServer) getListOfDisabledClusters(
 // getListOfDisabledClustersAndAck reads list of disabled clusters from aggregator and gets
 // information about rule ack
 func (server *HTTPServer) getListOfDisabledClustersAndAck(
+	ctx context.Context,
 	orgID types.OrgID, ruleSelector ctypes.RuleSelector,
 ) (
 	disabledClusters []ctypes.DisabledClusterInfo,
@@ -1195,7 +1298,7 @@ func (server *HTTPServer) getListOfDisabledClustersAndAck(
 	ackFound bool,
 	err error,
 ) {
-	disabledClusters, err = server.getListOfDisabledClusters(orgID, ruleSelector)
+	disabledClusters, err = server.getListOfDisabledClusters(ctx, orgID, ruleSelector)
 	if err != nil {
 		log.Error().Err(err).Int(orgIDTag, int(orgID)).Str(selectorStr, string(ruleSelector)).
 			Msg("Couldn't retrieve disabled clusters for given rule selector")
@@ -1208,6 +1311,7 @@ func (server *HTTPServer) getListOfDisabledClustersAndAck(
 	}
 
 	acknowledge, ackFound, err = server.readRuleDisableStatus(
+		ctx,
 		ctypes.Component(ruleID),
 		errorKey,
 		orgID,
@@ -1222,6 +1326,7 @@ func (server *HTTPServer) getListOfDisabledClustersAndAck(
 }
 
 // processClustersDetailResponse processes responses from aggregator and AMS API and sends a response
+// in the requested format
 func (server *HTTPServer) processClustersDetailResponse(
 	impactedClusters []ctypes.HittingClustersData,
 	disabledClusters []ctypes.DisabledClusterInfo,
@@ -1229,6 +1334,7 @@ func (server *HTTPServer) processClustersDetailResponse(
 	acknowledge ctypes.Acknowledgement,
 	ruleAcked bool,
 	writer http.ResponseWriter,
+	format exportFormat,
 ) error {
 	data := types.ClustersDetailData{
 		EnabledClusters:  make([]ctypes.HittingClustersData, 0),
@@ -1273,6 +1379,10 @@ func (server *HTTPServer) processClustersDetailResponse(
 		}
 	}
 
+	if format != jsonExportFormat {
+		return sendExport(writer, format, clusterDetailExportRecords(&data), clusterDetailExportColumns)
+	}
+
 	response := types.ClustersDetailResponse{
 		Status: OkMsg,
 		Data:   data,
@@ -1286,9 +1396,10 @@ func (server *HTTPServer) processClustersDetailResponse(
 // This endpoint was previously causing a performance issue affecting the insights-operator. We need to ensure
 // that this endpoint will always be 100% backwards compatible.
 func (server *HTTPServer) getRequestStatusForCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -1312,14 +1423,14 @@ func (server *HTTPServer) getRequestStatusForCluster(writer http.ResponseWriter,
 	}
 
 	// perform EXISTS query to check whether an archive (request_id) was processed
-	err = server.redis.GetRequestStatus(orgID, clusterID, requestID)
+	err = server.tracedRedis(request.Context()).GetRequestStatus(orgID, clusterID, requestID)
 	if err != nil {
 		switch err.(type) {
 		case *utypes.ItemNotFoundError:
 			// keep same message as in the original endpoint
 			err := responses.SendNotFound(writer, RequestIDNotFound)
 			if err != nil {
-				log.Error().Err(err).Msg(responseDataError)
+				logger.Error().Err(err).Msg(responseDataError)
 			}
 			return
 		default:
@@ -1343,11 +1454,13 @@ func (server *HTTPServer) getRequestStatusForCluster(writer http.ResponseWriter,
 }
 
 // getRequestsForCluster method implements endpoint that should return a list of
-// all request IDs and their details for given cluster
+// all request IDs and their details for given cluster. Number of returned
+// requests can be limited, the most recently received ones are returned then.
 func (server *HTTPServer) getRequestsForCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -1358,14 +1471,20 @@ func (server *HTTPServer) getRequestsForCluster(writer http.ResponseWriter, requ
 		return
 	}
 
+	limit, err := readNonNegativeIntParam(LimitParam, request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
 	// make sure we don't access server.redis when it's nil
 	if !server.checkRedisClientReadiness(writer) {
 		// error has been handled already
 		return
 	}
 
-	// get request ID list from Redis using SCAN command
-	requestIDsForCluster, err := server.redis.GetRequestIDsForClusterID(orgID, clusterID)
+	// get request ID list from Redis
+	requestIDsForCluster, err := server.tracedRedis(request.Context()).GetRequestIDsForClusterID(orgID, clusterID, limit)
 	if err != nil {
 		handleServerError(writer, err)
 		return
@@ -1373,13 +1492,13 @@ func (server *HTTPServer) getRequestsForCluster(writer http.ResponseWriter, requ
 	if len(requestIDsForCluster) == 0 {
 		err := responses.SendNotFound(writer, RequestsForClusterNotFound)
 		if err != nil {
-			log.Error().Err(err).Msg(responseDataError)
+			logger.Error().Err(err).Msg(responseDataError)
 		}
 		return
 	}
 
 	// get data for each request ID. Omit missing keys in case the data expired in the meantime
-	requestIDsData, err := server.redis.GetTimestampsForRequestIDs(orgID, clusterID, requestIDsForCluster, true)
+	requestIDsData, err := server.tracedRedis(request.Context()).GetTimestampsForRequestIDs(orgID, clusterID, requestIDsForCluster, true)
 	if err != nil {
 		handleServerError(writer, err)
 		return
@@ -1402,16 +1521,17 @@ func (server *HTTPServer) getRequestsForCluster(writer http.ResponseWriter, requ
 // getRequestsForCluster method implements endpoint that should return a list of
 // request IDs and their details for given cluster and given list of request IDs provided in request body
 func (server *HTTPServer) getRequestsForClusterPostVariant(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	const logMsg = "getRequestsForClusterPostVariant"
 
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
-	log.Debug().Uint32(orgIDTag, uint32(orgID)).Msg(logMsg)
+	logger.Debug().Uint32(orgIDTag, uint32(orgID)).Msg(logMsg)
 
 	clusterID, successful := httputils.ReadClusterName(writer, request)
 	if !successful {
@@ -1419,7 +1539,7 @@ func (server *HTTPServer) getRequestsForClusterPostVariant(writer http.ResponseW
 		return
 	}
 
-	log.Debug().Str("selected cluster", string(clusterID)).Msg(logMsg)
+	logger.Debug().Str("selected cluster", string(clusterID)).Msg(logMsg)
 
 	// get request ID list from request body
 	requestIDsForCluster, err := readRequestIDList(writer, request)
@@ -1428,7 +1548,7 @@ func (server *HTTPServer) getRequestsForClusterPostVariant(writer http.ResponseW
 		return
 	}
 
-	log.Debug().
+	logger.Debug().
 		Uint32(orgIDTag, uint32(orgID)).
 		Str("selected cluster", string(clusterID)).
 		Int("IDS count", len(requestIDsForCluster)).
@@ -1441,7 +1561,7 @@ func (server *HTTPServer) getRequestsForClusterPostVariant(writer http.ResponseW
 	}
 
 	// get data for each request ID. Don't omit missing keys, because requester wants to know which are valid
-	requestIDsData, err := server.redis.GetTimestampsForRequestIDs(orgID, clusterID, requestIDsForCluster, false)
+	requestIDsData, err := server.tracedRedis(request.Context()).GetTimestampsForRequestIDs(orgID, clusterID, requestIDsForCluster, false)
 	if err != nil {
 		handleServerError(writer, err)
 		return
@@ -1464,9 +1584,10 @@ func (server *HTTPServer) getRequestsForClusterPostVariant(writer http.ResponseW
 // getReportForRequest method implements endpoint that should return
 // simplified result for given request ID
 func (server *HTTPServer) getReportForRequest(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -1490,23 +1611,23 @@ func (server *HTTPServer) getReportForRequest(writer http.ResponseWriter, reques
 	}
 
 	// get rule hits from Redis
-	ruleHits, err := server.redis.GetRuleHitsForRequest(orgID, clusterID, requestID)
+	ruleHits, err := server.tracedRedis(request.Context()).GetRuleHitsForRequest(orgID, clusterID, requestID)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
 	// get a map of acknowledged rules
-	ackedRulesMap, err := server.getRuleAcksMap(orgID)
+	ackedRulesMap, err := server.getRuleAcksMap(request.Context(), orgID)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
 	// retrieve user disabled rules for given cluster
-	disabledRulesForCluster, err := server.getDisabledRulesForClusterMap(writer, orgID, clusterID)
+	disabledRulesForCluster, err := server.getDisabledRulesForClusterMap(request.Context(), writer, orgID, clusterID)
 	if err != nil {
-		log.Error().Err(err).Msg("problem getting user disabled rules for cluster")
+		logger.Error().Err(err).Msg("problem getting user disabled rules for cluster")
 		// server error has been handled already
 		return
 	}
@@ -1573,16 +1694,40 @@ func filterRulesGetContent(
 }
 
 func (server HTTPServer) getDisabledRulesForClusterMap(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID types.OrgID,
 	clusterID types.ClusterName,
 ) (
 	disabledRules map[types.RuleID]bool, err error,
 ) {
-	disabledRules = make(map[types.RuleID]bool)
+	disabledRulesPerCluster, err := server.getDisabledRulesForClustersMap(ctx, writer, orgID, []types.ClusterName{clusterID})
+	if err != nil {
+		return
+	}
+
+	disabledRules = disabledRulesPerCluster[clusterID]
+	if disabledRules == nil {
+		disabledRules = make(map[types.RuleID]bool)
+	}
+
+	return
+}
+
+// getDisabledRulesForClustersMap method returns user disabled rules for all
+// given clusters, read from aggregator in a single call
+func (server HTTPServer) getDisabledRulesForClustersMap(
+	ctx context.Context,
+	writer http.ResponseWriter,
+	orgID types.OrgID,
+	clusterIDs []types.ClusterName,
+) (
+	disabledRules map[types.ClusterName]map[types.RuleID]bool, err error,
+) {
+	disabledRules = make(map[types.ClusterName]map[types.RuleID]bool)
 
 	// use existing endpoint accepting list of clusters
-	listOfDisabledRules, err := server.readListOfDisabledRulesForClusters(writer, orgID, []types.ClusterName{clusterID})
+	listOfDisabledRules, err := server.readListOfDisabledRulesForClusters(ctx, writer, orgID, clusterIDs)
 	if err != nil {
 		log.Error().Err(err).Msg("error reading disabled rules from aggregator")
 		handleServerError(writer, err)
@@ -1597,7 +1742,10 @@ func (server HTTPServer) getDisabledRulesForClusterMap(
 			continue
 		}
 
-		disabledRules[compositeRuleID] = true
+		if disabledRules[disabledRule.ClusterID] == nil {
+			disabledRules[disabledRule.ClusterID] = make(map[types.RuleID]bool)
+		}
+		disabledRules[disabledRule.ClusterID][compositeRuleID] = true
 	}
 
 	return
@@ -1613,34 +1761,37 @@ func (server *HTTPServer) checkRedisClientReadiness(writer http.ResponseWriter)
 }
 
 // getDVONamespaceList returns a list of all DVO namespaces to which an account has access.
+// The list can be exported as CSV or NDJSON based on the Accept header.
 func (server *HTTPServer) getDVONamespaceList(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	tStart := time.Now()
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
 
 	// get active clusters info from AMS API
-	activeClustersInfo, err := server.readClusterInfoForOrgID(orgID)
+	activeClustersInfo, err := server.readClusterInfoForOrgID(request.Context(), orgID)
 	if err != nil {
-		log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
+		logger.Error().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
 		handleServerError(writer, err)
 		return
 	}
 	clusterInfoMap := types.ClusterInfoArrayToMap(activeClustersInfo)
+	tracing.SetAttributes(request.Context(), tracing.ClusterCount(len(activeClustersInfo)))
 
-	log.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to get %d clusters from AMS API", time.Since(tStart), len(activeClustersInfo))
+	logger.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to get %d clusters from AMS API", time.Since(tStart), len(activeClustersInfo))
 
 	// get workloads for clusters
-	workloads, err := server.getWorkloadsForOrganization(orgID, writer, activeClustersInfo)
+	workloads, err := server.getWorkloadsForOrganization(request.Context(), orgID, writer, activeClustersInfo)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
-	log.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to get %d workloads from aggregator", time.Since(tStart), len(workloads))
+	logger.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to get %d workloads from aggregator", time.Since(tStart), len(workloads))
 
 	workloadsProcessed, err := processWorkloadsRecommendations(clusterInfoMap, workloads)
 	if err != nil {
@@ -1648,12 +1799,19 @@ func (server *HTTPServer) getDVONamespaceList(writer http.ResponseWriter, reques
 		return
 	}
 
+	if format := negotiateExportFormat(request); format != jsonExportFormat {
+		if err = sendExport(writer, format, slices.Values(workloadsProcessed), workloadExportColumns); err != nil {
+			logger.Error().Err(err).Msg(problemSendingResponseError)
+		}
+		return
+	}
+
 	// prepare response
 	responseData := map[string]interface{}{}
 	responseData["status"] = OkMsg
 	responseData["workloads"] = workloadsProcessed
 
-	log.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to process response into %d results", time.Since(tStart), len(workloadsProcessed))
+	logger.Info().Int(orgIDTag, int(orgID)).Msgf("getDVONamespaceList took %v to process response into %d results", time.Since(tStart), len(workloadsProcessed))
 
 	// send response to client
 	err = responses.SendOK(writer, responseData)
@@ -1719,9 +1877,10 @@ func processWorkloadsRecommendations(
 
 // getDVONamespacesForCluster returns a DVO workload recommendations for a single namespace within a cluster
 func (server *HTTPServer) getDVONamespacesForCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -1739,29 +1898,29 @@ func (server *HTTPServer) getDVONamespacesForCluster(writer http.ResponseWriter,
 
 	// get cluster info from AMS API
 	if server.amsClient == nil && !server.Config.UseOrgClustersFallback {
-		log.Error().Msg("unable to retrieve info about cluster")
+		logger.Error().Msg("unable to retrieve info about cluster")
 		handleServerError(writer, &AMSAPIUnavailableError{})
 		return
 	}
 
 	clusterInfo, err := server.amsClient.GetSingleClusterInfoForOrganization(orgID, clusterID)
 	if err != nil {
-		log.Warn().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
+		logger.Warn().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
 		handleServerError(writer, err)
 		return
 	}
 
 	// get namespace data from aggregator
-	workloads, err := server.getWorkloadsForCluster(orgID, clusterID, namespace)
+	workloads, err := server.getWorkloadsForCluster(request.Context(), orgID, clusterID, namespace)
 	if err != nil {
 		switch err.(type) {
 		case *json.SyntaxError:
 			msg := "aggregator provided a wrong response"
-			log.Error().Err(err).Msg(msg)
+			logger.Error().Err(err).Msg(msg)
 			handleServerError(writer, errors.New(msg))
 			return
 		case *url.Error:
-			log.Error().Err(err).Msg("aggregator is not responding")
+			logger.Error().Err(err).Msg("aggregator is not responding")
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
 			return
 		default:
@@ -1773,7 +1932,7 @@ func (server *HTTPServer) getDVONamespacesForCluster(writer http.ResponseWriter,
 	workloadsProcessed, err := fillInWorkloadsData(clusterInfo, workloads)
 	if err != nil {
 		msg := "unable to fill in data from content-service"
-		log.Error().Err(err).Msg(msg)
+		logger.Error().Err(err).Msg(msg)
 		handleServerError(writer, errors.New(msg))
 		return
 	}
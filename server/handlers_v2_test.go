@@ -1169,9 +1169,10 @@ func TestHTTPServer_ClustersDetailEndpointAggregatorResponse500(t *testing.T) {
 			Endpoint:     server.ClustersDetail,
 			EndpointArgs: []interface{}{testdata.Rule1CompositeID},
 			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
 		}, &helpers.APIResponse{
 			StatusCode: http.StatusInternalServerError,
-			Body:       aggregatorResponse,
+			Body:       `{"status": "Internal Server Error", "request_id": "test-request-id"}`,
 		},
 	)
 }
@@ -1377,9 +1378,10 @@ func TestHTTPServer_GetRequestStatusForCluster_BadRequestID(t *testing.T) {
 				Endpoint:     server.StatusOfRequestID,
 				EndpointArgs: []interface{}{testdata.ClusterName, "_"}, // invalid requestID
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusBadRequest,
-				Body:       `{"status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
+				Body:       `{"request_id":"test-request-id","status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
 			},
 		)
 	}, testTimeout)
@@ -1452,6 +1454,7 @@ func TestHTTPServer_GetRequestsForCluster_OK1Request(t *testing.T) {
 
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
 
+		helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
 		expectedKey1stCommand := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
 		redisServer.ExpectScan(0, expectedKey1stCommand, services.ScanBatchCount).SetVal([]string{"requestID1"}, 0)
 
@@ -1500,6 +1503,7 @@ func TestHTTPServer_GetRequestsForCluster_OK3Requests(t *testing.T) {
 			requestIDs[i] = fmt.Sprintf("requestID%d", i)
 		}
 
+		helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
 		expectedKey1stCommand := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
 		redisServer.ExpectScan(0, expectedKey1stCommand, services.ScanBatchCount).SetVal([]string{requestIDs[0], requestIDs[1], requestIDs[2]}, 0)
 
@@ -1544,6 +1548,77 @@ func TestHTTPServer_GetRequestsForCluster_OK3Requests(t *testing.T) {
 	}, testTimeout)
 }
 
+func TestHTTPServer_GetRequestsForCluster_Limit(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		// only the most recent requests are read from the index
+		indexKey := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName)
+		redisServer.ExpectZRevRange(indexKey, 0, 1).SetVal([]string{"requestID2", "requestID1"})
+		redisServer.ExpectExists(fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName, "requestID2")).SetVal(1)
+		redisServer.ExpectExists(fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName, "requestID1")).SetVal(1)
+
+		expectedKey2ndCommand := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, "requestID2")
+		redisServer.ExpectHMGet(
+			expectedKey2ndCommand, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+		).SetVal([]interface{}{"requestID2", receivedTimestampTest, processedTimestampTest})
+
+		iou_helpers.AssertAPIRequest(
+			t,
+			testServer,
+			serverConfigXRH.APIv2Prefix,
+			&helpers.APIRequest{
+				Method:       http.MethodGet,
+				Endpoint:     server.ListAllRequestIDs + "?limit=1",
+				EndpointArgs: []interface{}{testdata.ClusterName},
+				XRHIdentity:  goodXRHAuthToken,
+			}, &helpers.APIResponse{
+				StatusCode: http.StatusOK,
+				Body: fmt.Sprintf(`{
+					"cluster":"%v",
+					"status":"ok",
+					"requests":[
+						{"processed":"%v", "received":"%v", "requestID":"requestID2", "valid":true}
+					]
+				}`, testdata.ClusterName, processedTimestampTest, receivedTimestampTest),
+			},
+		)
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+func TestHTTPServer_GetRequestsForCluster_BadLimit(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	iou_helpers.AssertAPIRequest(
+		t,
+		testServer,
+		serverConfigXRH.APIv2Prefix,
+		&helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.ListAllRequestIDs + "?limit=-1",
+			EndpointArgs: []interface{}{testdata.ClusterName},
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusBadRequest,
+			Body: `{
+				"status": "Error during parsing param 'limit' with value '-1'. Error: 'non-negative integer expected'",
+				"request_id": "test-request-id"
+			}`,
+		},
+	)
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
 func TestHTTPServer_GetRequestsForCluster_RequestsNotFound(t *testing.T) {
 	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
 		defer helpers.CleanAfterGock(t)
@@ -1552,6 +1627,7 @@ func TestHTTPServer_GetRequestsForCluster_RequestsNotFound(t *testing.T) {
 
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
 
+		helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
 		expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
 		redisServer.ExpectScan(0, expectedKey, services.ScanBatchCount).SetVal([]string{}, 0)
 
@@ -1660,6 +1736,7 @@ func TestHTTPServer_GetRequestsForCluster_RedisError500(t *testing.T) {
 
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
 
+		helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
 		expectedKey1stCommand := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
 		redisServer.ExpectScan(0, expectedKey1stCommand, services.ScanBatchCount).SetErr(errors.New("Redis server failure"))
 
@@ -1689,6 +1766,7 @@ func TestHTTPServer_GetRequestsForCluster_RedisError500_2ndCmd(t *testing.T) {
 
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
 
+		helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
 		expectedKey1stCommand := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
 		redisServer.ExpectScan(0, expectedKey1stCommand, services.ScanBatchCount).SetVal([]string{"requestID1"}, 0)
 
@@ -2003,9 +2081,10 @@ func TestHTTPServer_GetRequestsForClusterPostVariant_NoBody(t *testing.T) {
 				Endpoint:     server.ListAllRequestIDs,
 				EndpointArgs: []interface{}{testdata.ClusterName},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusBadRequest,
-				Body:       `{"status":"client didn't provide request body"}`,
+				Body:       `{"request_id":"test-request-id","status":"client didn't provide request body"}`,
 			},
 		)
 	}, testTimeout)
@@ -2030,10 +2109,11 @@ func TestHTTPServer_GetRequestsForClusterPostVariant_BadBodyContent(t *testing.T
 				Endpoint:     server.ListAllRequestIDs,
 				EndpointArgs: []interface{}{testdata.ClusterName},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 				Body:         "body is not JSON",
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusBadRequest,
-				Body:       `{"status":"client didn't provide a valid request body"}`,
+				Body:       `{"request_id":"test-request-id","status":"client didn't provide a valid request body"}`,
 			},
 		)
 	}, testTimeout)
@@ -2061,10 +2141,11 @@ func TestHTTPServer_GetRequestsForClusterPostVariant_BadRequestID(t *testing.T)
 				Endpoint:     server.ListAllRequestIDs,
 				EndpointArgs: []interface{}{testdata.ClusterName},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 				Body:         reqBody,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusBadRequest,
-				Body:       `{"status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
+				Body:       `{"request_id":"test-request-id","status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
 			},
 		)
 	}, testTimeout)
@@ -2096,9 +2177,10 @@ func TestHTTPServer_GetReportForRequest_OK_RequestNotFound(t *testing.T) {
 				Endpoint:     server.RuleHitsForRequestID,
 				EndpointArgs: []interface{}{testdata.ClusterName, "requestID1"},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusNotFound,
-				Body:       `{"status":"Item with ID requestID1 was not found in the storage"}`,
+				Body:       `{"request_id":"test-request-id","status":"Item with ID requestID1 was not found in the storage"}`,
 			},
 		)
 
@@ -2319,9 +2401,10 @@ func TestHTTPServer_GetReportForRequest_BadRequestID(t *testing.T) {
 				Endpoint:     server.RuleHitsForRequestID,
 				EndpointArgs: []interface{}{testdata.ClusterName, "_"}, // invalid request ID
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusBadRequest,
-				Body:       `{"status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
+				Body:       `{"request_id":"test-request-id","status":"Error during parsing param 'request_id' with value '_'. Error: 'invalid request ID: '_''"}`,
 			},
 		)
 	}, testTimeout)
@@ -2682,7 +2765,7 @@ func TestHTTPServer_GetReportForRequest_NoRedis(t *testing.T) {
 		requestIDList := []types.RequestID{"requestID1"}
 		reqBody, _ := json.Marshal(requestIDList)
 
-		expectedResponse := `{"status": "Internal Server Error"}`
+		expectedResponse := `{"status": "Internal Server Error", "request_id": "test-request-id"}`
 
 		iou_helpers.AssertAPIRequest(
 			t,
@@ -2693,6 +2776,7 @@ func TestHTTPServer_GetReportForRequest_NoRedis(t *testing.T) {
 				Endpoint:     server.RuleHitsForRequestID,
 				EndpointArgs: []interface{}{testdata.ClusterName, "requestID1"},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 				Body:         reqBody,
 			}, &helpers.APIResponse{
 				StatusCode: http.StatusInternalServerError,
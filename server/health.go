@@ -0,0 +1,306 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+)
+
+const (
+	// DependencyStatusOK means that the dependency is available
+	DependencyStatusOK = "ok"
+	// DependencyStatusDegraded means that the dependency is not available
+	// and the smart proxy can't serve requests properly
+	DependencyStatusDegraded = "degraded"
+	// DependencyStatusDisabled means that the dependency is not configured.
+	// It does not affect readiness of the service
+	DependencyStatusDisabled = "disabled"
+
+	// names of dependencies reported by readiness endpoint
+	aggregatorDependency       = "aggregator"
+	contentServiceDependency   = "content-service"
+	amsDependency              = "ams"
+	redisDependency            = "redis"
+	rbacDependency             = "rbac"
+	contentDirectoryDependency = "content-directory"
+
+	// dependencyCheckTimeout is the maximum time spent checking one
+	// upstream service during readiness check
+	dependencyCheckTimeout = 2 * time.Second
+
+	// upstreamStatusCacheDuration is the time for which results of checks
+	// of upstream services are reused by readiness endpoint, so probes
+	// don't call the upstream services each time
+	upstreamStatusCacheDuration = 30 * time.Second
+)
+
+// DependencyStatus represents state of one dependency reported by the
+// readiness endpoint
+type DependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// dependencyCheck is a function that checks one dependency
+type dependencyCheck func() DependencyStatus
+
+// dependencyStatusCache stores results of the last check of upstream
+// services
+type dependencyStatusCache struct {
+	mutex     sync.Mutex
+	checkedAt time.Time
+	statuses  map[string]DependencyStatus
+}
+
+// livenessEndpoint method handles requests to the liveness endpoint. It just
+// reports that the process is able to handle HTTP requests.
+func (server *HTTPServer) livenessEndpoint(writer http.ResponseWriter, _ *http.Request) {
+	err := responses.SendOK(writer, responses.BuildOkResponse())
+	if err != nil {
+		log.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// readinessEndpoint method handles requests to the readiness endpoint. All
+// dependencies are checked concurrently and the list of degraded ones is
+// returned. HTTP code 503 is returned only when local prerequisites of the
+// smart proxy are degraded. Upstream services are shared by all instances,
+// so their outage would make all instances unready at once; they are only
+// reported.
+func (server *HTTPServer) readinessEndpoint(writer http.ResponseWriter, _ *http.Request) {
+	dependencies := server.checkDependencies()
+
+	degraded := []string{}
+	unready := []string{}
+	for name, dependency := range dependencies {
+		if dependency.Status != DependencyStatusDegraded {
+			continue
+		}
+		degraded = append(degraded, name)
+		if localDependencies[name] {
+			unready = append(unready, name)
+		}
+	}
+	sort.Strings(degraded)
+
+	responseData := map[string]interface{}{}
+	responseData["dependencies"] = dependencies
+	responseData["degraded"] = degraded
+
+	statusCode := http.StatusOK
+	switch {
+	case server.IsShuttingDown():
+		statusCode = http.StatusServiceUnavailable
+		responseData["status"] = shuttingDownMessage
+	case len(unready) > 0:
+		statusCode = http.StatusServiceUnavailable
+		responseData["status"] = DependencyStatusDegraded
+		log.Warn().Strs("degraded", degraded).Msg("Smart proxy is not ready")
+	default:
+		if len(degraded) > 0 {
+			log.Warn().Strs("degraded", degraded).Msg("Upstream services are degraded")
+		}
+		responseData["status"] = OkMsg
+	}
+
+	err := responses.Send(statusCode, writer, responseData)
+	if err != nil {
+		log.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// localDependencies contains names of dependencies that make the service
+// unready when they are degraded
+var localDependencies = map[string]bool{
+	redisDependency:            true,
+	contentDirectoryDependency: true,
+}
+
+// checkDependencies method returns results of checks of all dependencies.
+// Local prerequisites are checked each time, results of checks of upstream
+// services are reused for upstreamStatusCacheDuration.
+func (server *HTTPServer) checkDependencies() map[string]DependencyStatus {
+	results := runDependencyChecks(map[string]dependencyCheck{
+		redisDependency:            server.checkRedis,
+		contentDirectoryDependency: checkContentDirectory,
+	})
+
+	for name, result := range server.checkUpstreamDependencies() {
+		results[name] = result
+	}
+
+	return results
+}
+
+// checkUpstreamDependencies method returns results of checks of upstream
+// services. The services are checked again only when cached results are
+// older than upstreamStatusCacheDuration.
+func (server *HTTPServer) checkUpstreamDependencies() map[string]DependencyStatus {
+	checks := map[string]dependencyCheck{
+		aggregatorDependency: func() DependencyStatus {
+			return checkUpstreamService(server.ServicesConfig.AggregatorBaseEndpoint)
+		},
+		contentServiceDependency: func() DependencyStatus {
+			return checkUpstreamService(server.ServicesConfig.ContentBaseEndpoint)
+		},
+		amsDependency:  server.checkAMSClient,
+		rbacDependency: server.checkRBAC,
+	}
+
+	cache := server.upstreamStatuses
+	if cache == nil {
+		return runDependencyChecks(checks)
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.statuses == nil || time.Since(cache.checkedAt) >= upstreamStatusCacheDuration {
+		cache.statuses = runDependencyChecks(checks)
+		cache.checkedAt = time.Now()
+	}
+
+	return cache.statuses
+}
+
+// runDependencyChecks function runs given dependency checks concurrently
+// and returns their results
+func runDependencyChecks(checks map[string]dependencyCheck) map[string]DependencyStatus {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]DependencyStatus, len(checks))
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check dependencyCheck) {
+			defer wg.Done()
+			result := check()
+			mutex.Lock()
+			results[name] = result
+			mutex.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// degradedStatus function constructs status of dependency that is not
+// available
+func degradedStatus(err error) DependencyStatus {
+	return DependencyStatus{
+		Status: DependencyStatusDegraded,
+		Error:  err.Error(),
+	}
+}
+
+// checkUpstreamService function checks if the upstream REST API service is
+// responding. Any response except server errors means the service is alive.
+func checkUpstreamService(baseEndpoint string) DependencyStatus {
+	if baseEndpoint == "" {
+		return DependencyStatus{Status: DependencyStatusDisabled}
+	}
+
+	client := http.Client{Timeout: dependencyCheckTimeout}
+	// #nosec G107
+	resp, err := client.Get(baseEndpoint)
+	if err != nil {
+		return degradedStatus(err)
+	}
+	defer services.CloseResponseBody(resp)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return degradedStatus(fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK}
+}
+
+// checkAMSClient method checks if AMS API is responding. The service is able
+// to work without AMS (using the cluster list from aggregator), so a missing
+// client does not make the service unready.
+func (server *HTTPServer) checkAMSClient() DependencyStatus {
+	if server.amsClient == nil {
+		return DependencyStatus{
+			Status: DependencyStatusDisabled,
+			Error:  "AMS client is not initialized",
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dependencyCheckTimeout)
+	defer cancel()
+
+	if err := server.amsClient.HealthCheck(ctx); err != nil {
+		return degradedStatus(err)
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK}
+}
+
+// checkRedis method checks if Redis server is responding
+func (server *HTTPServer) checkRedis() DependencyStatus {
+	if server.redis == nil {
+		return DependencyStatus{Status: DependencyStatusDisabled}
+	}
+
+	if err := server.redis.HealthCheck(); err != nil {
+		return degradedStatus(err)
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK}
+}
+
+// checkRBAC method checks if RBAC service is responding when RBAC is turned
+// on in configuration
+func (server *HTTPServer) checkRBAC() DependencyStatus {
+	if !server.Config.UseRBAC {
+		return DependencyStatus{Status: DependencyStatusDisabled}
+	}
+
+	if server.rbacClient == nil {
+		return degradedStatus(errors.New("RBAC client is not initialized"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dependencyCheckTimeout)
+	defer cancel()
+
+	if err := server.rbacClient.HealthCheck(ctx); err != nil {
+		return degradedStatus(err)
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK}
+}
+
+// checkContentDirectory function checks if the rule content has been
+// retrieved from content service
+func checkContentDirectory() DependencyStatus {
+	if !content.IsContentDirectoryReady() {
+		return degradedStatus(errors.New("rule content has not been loaded yet"))
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK}
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// readinessResponse represents body returned by readiness endpoint
+type readinessResponse struct {
+	Status       string                             `json:"status"`
+	Degraded     []string                           `json:"degraded"`
+	Dependencies map[string]server.DependencyStatus `json:"dependencies"`
+}
+
+// readinessChecker returns body checker that compares list of degraded
+// dependencies and overall status
+func readinessChecker(expectedStatus string, expectedDegraded []string) iou_helpers.BodyChecker {
+	return func(t testing.TB, _, got []byte) {
+		var resp readinessResponse
+		helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+		assert.Equal(t, expectedStatus, resp.Status)
+		assert.ElementsMatch(t, expectedDegraded, resp.Degraded)
+		assert.Len(t, resp.Dependencies, 6)
+	}
+}
+
+// expectDependenciesAvailable mocks responses from aggregator and content
+// service and sets up rule content
+func expectDependenciesAvailable(t testing.TB) {
+	helpers.FailOnError(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+	for _, baseEndpoint := range []string{
+		helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+		helpers.DefaultServicesConfig.ContentBaseEndpoint,
+	} {
+		helpers.GockExpectAPIRequest(t, baseEndpoint, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.MainEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+		})
+	}
+}
+
+// TestLivenessEndpoint checks that the liveness endpoint is accessible
+// without authentication under both API prefixes
+func TestLivenessEndpoint(t *testing.T) {
+	for _, prefix := range []string{helpers.DefaultServerConfig.APIv1Prefix, helpers.DefaultServerConfig.APIv2Prefix} {
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.LivenessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+	}
+}
+
+// TestReadinessEndpointOK checks that the readiness endpoint reports ready
+// service when all dependencies are available
+func TestReadinessEndpointOK(t *testing.T) {
+	for _, prefix := range []string{helpers.DefaultServerConfig.APIv1Prefix, helpers.DefaultServerConfig.APIv2Prefix} {
+		helpers.RunTestWithTimeout(t, func(t testing.TB) {
+			defer helpers.CleanAfterGock(t)
+			expectDependenciesAvailable(t)
+
+			redisClient, redisServer := helpers.GetMockRedis()
+			redisServer.ExpectPing().SetVal("PONG")
+
+			testServer := helpers.CreateHTTPServer(
+				&helpers.DefaultServerConfig, nil, helpers.AMSClientWithOrgResults(testdata.OrgID, nil),
+				&redisClient, nil, nil, nil, nil,
+			)
+			iou_helpers.AssertAPIRequest(t, testServer, prefix, &helpers.APIRequest{
+				Method:   http.MethodGet,
+				Endpoint: server.ReadinessEndpoint,
+			}, &helpers.APIResponse{
+				StatusCode:  http.StatusOK,
+				Body:        "",
+				BodyChecker: readinessChecker(server.OkMsg, []string{}),
+			})
+		}, testTimeout)
+	}
+}
+
+// TestReadinessEndpointRedisDegraded checks that the readiness endpoint
+// reports unavailable Redis server
+func TestReadinessEndpointRedisDegraded(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		expectDependenciesAvailable(t)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		redisServer.ExpectPing().SetErr(errors.New("connection refused"))
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.ReadinessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusServiceUnavailable,
+			Body:        "",
+			BodyChecker: readinessChecker(server.DependencyStatusDegraded, []string{"redis"}),
+		})
+	}, testTimeout)
+}
+
+// TestReadinessEndpointAggregatorDegraded checks that the readiness endpoint
+// reports aggregator that returns server errors, but the service stays ready
+func TestReadinessEndpointAggregatorDegraded(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		helpers.FailOnError(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.MainEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusBadGateway,
+		})
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.ContentBaseEndpoint, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.MainEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+		})
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.ReadinessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusOK,
+			Body:        "",
+			BodyChecker: readinessChecker(server.OkMsg, []string{"aggregator"}),
+		})
+	}, testTimeout)
+}
+
+// TestReadinessEndpointAMSDegraded checks that the readiness endpoint
+// reports AMS API that is not responding, but the service stays ready
+func TestReadinessEndpointAMSDegraded(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		expectDependenciesAvailable(t)
+
+		testServer := helpers.CreateHTTPServer(
+			&helpers.DefaultServerConfig, nil, helpers.AMSClientWithHealthCheckError("connection refused"),
+			nil, nil, nil, nil, nil,
+		)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.ReadinessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusOK,
+			Body:        "",
+			BodyChecker: readinessChecker(server.OkMsg, []string{"ams"}),
+		})
+	}, testTimeout)
+}
+
+// TestReadinessEndpointRBACDegraded checks that the readiness endpoint
+// reports RBAC service that is not responding, but the service stays ready
+func TestReadinessEndpointRBACDegraded(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		expectDependenciesAvailable(t)
+
+		serverConfig := helpers.DefaultServerConfig
+		serverConfig.UseRBAC = true
+		rbacClient := &MockRBACClient{healthCheckError: errors.New("connection refused")}
+
+		testServer := helpers.CreateHTTPServer(&serverConfig, nil, nil, nil, nil, nil, nil, rbacClient)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.ReadinessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusOK,
+			Body:        "",
+			BodyChecker: readinessChecker(server.OkMsg, []string{"rbac"}),
+		})
+	}, testTimeout)
+}
+
+// TestReadinessEndpointCachesUpstreamStatus checks that upstream services
+// are not called by each readiness check
+func TestReadinessEndpointCachesUpstreamStatus(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		expectDependenciesAvailable(t)
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		for i := 0; i < 2; i++ {
+			// upstream services are mocked for the first check only
+			iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+				Method:   http.MethodGet,
+				Endpoint: server.ReadinessEndpoint,
+			}, &helpers.APIResponse{
+				StatusCode:  http.StatusOK,
+				Body:        "",
+				BodyChecker: readinessChecker(server.OkMsg, []string{}),
+			})
+		}
+	}, testTimeout)
+}
+
+// TestReadinessEndpointDuringShutdown checks that the readiness endpoint
+// fails as soon as the server starts draining requests
+func TestReadinessEndpointDuringShutdown(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		expectDependenciesAvailable(t)
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		testServer.MarkShuttingDown()
+
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:   http.MethodGet,
+			Endpoint: server.ReadinessEndpoint,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusServiceUnavailable,
+		})
+	}, testTimeout)
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// etagHeader is the name of header with the version of returned data
+	etagHeader = "ETag"
+	// ifNoneMatchHeader is the name of header with versions of data the
+	// client has already received
+	ifNoneMatchHeader = "If-None-Match"
+	// weakETagPrefix is the prefix of weak entity tags
+	weakETagPrefix = "W/"
+)
+
+// getLatestReportForCluster method implements endpoint that should return
+// simplified result for the most recently received request of given cluster
+// whose report is stored. The response contains ETag header and HTTP code
+// 304 is returned without any body when the client already has the same
+// version of the result, in which case rule hits are not filtered and their
+// content is not read at all.
+func (server *HTTPServer) getLatestReportForCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	clusterID, successful := httputils.ReadClusterName(writer, request)
+	if !successful {
+		// error handled by function
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	// the latest request is read together with its rule hits using the
+	// index of requests, keys are scanned only for clusters without index
+	latestRequests, err := server.tracedRedis(request.Context()).GetLatestRequestsForClusters(
+		orgID, []types.ClusterName{clusterID},
+	)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	latest, found := latestRequests[clusterID]
+	if !found {
+		err := responses.SendNotFound(writer, RequestsForClusterNotFound)
+		if err != nil {
+			logger.Error().Err(err).Msg(responseDataError)
+		}
+		return
+	}
+
+	// get a map of acknowledged rules
+	ackedRulesMap, err := server.getRuleAcksMap(request.Context(), orgID)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// retrieve user disabled rules for given cluster
+	disabledRulesForCluster, err := server.getDisabledRulesForClusterMap(request.Context(), writer, orgID, clusterID)
+	if err != nil {
+		logger.Error().Err(err).Msg("problem getting user disabled rules for cluster")
+		// server error has been handled already
+		return
+	}
+
+	// the report can be compared without filtering rule hits and reading
+	// their content
+	etag := computeETag(reportValidator(latest.RequestStatus, content.GetContentVersion(), ackedRulesMap, disabledRulesForCluster))
+	writer.Header().Set(etagHeader, etag)
+	if etagMatches(request.Header.Get(ifNoneMatchHeader), etag) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// prepare response
+	responseData := map[string]interface{}{}
+	responseData["cluster"] = string(clusterID)
+	responseData["requestID"] = latest.RequestID
+	responseData["received"] = latest.Received
+	responseData["processed"] = latest.Processed
+	responseData["status"] = StatusProcessed
+	responseData["report"] = filterRulesGetContent(latest.RuleHits, ackedRulesMap, disabledRulesForCluster)
+
+	responseBytes, err := json.Marshal(responseData)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// send response to client
+	err = responses.Send(http.StatusOK, writer, responseBytes)
+	if err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// reportValidator function returns data the latest report depends on: the
+// request, its processed timestamp, version of rule content and rules
+// acknowledged in the organization or disabled for the cluster. Rule hits of
+// the request never change, so they don't need to be compared to detect a
+// change of the report.
+func reportValidator(
+	latest types.RequestStatus, contentVersion string, ackedRules, disabledRules map[types.RuleID]bool,
+) []byte {
+	validator := []string{latest.RequestID, latest.Processed, contentVersion}
+	validator = append(validator, sortedRuleIDs(ackedRules)...)
+	// separate both lists of rules
+	validator = append(validator, "")
+	validator = append(validator, sortedRuleIDs(disabledRules)...)
+
+	return []byte(strings.Join(validator, "\n"))
+}
+
+// sortedRuleIDs function returns IDs of rules set in given map in
+// alphabetical order
+func sortedRuleIDs(rules map[types.RuleID]bool) []string {
+	ruleIDs := make([]string, 0, len(rules))
+	for ruleID, set := range rules {
+		if set {
+			ruleIDs = append(ruleIDs, string(ruleID))
+		}
+	}
+	sort.Strings(ruleIDs)
+
+	return ruleIDs
+}
+
+// computeETag function returns strong entity tag for given data
+func computeETag(data []byte) string {
+	hash := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(hash[:]) + `"`
+}
+
+// etagMatches function checks if the value of If-None-Match header matches
+// given entity tag. Weak comparison is used as specified by RFC 9110.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, weakETagPrefix) == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+const (
+	receivedTimestamp        = "2026-01-01T09:55:00Z"
+	latestProcessedTimestamp = "2026-01-01T10:05:00Z"
+	olderProcessedTimestamp  = "2026-01-01T10:00:00Z"
+)
+
+// expectLatestRequest mocks reading of two indexed requests from Redis,
+// where the first one has been received later, and reading of given rule
+// acks and rule disables
+func expectLatestRequest(t *testing.T, redisServer redismock.ClientMock, ackedRules string) {
+	indexKey := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName)
+	redisServer.ExpectZRevRange(indexKey, 0, 1).SetVal([]string{"requestID1", "requestID2"})
+
+	for _, request := range []struct {
+		requestID string
+		processed string
+	}{
+		{"requestID1", latestProcessedTimestamp},
+		{"requestID2", olderProcessedTimestamp},
+	} {
+		key := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, request.requestID)
+		redisServer.ExpectHMGet(
+			key, services.RequestIDFieldName, services.ReceivedTimestampFieldName,
+			services.ProcessedTimestampFieldName, services.RuleHitsFieldName,
+		).SetVal([]interface{}{request.requestID, receivedTimestamp, request.processed, rule1Hit})
+	}
+
+	expectRuleDisablesForDiff(t, ackedRules)
+}
+
+// latestReportRequest executes request to the latest report endpoint with
+// given If-None-Match header
+func latestReportRequest(testServer *server.HTTPServer, ifNoneMatch string) *http.Response {
+	url := httputils.MakeURLToEndpoint(
+		helpers.DefaultServerConfig.APIv2Prefix, server.LatestReportForCluster, testdata.ClusterName,
+	)
+	req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+	req.Header.Set("x-rh-identity", goodXRHAuthToken)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	return iou_helpers.ExecuteRequest(testServer, req).Result()
+}
+
+// TestGetLatestReportForCluster checks that report for the request
+// processed as the last one is returned
+func TestGetLatestReportForCluster(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.LatestReportForCluster,
+			EndpointArgs: []interface{}{testdata.ClusterName},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{
+				"cluster": "%v",
+				"status": "processed",
+				"requestID": "requestID1",
+				"received": "%v",
+				"processed": "%v",
+				"report": [%v]
+			}`, testdata.ClusterName, receivedTimestamp, latestProcessedTimestamp,
+				simplifiedRuleHitJSON(testdata.Rule1ID, testdata.ErrorKey1, &testdata.RuleWithContent1)),
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetLatestReportForClusterNotModified checks that the body is not
+// returned when the client sends ETag of the same report
+func TestGetLatestReportForClusterNotModified(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+		response := latestReportRequest(testServer, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		etag := response.Header.Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		// rule content is not read when the report has not been changed
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+		response = latestReportRequest(testServer, `"other", W/`+etag)
+		assert.Equal(t, http.StatusNotModified, response.StatusCode)
+		assert.Equal(t, etag, response.Header.Get("ETag"))
+		body, err := io.ReadAll(response.Body)
+		helpers.FailOnError(t, err)
+		assert.Empty(t, body)
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetLatestReportForClusterAcked checks that the report is returned
+// again when rules acknowledged in the organization have been changed
+func TestGetLatestReportForClusterAcked(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+		response := latestReportRequest(testServer, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		etag := response.Header.Get("ETag")
+
+		expectLatestRequest(t, redisServer, helpers.ToJSONString(ResponseRule1DisabledSystemWide))
+		response = latestReportRequest(testServer, etag)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.NotEqual(t, etag, response.Header.Get("ETag"))
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetLatestReportForClusterContentChanged checks that the report is
+// returned again when different rule content has been loaded
+func TestGetLatestReportForClusterContentChanged(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+		response := latestReportRequest(testServer, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		etag := response.Header.Get("ETag")
+
+		err = loadMockRuleContentDir(&testdata.RuleContentDirectory5Rules)
+		assert.Nil(t, err)
+
+		expectLatestRequest(t, redisServer, ResponseNoRulesDisabledSystemWide)
+		response = latestReportRequest(testServer, etag)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.NotEqual(t, etag, response.Header.Get("ETag"))
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetLatestReportForClusterNotFound checks that 404 is returned when
+// there is no request stored for the cluster
+func TestGetLatestReportForClusterNotFound(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	indexKey := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName)
+	redisServer.ExpectZRevRange(indexKey, 0, 1).SetVal([]string{})
+	scanPattern := fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID)
+	redisServer.ExpectScan(0, scanPattern, services.ScanBatchCount).SetVal([]string{}, 0)
+
+	iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.LatestReportForCluster,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusNotFound,
+		Body:       `{"status": "Requests for cluster not found"}`,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestETagMatches checks comparison of entity tags from If-None-Match header
+func TestETagMatches(t *testing.T) {
+	const etag = `"abc"`
+
+	testCases := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{"", false},
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{"*", true},
+		{`"xyz"`, false},
+		{"abc", false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, server.ETagMatches(tc.ifNoneMatch, etag), tc.ifNoneMatch)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// StatusNoRequest is a status returned for clusters that have not sent any
+// request yet or whose requests have expired already
+const StatusNoRequest = "not_found"
+
+// getLatestRequestsForClusters method implements endpoint that should return
+// status of the most recently received request of each cluster from the list
+// provided in request body together with summary of its rule hits. Rules
+// acknowledged or disabled by the user are not counted. Data of all clusters
+// are read from Redis using pipelines, so the endpoint can replace a
+// separate call of requests endpoint for each cluster.
+func (server *HTTPServer) getLatestRequestsForClusters(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	clusterIDs, err := readClusterListFromBody(request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	latestRequests, err := server.tracedRedis(request.Context()).GetLatestRequestsForClusters(orgID, clusterIDs)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	var (
+		ackedRulesMap           map[ctypes.RuleID]bool
+		disabledRulesPerCluster map[types.ClusterName]map[types.RuleID]bool
+	)
+
+	// user data are needed only when there are some rule hits to summarize
+	if len(latestRequests) > 0 {
+		ackedRulesMap, err = server.getRuleAcksMap(request.Context(), orgID)
+		if err != nil {
+			handleServerError(writer, err)
+			return
+		}
+
+		clustersWithRequests := make([]types.ClusterName, 0, len(latestRequests))
+		for _, clusterID := range clusterIDs {
+			if _, found := latestRequests[clusterID]; found {
+				clustersWithRequests = append(clustersWithRequests, clusterID)
+			}
+		}
+
+		disabledRulesPerCluster, err = server.getDisabledRulesForClustersMap(
+			request.Context(), writer, orgID, clustersWithRequests,
+		)
+		if err != nil {
+			logger.Error().Err(err).Msg("problem getting user disabled rules for clusters")
+			// server error has been handled already
+			return
+		}
+	}
+
+	// keep the order of clusters from request
+	statuses := make([]types.ClusterLatestRequestStatus, len(clusterIDs))
+	for i, clusterID := range clusterIDs {
+		statuses[i] = types.ClusterLatestRequestStatus{
+			ClusterID: clusterID,
+			Status:    StatusNoRequest,
+		}
+
+		latest, found := latestRequests[clusterID]
+		if !found {
+			continue
+		}
+
+		statuses[i].RequestID = latest.RequestID
+		statuses[i].Received = latest.Received
+		statuses[i].Processed = latest.Processed
+		statuses[i].Status = StatusReceived
+		if latest.Processed != "" {
+			statuses[i].Status = StatusProcessed
+		}
+
+		ruleHits := filterRulesGetContent(latest.RuleHits, ackedRulesMap, disabledRulesPerCluster[clusterID])
+		statuses[i].RuleHits = summarizeRuleHits(ruleHits)
+	}
+
+	err = responses.SendOK(writer, responses.BuildOkResponseWithData("clusters", statuses))
+	if err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// readClusterListFromBody function reads list of clusters provided in
+// request body in the same format as used by multi-cluster URP endpoint.
+// Number of clusters is limited by MaxAllowedClusters.
+func readClusterListFromBody(request *http.Request) ([]types.ClusterName, error) {
+	if request.ContentLength <= 0 {
+		return nil, &NoBodyError{}
+	}
+
+	var clusterList ctypes.ClusterListInRequest
+	err := json.NewDecoder(request.Body).Decode(&clusterList)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clusterList.Clusters) > MaxAllowedClusters {
+		return nil, &TooManyClustersError{}
+	}
+
+	clusterIDs := make([]types.ClusterName, len(clusterList.Clusters))
+	for i, cluster := range clusterList.Clusters {
+		clusterIDs[i], err = httputils.ValidateClusterName(cluster)
+		if err != nil {
+			return nil, &RouterParsingError{
+				ParamName:  "clusters",
+				ParamValue: cluster,
+				ErrString:  "cluster ID in UUID format expected",
+			}
+		}
+	}
+
+	return clusterIDs, nil
+}
+
+// summarizeRuleHits function counts rule hits, both in total and by their
+// total risk
+func summarizeRuleHits(ruleHits []types.SimplifiedRuleHit) *types.RuleHitsSummary {
+	summary := types.RuleHitsSummary{
+		TotalHitCount:   len(ruleHits),
+		HitsByTotalRisk: make(map[int]int),
+	}
+
+	for _, ruleHit := range ruleHits {
+		summary.HitsByTotalRisk[ruleHit.TotalRisk]++
+	}
+
+	return &summary
+}
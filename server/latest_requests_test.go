@@ -0,0 +1,225 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// TestGetLatestRequestsForClusters checks that status of the latest request
+// and summary of not acked and not disabled rule hits are returned for each
+// cluster
+func TestGetLatestRequestsForClusters(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		indexKey := func(clusterID types.ClusterName) string {
+			return fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, clusterID)
+		}
+		reportKey := func(clusterID types.ClusterName, requestID string) string {
+			return fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, clusterID, requestID)
+		}
+
+		redisServer.ExpectZRevRange(indexKey(testdata.ClusterName), 0, 1).SetVal([]string{"requestID2"})
+		redisServer.ExpectZRevRange(indexKey(data.ClusterName2), 0, 1).SetVal([]string{"requestID5"})
+		redisServer.ExpectZRevRange(indexKey(data.ClusterName3), 0, 1).SetVal([]string{})
+		redisServer.ExpectHMGet(reportKey(testdata.ClusterName, "requestID2"), services.RequestIDFieldName,
+			services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName, services.RuleHitsFieldName,
+		).SetVal([]interface{}{"requestID2", receivedTimestamp, latestProcessedTimestamp,
+			strings.Join([]string{rule1Hit, rule2Hit, rule3Hit}, ",")})
+		redisServer.ExpectHMGet(reportKey(data.ClusterName2, "requestID5"), services.RequestIDFieldName,
+			services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName, services.RuleHitsFieldName,
+		).SetVal([]interface{}{"requestID5", receivedTimestamp, nil, nil})
+		redisServer.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+			SetVal([]string{}, 0)
+
+		// rule 2 is acked, rule 3 is disabled for the first cluster
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+			EndpointArgs: []interface{}{testdata.OrgID},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       helpers.ToJSONString(ResponseRule2DisabledSystemWide),
+		})
+
+		reqBody, _ := json.Marshal([]types.ClusterName{testdata.ClusterName, data.ClusterName2})
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodPost,
+			Endpoint:     ira_server.ListOfDisabledRulesForClusters,
+			EndpointArgs: []interface{}{testdata.OrgID},
+			Body:         reqBody,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{"rules":[{"ClusterID":"%v","RuleID":"%v.report","ErrorKey":"%v"}],"status":"ok"}`,
+				testdata.ClusterName, testdata.Rule3ID, testdata.ErrorKey3),
+		})
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodPost,
+			Endpoint:    server.LatestRequestsForClusters,
+			XRHIdentity: goodXRHAuthToken,
+			Body: fmt.Sprintf(`{"clusters":["%v","%v","%v"]}`,
+				testdata.ClusterName, data.ClusterName2, data.ClusterName3),
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{
+				"status": "ok",
+				"clusters": [
+					{
+						"cluster": "%v",
+						"status": "processed",
+						"requestID": "requestID2",
+						"received": "%v",
+						"processed": "%v",
+						"rule_hits": {"total_hit_count": 1, "hits_by_total_risk": {"%v": 1}}
+					},
+					{
+						"cluster": "%v",
+						"status": "received",
+						"requestID": "requestID5",
+						"received": "%v",
+						"rule_hits": {"total_hit_count": 0, "hits_by_total_risk": {}}
+					},
+					{
+						"cluster": "%v",
+						"status": "not_found"
+					}
+				]
+			}`, testdata.ClusterName, receivedTimestamp, latestProcessedTimestamp, testdata.RuleWithContent1.TotalRisk,
+				data.ClusterName2, receivedTimestamp, data.ClusterName3),
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetLatestRequestsForClusters_NoRequests checks that user data are not
+// read from aggregator when none of the clusters has sent any request
+func TestGetLatestRequestsForClusters_NoRequests(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	redisServer.ExpectZRevRange(fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName), 0, 1).
+		SetVal([]string{})
+	redisServer.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{}, 0)
+
+	iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.LatestRequestsForClusters,
+		XRHIdentity: goodXRHAuthToken,
+		Body:        fmt.Sprintf(`{"clusters":["%v"]}`, testdata.ClusterName),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       fmt.Sprintf(`{"status":"ok","clusters":[{"cluster":"%v","status":"not_found"}]}`, testdata.ClusterName),
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestGetLatestRequestsForClusters_RedisError checks that Redis error is
+// reported as internal server error
+func TestGetLatestRequestsForClusters_RedisError(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	redisServer.ExpectZRevRange(fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName), 0, 1).
+		SetErr(errors.New("Redis server failure"))
+
+	iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodPost,
+		Endpoint:     server.LatestRequestsForClusters,
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+		Body:         fmt.Sprintf(`{"clusters":["%v"]}`, testdata.ClusterName),
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusInternalServerError,
+		Body:       `{"status":"Internal Server Error","request_id":"test-request-id"}`,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestGetLatestRequestsForClusters_BadRequest checks that invalid list of
+// clusters is refused without accessing Redis
+func TestGetLatestRequestsForClusters_BadRequest(t *testing.T) {
+	tooManyClusters := generateUUIDs(server.MaxAllowedClusters + 1)
+
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus string
+	}{
+		{
+			name:           "no body",
+			body:           "",
+			expectedStatus: "client didn't provide request body",
+		},
+		{
+			name:           "too many clusters",
+			body:           fmt.Sprintf(`{"clusters":["%s"]}`, strings.Join(tooManyClusters, `","`)),
+			expectedStatus: "the maximum amount of clusters allowed are 100",
+		},
+		{
+			name: "invalid cluster ID",
+			body: fmt.Sprintf(`{"clusters":["%v"]}`, testdata.BadClusterName),
+			expectedStatus: fmt.Sprintf("Error during parsing param 'clusters' with value '%v'. "+
+				"Error: 'cluster ID in UUID format expected'", testdata.BadClusterName),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			redisClient, redisServer := helpers.GetMockRedis()
+			testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+			iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+				Method:       http.MethodPost,
+				Endpoint:     server.LatestRequestsForClusters,
+				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
+				Body:         tc.body,
+			}, &helpers.APIResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       fmt.Sprintf(`{"status":%q,"request_id":"test-request-id"}`, tc.expectedStatus),
+			})
+
+			helpers.RedisExpectationsMet(t, redisServer)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"iter"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ctypes "github.com/RedHatInsights/insights-results-types"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// CSVContentType represents the text/csv content type
+	CSVContentType = "text/csv; charset=utf-8"
+	// NDJSONContentType represents the newline delimited JSON content type
+	NDJSONContentType = "application/x-ndjson"
+
+	acceptHeader = "Accept"
+
+	// exportFlushInterval is the number of records after which the exported
+	// data are flushed to the client
+	exportFlushInterval = 100
+)
+
+// exportFormat represents format of the lists returned by endpoints
+// supporting export
+type exportFormat int
+
+const (
+	jsonExportFormat exportFormat = iota
+	csvExportFormat
+	ndjsonExportFormat
+)
+
+// exportColumn represents one column of the exported CSV file
+type exportColumn[T any] struct {
+	name  string
+	value func(record *T) string
+}
+
+// negotiateExportFormat function selects the format of the response based
+// on Accept header. The format accepted with the highest quality is used,
+// media ranges with zero quality are not acceptable. JSON response is used
+// when neither CSV nor NDJSON is preferred by the client.
+func negotiateExportFormat(request *http.Request) exportFormat {
+	qualities := map[exportFormat]float64{}
+
+	for _, mediaRange := range strings.Split(request.Header.Get(acceptHeader), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := params["q"]; found {
+			quality, err = strconv.ParseFloat(value, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		var format exportFormat
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			format = jsonExportFormat
+		case "text/csv":
+			format = csvExportFormat
+		case NDJSONContentType:
+			format = ndjsonExportFormat
+		default:
+			continue
+		}
+
+		qualities[format] = max(qualities[format], quality)
+	}
+
+	// JSON wins ties
+	selected := jsonExportFormat
+	for _, format := range []exportFormat{csvExportFormat, ndjsonExportFormat} {
+		if qualities[format] > qualities[selected] {
+			selected = format
+		}
+	}
+
+	return selected
+}
+
+// sendExport function streams the records to the client in the requested
+// format. Records are flushed to the client periodically, so the whole
+// response is never buffered in memory. Records are generated by the
+// iterator one by one, so they don't need to be copied into a new list.
+func sendExport[T any](writer http.ResponseWriter, format exportFormat, records iter.Seq[T], columns []exportColumn[T]) error {
+	switch format {
+	case csvExportFormat:
+		writer.Header().Set(contentTypeHeader, CSVContentType)
+		writer.WriteHeader(http.StatusOK)
+		return writeCSVExport(writer, records, columns)
+	case ndjsonExportFormat:
+		writer.Header().Set(contentTypeHeader, NDJSONContentType)
+		writer.WriteHeader(http.StatusOK)
+		return writeNDJSONExport(writer, records)
+	default:
+		return errors.New("unsupported export format")
+	}
+}
+
+// flushExport function sends already written part of the response to the
+// client if the writer supports it
+func flushExport(writer http.ResponseWriter) error {
+	err := http.NewResponseController(writer).Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// writeCSVExport function writes header with column names followed by one
+// line for each record
+func writeCSVExport[T any](writer http.ResponseWriter, records iter.Seq[T], columns []exportColumn[T]) error {
+	csvWriter := csv.NewWriter(writer)
+
+	line := make([]string, len(columns))
+	for i, column := range columns {
+		line[i] = column.name
+	}
+	if err := csvWriter.Write(line); err != nil {
+		return err
+	}
+
+	written := 0
+	for record := range records {
+		for j, column := range columns {
+			line[j] = column.value(&record)
+		}
+		if err := csvWriter.Write(line); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushInterval == 0 {
+			if err := flushCSVExport(writer, csvWriter); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flushCSVExport(writer, csvWriter)
+}
+
+// flushCSVExport function flushes both CSV writer and response writer
+func flushCSVExport(writer http.ResponseWriter, csvWriter *csv.Writer) error {
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return err
+	}
+	return flushExport(writer)
+}
+
+// writeNDJSONExport function writes each record as JSON on separate line
+func writeNDJSONExport[T any](writer http.ResponseWriter, records iter.Seq[T]) error {
+	encoder := json.NewEncoder(writer)
+
+	written := 0
+	for record := range records {
+		if err := encoder.Encode(&record); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushInterval == 0 {
+			if err := flushExport(writer); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flushExport(writer)
+}
+
+// exportTime function formats time for the export, zero time is exported as
+// empty string
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// exportHits function returns number of hits with given total risk or
+// severity
+func exportHits(hits map[int]int, totalRisk int) string {
+	return strconv.Itoa(hits[totalRisk])
+}
+
+// recommendationExportColumns contains columns of exported recommendations
+// list
+var recommendationExportColumns = []exportColumn[types.RecommendationListView]{
+	{"rule_id", func(r *types.RecommendationListView) string { return string(r.RuleID) }},
+	{"description", func(r *types.RecommendationListView) string { return r.Description }},
+	{"generic", func(r *types.RecommendationListView) string { return r.Generic }},
+	{"publish_date", func(r *types.RecommendationListView) string { return exportTime(r.PublishDate) }},
+	{"total_risk", func(r *types.RecommendationListView) string { return strconv.Itoa(int(r.TotalRisk)) }},
+	{"resolution_risk", func(r *types.RecommendationListView) string { return strconv.Itoa(int(r.ResolutionRisk)) }},
+	{"impact", func(r *types.RecommendationListView) string { return strconv.Itoa(int(r.Impact)) }},
+	{"likelihood", func(r *types.RecommendationListView) string { return strconv.Itoa(int(r.Likelihood)) }},
+	{"tags", func(r *types.RecommendationListView) string { return strings.Join(r.Tags, " ") }},
+	{"disabled", func(r *types.RecommendationListView) string { return strconv.FormatBool(r.Disabled) }},
+	{"impacted_clusters_count", func(r *types.RecommendationListView) string {
+		return strconv.FormatUint(uint64(r.ImpactedClustersCnt), 10)
+	}},
+}
+
+// clusterExportColumns contains columns of exported clusters list. Hits by
+// total risk are exported in separate columns.
+var clusterExportColumns = []exportColumn[types.ClusterListView]{
+	{"cluster_id", func(c *types.ClusterListView) string { return string(c.ClusterID) }},
+	{"cluster_name", func(c *types.ClusterListView) string { return c.ClusterName }},
+	{"managed", func(c *types.ClusterListView) string { return strconv.FormatBool(c.Managed) }},
+	{"last_checked_at", func(c *types.ClusterListView) string { return string(c.LastCheckedAt) }},
+	{"cluster_version", func(c *types.ClusterListView) string { return string(c.Version) }},
+	{"total_hit_count", func(c *types.ClusterListView) string { return strconv.FormatUint(uint64(c.TotalHitCount), 10) }},
+	{"hits_critical", func(c *types.ClusterListView) string { return exportHits(c.HitsByTotalRisk, 4) }},
+	{"hits_important", func(c *types.ClusterListView) string { return exportHits(c.HitsByTotalRisk, 3) }},
+	{"hits_moderate", func(c *types.ClusterListView) string { return exportHits(c.HitsByTotalRisk, 2) }},
+	{"hits_low", func(c *types.ClusterListView) string { return exportHits(c.HitsByTotalRisk, 1) }},
+}
+
+// workloadExportColumns contains columns of exported DVO namespaces list.
+// Hits by severity are exported in separate columns.
+var workloadExportColumns = []exportColumn[types.Workload]{
+	{"cluster_uuid", func(w *types.Workload) string { return w.Cluster.UUID }},
+	{"cluster_display_name", func(w *types.Workload) string { return w.Cluster.DisplayName }},
+	{"namespace_uuid", func(w *types.Workload) string { return w.Namespace.UUID }},
+	{"namespace_name", func(w *types.Workload) string { return w.Namespace.FullName }},
+	{"recommendations", func(w *types.Workload) string { return strconv.Itoa(w.Metadata.Recommendations) }},
+	{"objects", func(w *types.Workload) string { return strconv.Itoa(w.Metadata.Objects) }},
+	{"reported_at", func(w *types.Workload) string { return w.Metadata.ReportedAt }},
+	{"last_checked_at", func(w *types.Workload) string { return w.Metadata.LastCheckedAt }},
+	{"highest_severity", func(w *types.Workload) string { return strconv.Itoa(w.Metadata.HighestSeverity) }},
+	{"hits_critical", func(w *types.Workload) string { return exportHits(w.Metadata.HitsBySeverity, 4) }},
+	{"hits_important", func(w *types.Workload) string { return exportHits(w.Metadata.HitsBySeverity, 3) }},
+	{"hits_moderate", func(w *types.Workload) string { return exportHits(w.Metadata.HitsBySeverity, 2) }},
+	{"hits_low", func(w *types.Workload) string { return exportHits(w.Metadata.HitsBySeverity, 1) }},
+}
+
+// clusterDetailExportRecord represents one cluster affected by the rule in
+// the exported clusters detail list. Enabled and disabled clusters are
+// exported together and distinguished by Disabled flag.
+type clusterDetailExportRecord struct {
+	ClusterID     ctypes.ClusterName `json:"cluster_id"`
+	ClusterName   string             `json:"cluster_name"`
+	LastCheckedAt string             `json:"last_checked_at,omitempty"`
+	ImpactedSince string             `json:"impacted,omitempty"`
+	Version       ctypes.Version     `json:"cluster_version,omitempty"`
+	Disabled      bool               `json:"disabled"`
+	DisabledAt    string             `json:"disabled_at,omitempty"`
+	Justification string             `json:"justification,omitempty"`
+}
+
+// clusterDetailExportColumns contains columns of exported clusters detail
+// list
+var clusterDetailExportColumns = []exportColumn[clusterDetailExportRecord]{
+	{"cluster_id", func(c *clusterDetailExportRecord) string { return string(c.ClusterID) }},
+	{"cluster_name", func(c *clusterDetailExportRecord) string { return c.ClusterName }},
+	{"last_checked_at", func(c *clusterDetailExportRecord) string { return c.LastCheckedAt }},
+	{"impacted", func(c *clusterDetailExportRecord) string { return c.ImpactedSince }},
+	{"cluster_version", func(c *clusterDetailExportRecord) string { return string(c.Version) }},
+	{"disabled", func(c *clusterDetailExportRecord) string { return strconv.FormatBool(c.Disabled) }},
+	{"disabled_at", func(c *clusterDetailExportRecord) string { return c.DisabledAt }},
+	{"justification", func(c *clusterDetailExportRecord) string { return c.Justification }},
+}
+
+// clusterDetailExportRecords function returns iterator converting enabled
+// and disabled clusters into records of the exported list one by one
+func clusterDetailExportRecords(data *types.ClustersDetailData) iter.Seq[clusterDetailExportRecord] {
+	return func(yield func(clusterDetailExportRecord) bool) {
+		for i := range data.EnabledClusters {
+			cluster := &data.EnabledClusters[i]
+			if !yield(clusterDetailExportRecord{
+				ClusterID:     cluster.Cluster,
+				ClusterName:   cluster.Name,
+				LastCheckedAt: cluster.LastSeen,
+				ImpactedSince: cluster.ImpactedSince,
+				Version:       cluster.Meta.Version,
+			}) {
+				return
+			}
+		}
+
+		for i := range data.DisabledClusters {
+			cluster := &data.DisabledClusters[i]
+			if !yield(clusterDetailExportRecord{
+				ClusterID:     cluster.ClusterID,
+				ClusterName:   cluster.ClusterName,
+				Disabled:      true,
+				DisabledAt:    exportTime(cluster.DisabledAt),
+				Justification: cluster.Justification,
+			}) {
+				return
+			}
+		}
+	}
+}
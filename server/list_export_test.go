@@ -0,0 +1,209 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// csvChecker returns body checker that compares the exported CSV file
+func csvChecker(expectedRecords [][]string) iou_helpers.BodyChecker {
+	return func(t testing.TB, _, got []byte) {
+		records, err := csv.NewReader(bytes.NewReader(got)).ReadAll()
+		helpers.FailOnError(t, err)
+		assert.Equal(t, expectedRecords, records)
+	}
+}
+
+// TestHTTPServer_RecommendationsListExportCSV checks that the
+// recommendations list is exported as CSV after sorting and pagination
+func TestHTTPServer_RecommendationsListExportCSV(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{testdata.RuleContent1, testdata.RuleContent2},
+		),
+	)
+	assert.Nil(t, err)
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		testServer := expectRecommendationsFor2Rules2Clusters(t)
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.RecommendationsListEndpoint + "?sort=-total_risk&limit=1",
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: http.Header{"Accept": []string{"text/csv"}},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"Content-Type": server.CSVContentType},
+			Body:       "",
+			BodyChecker: csvChecker([][]string{
+				{
+					"rule_id", "description", "generic", "publish_date", "total_risk", "resolution_risk",
+					"impact", "likelihood", "tags", "disabled", "impacted_clusters_count",
+				},
+				{
+					string(testdata.Rule2CompositeID), testdata.RuleErrorKey2.Description, testdata.RuleErrorKey2.Generic,
+					"1970-01-01T00:00:25Z", "2", fmt.Sprint(testdata.RuleErrorKey2.ResolutionRisk),
+					"2", "3", "", "false", "1",
+				},
+			}),
+		})
+	}, testTimeout)
+}
+
+// TestHTTPServer_ClustersViewExportNDJSON checks that the clusters list is
+// exported as NDJSON when it's preferred by the client
+func TestHTTPServer_ClustersViewExportNDJSON(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{testdata.RuleContent1, testdata.RuleContent2},
+		),
+	)
+	assert.Nil(t, err)
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		testServer := expectClustersView(t)
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.ClustersRecommendationsEndpoint + "?sort=cluster_name",
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: http.Header{"Accept": []string{"text/html, application/x-ndjson;q=0.9"}},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"Content-Type": server.NDJSONContentType},
+			Body:       "",
+			BodyChecker: func(t testing.TB, _, got []byte) {
+				names := []string{}
+				decoder := json.NewDecoder(bytes.NewReader(got))
+				for decoder.More() {
+					var cluster types.ClusterListView
+					helpers.FailOnError(t, decoder.Decode(&cluster))
+					names = append(names, cluster.ClusterName)
+				}
+				assert.Equal(t, []string{"alpha prod", "Beta", "gamma prod"}, names)
+				assert.Equal(t, 3, bytes.Count(got, []byte("\n")))
+			},
+		})
+	}, testTimeout)
+}
+
+// TestHTTPServer_ClustersDetailExportCSV checks that enabled and disabled
+// clusters affected by the rule are exported together as CSV
+func TestHTTPServer_ClustersDetailExportCSV(t *testing.T) {
+	clusters := []types.ClusterName{data.ClusterInfoResult2Clusters[0].ID, data.ClusterInfoResult2Clusters[1].ID}
+	assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     ira_server.RuleClusterDetailEndpoint,
+			EndpointArgs: []interface{}{testdata.Rule1CompositeID, testdata.OrgID, userIDInGoodAuthToken},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{"clusters":[{"cluster":"%v","last_checked_at":"%v","meta":{"cluster_version":"%v"}}],"status":"ok"}`,
+				clusters[0], testTimeStr, testdata.ClusterVersion),
+		})
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     ira_server.ListOfDisabledClusters,
+			EndpointArgs: []interface{}{testdata.Rule1ID + dotReportRuleModuleSuffix, testdata.ErrorKey1, testdata.OrgID},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{"clusters":[{"cluster_id":"%v","disabled_at":"%v","justification":"not needed"}],"status":"ok"}`,
+				clusters[1], testTimeStr),
+		})
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     ira_server.ReadRuleSystemWide,
+			EndpointArgs: []interface{}{testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       `{"disabledRule":{},"status":"ok"}`,
+		})
+
+		amsClientMock := helpers.AMSClientWithOrgResults(testdata.OrgID, data.ClusterInfoResult2Clusters)
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.ClustersDetail,
+			EndpointArgs: []interface{}{testdata.Rule1CompositeID},
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: http.Header{"Accept": []string{"text/csv"}},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"Content-Type": server.CSVContentType},
+			Body:       "",
+			BodyChecker: csvChecker([][]string{
+				{"cluster_id", "cluster_name", "last_checked_at", "impacted", "cluster_version", "disabled", "disabled_at", "justification"},
+				{string(clusters[0]), data.ClusterDisplayName1, testTimeStr, "", string(testdata.ClusterVersion), "false", "", ""},
+				{string(clusters[1]), data.ClusterDisplayName2, "", "", "", "true", testTimeStr, "not needed"},
+			}),
+		})
+	}, testTimeout)
+}
+
+// TestNegotiateExportFormat checks that the format accepted with the highest
+// quality is selected
+func TestNegotiateExportFormat(t *testing.T) {
+	testCases := []struct {
+		accept   string
+		expected interface{}
+	}{
+		{"", server.JSONExportFormat},
+		{"text/csv", server.CSVExportFormat},
+		{"application/x-ndjson", server.NDJSONExportFormat},
+		{"text/html, application/x-ndjson;q=0.9", server.NDJSONExportFormat},
+		{"application/json, text/csv;q=0.1", server.JSONExportFormat},
+		{"text/csv;q=0", server.JSONExportFormat},
+		{"text/csv;q=0.5, application/x-ndjson;q=0.8", server.NDJSONExportFormat},
+		{"text/csv;q=0.5, application/json;q=0.5", server.JSONExportFormat},
+		{"*/*;q=0.8, text/csv", server.CSVExportFormat},
+		{"*/*, text/csv;q=0.9", server.JSONExportFormat},
+		{"text/csv;q=invalid", server.JSONExportFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.accept, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+			helpers.FailOnError(t, err)
+			request.Header.Set("Accept", tc.accept)
+
+			assert.Equal(t, tc.expected, server.NegotiateExportFormat(request))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LimitParam parameter with the maximum number of items returned in one
+	// page of the list
+	LimitParam = "limit"
+	// OffsetParam parameter with the number of items skipped from the
+	// beginning of the list
+	OffsetParam = "offset"
+	// SortParam parameter with the name of field used to sort the list.
+	// Items are sorted in descending order when the name is prefixed by "-"
+	SortParam = "sort"
+
+	descendingSortPrefix = "-"
+)
+
+// listPagination contains the requested page of the list. Zero limit means
+// that all items starting from offset are returned.
+type listPagination struct {
+	limit  int
+	offset int
+}
+
+// listSorting contains the field the list is sorted by
+type listSorting struct {
+	field      string
+	descending bool
+}
+
+// listComparators maps names of fields that can be used for sorting to
+// functions comparing two items by the field
+type listComparators[T any] map[string]func(a, b *T) int
+
+// readNonNegativeIntParam returns the value of integer parameter in query or
+// zero when the parameter is not provided
+func readNonNegativeIntParam(name string, request *http.Request) (int, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, &RouterParsingError{
+			ParamName:  name,
+			ParamValue: value,
+			ErrString:  "non-negative integer expected",
+		}
+	}
+	return number, nil
+}
+
+// readListPagination returns the page of the list requested by "limit" and
+// "offset" parameters
+func readListPagination(request *http.Request) (pagination listPagination, err error) {
+	pagination.limit, err = readNonNegativeIntParam(LimitParam, request)
+	if err != nil {
+		return
+	}
+	pagination.offset, err = readNonNegativeIntParam(OffsetParam, request)
+	return
+}
+
+// readListSorting returns the sorting requested by "sort" parameter. Only
+// fields with comparator can be used.
+func readListSorting[T any](request *http.Request, comparators listComparators[T]) (sorting listSorting, err error) {
+	value := request.URL.Query().Get(SortParam)
+	if value == "" {
+		return
+	}
+
+	sorting.field = strings.TrimPrefix(value, descendingSortPrefix)
+	sorting.descending = sorting.field != value
+
+	if _, found := comparators[sorting.field]; !found {
+		fields := make([]string, 0, len(comparators))
+		for field := range comparators {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		err = &RouterParsingError{
+			ParamName:  SortParam,
+			ParamValue: value,
+			ErrString:  "sorting is supported by fields: " + strings.Join(fields, ", "),
+		}
+	}
+	return
+}
+
+// readQueryListParam returns comma separated values of the parameter in query
+func readQueryListParam(name string, request *http.Request) []string {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// sortList sorts the items in place. Order of items with the same value of
+// the sorting field is kept.
+func sortList[T any](items []T, sorting listSorting, comparators listComparators[T]) {
+	compare, found := comparators[sorting.field]
+	if !found {
+		return
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		if sorting.descending {
+			return compare(&b, &a)
+		}
+		return compare(&a, &b)
+	})
+}
+
+// paginateList returns the requested page of the list
+func paginateList[T any](items []T, pagination listPagination) []T {
+	if pagination.offset >= len(items) {
+		return items[:0]
+	}
+	items = items[pagination.offset:]
+
+	if pagination.limit > 0 && pagination.limit < len(items) {
+		items = items[:pagination.limit]
+	}
+	return items
+}
+
+// compareStringsFold compares strings ignoring case
+func compareStringsFold(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -25,10 +25,12 @@ import (
 
 const unknownUserAgent = "unknown"
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// responseWriter wraps http.ResponseWriter to capture status code and size
+// of the response
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	size       int
 }
 
 // WriteHeader captures the status code for metrics
@@ -37,6 +39,19 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Write counts bytes written into the response body
+func (rw *responseWriter) Write(data []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(data)
+	rw.size += n
+	return n, err
+}
+
+// Unwrap returns the original response writer, so http.ResponseController
+// can reach it
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // normalizeUserAgent normalizes the user agent string to reduce cardinality
 // and prevent potential high cardinality issues in Prometheus
 func normalizeUserAgent(userAgent string) string {
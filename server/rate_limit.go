@@ -0,0 +1,119 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
+)
+
+// headers with the state of the rate limiter sent to the client
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	retryAfterHeader         = "Retry-After"
+
+	unknownIdentityType = "unknown"
+)
+
+// defaultEndpointClasses assigns endpoint classes to endpoints that make many
+// or slow calls to upstream services. All other endpoints use the default
+// class.
+var defaultEndpointClasses = map[string]string{
+	ReportForListOfClustersEndpoint:            ratelimit.ExpensiveClass,
+	ReportForListOfClustersPayloadEndpoint:     ratelimit.ExpensiveClass,
+	UpgradeRisksPredictionMultiClusterEndpoint: ratelimit.ExpensiveClass,
+}
+
+// SetRateLimiter method sets the rate limiter used to limit requests made
+// by organizations
+func (server *HTTPServer) SetRateLimiter(rateLimiter *ratelimit.RateLimiter) {
+	server.rateLimiter = rateLimiter
+}
+
+// rateLimitClass method returns the endpoint class for endpoint template
+func (server *HTTPServer) rateLimitClass(endpoint string) string {
+	endpoint = server.trimAPIPrefix(endpoint)
+	if class, found := server.rateLimiter.EndpointClass(endpoint); found {
+		return class
+	}
+	if class, found := defaultEndpointClasses[endpoint]; found {
+		return class
+	}
+	return ratelimit.DefaultClass
+}
+
+// RateLimitMiddleware method rejects requests of organizations that have
+// exhausted their budget for the endpoint class with HTTP code 429. The
+// budget is counted separately for each identity type, so for example a
+// service account can't block users from the same organization. Requests
+// without identity (those that don't need authentication) are not limited.
+func (server *HTTPServer) RateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if server.rateLimiter == nil {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		identity, err := auth.GetAuthToken(request)
+		if err != nil {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		identityType := identity.Type
+		if identityType == "" {
+			identityType = unknownIdentityType
+		}
+
+		class := server.rateLimitClass(getEndpointFromRequest(request))
+		result, err := server.rateLimiter.Allow(class, identity.OrgID, identityType)
+		if err != nil {
+			// limiter storage is not available, better to let the request
+			// through than to block all users
+			log.Error().Err(err).Msg("Unable to check rate limit")
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		if result.Limit > 0 {
+			writer.Header().Set(rateLimitLimitHeader, strconv.Itoa(result.Limit))
+			writer.Header().Set(rateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		}
+
+		if !result.Allowed {
+			metrics.RateLimitedRequests.WithLabelValues(class, identityType).Inc()
+			log.Warn().
+				Int(orgIDTag, int(identity.OrgID)).
+				Str("class", class).
+				Str("identityType", identityType).
+				Msg("Rate limit exceeded")
+
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			writer.Header().Set(retryAfterHeader, strconv.Itoa(max(retryAfter, 1)))
+			handleServerError(writer, &TooManyRequestsError{})
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// TestRateLimitMiddleware checks that requests over the budget of the
+// organization are rejected with Retry-After header
+func TestRateLimitMiddleware(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		testServer.SetRateLimiter(ratelimit.NewRateLimiter(ratelimit.NewMemoryStore(), ratelimit.Configuration{
+			Classes: map[string]ratelimit.ClassConfiguration{
+				ratelimit.DefaultClass: {Requests: 1, Period: time.Hour},
+			},
+		}))
+
+		expectAckListFromAggregator(t)
+		assertAckList(t, testServer)
+
+		req, err := http.NewRequest(http.MethodGet, helpers.DefaultServerConfig.APIv2Prefix+server.AckListEndpoint, http.NoBody)
+		helpers.FailOnError(t, err)
+		req.Header.Set("x-rh-identity", goodXRHAuthToken)
+
+		response := helpers.ExecuteRequest(testServer, req)
+		assert.Equal(t, http.StatusTooManyRequests, response.Code)
+		assert.Equal(t, "1", response.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", response.Header().Get("X-RateLimit-Remaining"))
+
+		retryAfter, err := strconv.Atoi(response.Header().Get("Retry-After"))
+		helpers.FailOnError(t, err)
+		assert.True(t, retryAfter >= 1 && retryAfter <= 3600)
+	}, testTimeout)
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,7 @@ import (
 	"github.com/RedHatInsights/insights-operator-utils/responses"
 	utypes "github.com/RedHatInsights/insights-operator-utils/types"
 	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
 	ctypes "github.com/RedHatInsights/insights-results-types"
 	"github.com/rs/zerolog/log"
@@ -32,7 +34,8 @@ import (
 
 // postRating handles the POST method for Rating endpoint
 func (server *HTTPServer) postRating(writer http.ResponseWriter, request *http.Request) {
-	log.Debug().Msg("postRating")
+	logger := requestLogger(request)
+	logger.Debug().Msg("postRating")
 
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
@@ -40,30 +43,38 @@ func (server *HTTPServer) postRating(writer http.ResponseWriter, request *http.R
 		return
 	}
 
-	log.Debug().Uint32("org_id", uint32(orgID)).Msg("Extracted user and org")
+	logger.Debug().Uint32("org_id", uint32(orgID)).Msg("Extracted user and org")
 
-	rating, successful := server.postRatingToAggregator(orgID, request, writer)
+	rating, successful := server.postRatingToAggregator(request.Context(), orgID, request, writer)
 	if !successful {
-		log.Error().Msg("Unable to get response from aggregator")
+		logger.Error().Msg("Unable to get response from aggregator")
 		// All errors already handled
 		return
 	}
 
+	server.recordAuditRequest(request, &audit.Event{
+		Action:       audit.VoteChanged,
+		OrgID:        orgID,
+		RuleSelector: ctypes.RuleSelector(rating.Rule),
+		Vote:         auditVotes[rating.Rating],
+	})
+
 	bodyContent, err := json.Marshal(rating)
 	if err != nil {
-		log.Error().Err(err).Msg("Unable to unmarshall the response from aggregator")
+		logger.Error().Err(err).Msg("Unable to unmarshall the response from aggregator")
 		handleServerError(writer, err)
 		return
 	}
 
 	err = responses.Send(http.StatusOK, writer, bodyContent)
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
 // postRatingToAggregator asks aggregator for update the rating for a given rule by the current user/org
 func (server HTTPServer) postRatingToAggregator(
+	ctx context.Context,
 	orgID ctypes.OrgID, request *http.Request, writer http.ResponseWriter,
 ) (*ctypes.RuleRating, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -88,7 +99,7 @@ func (server HTTPServer) postRatingToAggregator(
 
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	aggregatorResp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(body))
+	aggregatorResp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(body))
 	if err != nil {
 		handleServerError(writer, err)
 		return nil, false
@@ -129,6 +140,7 @@ func (server HTTPServer) postRatingToAggregator(
 
 // getRatingForRecommendation retrieves user rating for recommendation from aggregator
 func (server HTTPServer) getRatingForRecommendation(
+	ctx context.Context,
 	orgID ctypes.OrgID,
 	ruleID ctypes.RuleID,
 ) (
@@ -152,7 +164,7 @@ func (server HTTPServer) getRatingForRecommendation(
 
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	aggregatorResp, err := http.Get(aggregatorURL)
+	aggregatorResp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		log.Error().Err(err).Str(urlStr, aggregatorURL).Msg("problem getting URL from aggregator")
 		return
@@ -0,0 +1,207 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// TagsParam parameter with comma separated tags. Only recommendations
+	// having all the tags are returned
+	TagsParam = "tags"
+	// CategoryParam parameter with comma separated categories. Only
+	// recommendations belonging to any of the categories are returned
+	CategoryParam = "category"
+	// TotalRiskMinParam parameter with the lowest total risk of returned
+	// recommendations
+	TotalRiskMinParam = "total_risk_min"
+	// TotalRiskMaxParam parameter with the highest total risk of returned
+	// recommendations
+	TotalRiskMaxParam = "total_risk_max"
+	// TextParam parameter with text searched in the description of
+	// recommendations
+	TextParam = "text"
+
+	minTotalRisk = 1
+	maxTotalRisk = 4
+)
+
+// recommendationCategories maps categories of recommendations to the tags
+// used in rule content
+var recommendationCategories = map[string]string{
+	"service_availability": "service_availability",
+	"performance":          "performance",
+	"fault_tolerance":      "fault_tolerance",
+	"security":             "security",
+}
+
+// recommendationComparators contains fields the recommendations list can be
+// sorted by
+var recommendationComparators = listComparators[types.RecommendationListView]{
+	"total_risk": func(a, b *types.RecommendationListView) int {
+		return cmp.Compare(a.TotalRisk, b.TotalRisk)
+	},
+	"impacted_clusters_count": func(a, b *types.RecommendationListView) int {
+		return cmp.Compare(a.ImpactedClustersCnt, b.ImpactedClustersCnt)
+	},
+	"publish_date": func(a, b *types.RecommendationListView) int {
+		return a.PublishDate.Compare(b.PublishDate)
+	},
+	"description": func(a, b *types.RecommendationListView) int {
+		return compareStringsFold(a.Description, b.Description)
+	},
+}
+
+// sortRecommendationList sorts the recommendations by the requested field.
+// Recommendations are ordered by rule ID first, so the order of pages is
+// stable even when no sorting is requested.
+func sortRecommendationList(recommendations []types.RecommendationListView, sorting listSorting) {
+	slices.SortFunc(recommendations, func(a, b types.RecommendationListView) int {
+		return cmp.Compare(a.RuleID, b.RuleID)
+	})
+	sortList(recommendations, sorting, recommendationComparators)
+}
+
+// recommendationFilter contains conditions recommendations returned by
+// /rule endpoint must meet
+type recommendationFilter struct {
+	tags         []string
+	categoryTags []string
+	minTotalRisk uint8
+	maxTotalRisk uint8
+	text         string
+}
+
+// recommendationListParams contains parameters of /rule endpoint that
+// control content of the returned list
+type recommendationListParams struct {
+	filter     recommendationFilter
+	sorting    listSorting
+	pagination listPagination
+}
+
+// readTotalRiskParam returns value of total risk parameter in query or the
+// default value if the parameter is not provided
+func readTotalRiskParam(name string, defaultValue uint8, request *http.Request) (uint8, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	totalRisk, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || totalRisk < minTotalRisk || totalRisk > maxTotalRisk {
+		return 0, &RouterParsingError{
+			ParamName:  name,
+			ParamValue: value,
+			ErrString:  fmt.Sprintf("total risk between %d and %d expected", minTotalRisk, maxTotalRisk),
+		}
+	}
+	return uint8(totalRisk), nil
+}
+
+// readRecommendationFilter returns filter constructed from the parameters
+// in query
+func readRecommendationFilter(request *http.Request) (filter recommendationFilter, err error) {
+	filter.tags = readQueryListParam(TagsParam, request)
+	filter.text = strings.ToLower(request.URL.Query().Get(TextParam))
+
+	for _, category := range readQueryListParam(CategoryParam, request) {
+		tag, found := recommendationCategories[category]
+		if !found {
+			err = &RouterParsingError{
+				ParamName:  CategoryParam,
+				ParamValue: category,
+				ErrString:  "unknown category",
+			}
+			return
+		}
+		filter.categoryTags = append(filter.categoryTags, tag)
+	}
+
+	// no recommendation is filtered out by total risk when the range is not
+	// provided
+	filter.minTotalRisk, err = readTotalRiskParam(TotalRiskMinParam, 0, request)
+	if err != nil {
+		return
+	}
+	filter.maxTotalRisk, err = readTotalRiskParam(TotalRiskMaxParam, math.MaxUint8, request)
+	if err != nil {
+		return
+	}
+
+	if filter.minTotalRisk > filter.maxTotalRisk {
+		err = &RouterParsingError{
+			ParamName:  TotalRiskMinParam,
+			ParamValue: filter.minTotalRisk,
+			ErrString:  fmt.Sprintf("must not be greater than %s", TotalRiskMaxParam),
+		}
+	}
+	return
+}
+
+// readRecommendationListParams reads filter, sorting and pagination of the
+// recommendations list. If it's not possible, it writes http error to the
+// writer and returns error
+func readRecommendationListParams(writer http.ResponseWriter, request *http.Request) (
+	params recommendationListParams, err error,
+) {
+	params.filter, err = readRecommendationFilter(request)
+	if err == nil {
+		params.sorting, err = readListSorting(request, recommendationComparators)
+	}
+	if err == nil {
+		params.pagination, err = readListPagination(request)
+	}
+
+	if err != nil {
+		handleServerError(writer, err)
+	}
+	return
+}
+
+// matches method checks if the recommendation meets all conditions of the
+// filter
+func (filter *recommendationFilter) matches(recommendation *types.RecommendationListView) bool {
+	if filter == nil {
+		return true
+	}
+
+	if recommendation.TotalRisk < filter.minTotalRisk || recommendation.TotalRisk > filter.maxTotalRisk {
+		return false
+	}
+
+	for _, tag := range filter.tags {
+		if !slices.Contains(recommendation.Tags, tag) {
+			return false
+		}
+	}
+
+	if len(filter.categoryTags) > 0 && !slices.ContainsFunc(filter.categoryTags, func(tag string) bool {
+		return slices.Contains(recommendation.Tags, tag)
+	}) {
+		return false
+	}
+
+	return filter.text == "" || strings.Contains(strings.ToLower(recommendation.Description), filter.text)
+}
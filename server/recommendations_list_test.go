@@ -0,0 +1,206 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// recommendationListChecker returns body checker that compares IDs of
+// returned recommendations including their order and the list metadata
+func recommendationListChecker(expectedRuleIDs []ctypes.RuleID, expectedMeta types.ListMeta) iou_helpers.BodyChecker {
+	return func(t testing.TB, _, got []byte) {
+		var resp struct {
+			Meta            types.ListMeta                 `json:"meta"`
+			Recommendations []types.RecommendationListView `json:"recommendations"`
+		}
+		helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+		ruleIDs := []ctypes.RuleID{}
+		for i := range resp.Recommendations {
+			ruleIDs = append(ruleIDs, resp.Recommendations[i].RuleID)
+		}
+		assert.Equal(t, expectedRuleIDs, ruleIDs)
+		assert.Equal(t, expectedMeta, resp.Meta)
+	}
+}
+
+// expectRecommendationsFor2Rules2Clusters mocks responses from AMS and
+// aggregator with the first rule hitting two clusters and the second one
+// hitting one cluster
+func expectRecommendationsFor2Rules2Clusters(t testing.TB) *server.HTTPServer {
+	clusterInfoList := []types.ClusterInfo{data.GetRandomClusterInfo(), data.GetRandomClusterInfo()}
+	clusterList := types.GetClusterNames(clusterInfoList)
+	reqBody, _ := json.Marshal(clusterList)
+
+	respBody := fmt.Sprintf(`{"recommendations":{"%v":["%v","%v"],"%v":["%v"]},"status":"ok"}`,
+		testdata.Rule1CompositeID, clusterList[0], clusterList[1],
+		testdata.Rule2CompositeID, clusterList[0],
+	)
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+		&helpers.APIRequest{
+			Method:       http.MethodPost,
+			Endpoint:     ira_server.RecommendationsListEndpoint,
+			EndpointArgs: []interface{}{testdata.OrgID, userIDInGoodAuthToken},
+			Body:         reqBody,
+		},
+		&helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       respBody,
+		},
+	)
+
+	expectNoRulesDisabledSystemWide(&t, testdata.OrgID)
+
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+		&helpers.APIRequest{
+			Method:       http.MethodPost,
+			Endpoint:     ira_server.ListOfDisabledRulesForClusters,
+			EndpointArgs: []interface{}{testdata.OrgID},
+			Body:         reqBody,
+		},
+		&helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"rules":[],"status":"ok"}`,
+		},
+	)
+
+	amsClientMock := helpers.AMSClientWithOrgResults(testdata.OrgID, clusterInfoList)
+	return helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
+}
+
+// TestHTTPServer_RecommendationsListFilterSortPaginate checks that filters,
+// sorting and pagination are applied to the recommendations list
+func TestHTTPServer_RecommendationsListFilterSortPaginate(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{testdata.RuleContent1, testdata.RuleContent2},
+		),
+	)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name            string
+		query           string
+		expectedRuleIDs []ctypes.RuleID
+		expectedMeta    types.ListMeta
+	}{
+		{
+			name:            "no parameters",
+			query:           "",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule1CompositeID, testdata.Rule2CompositeID},
+			expectedMeta:    types.ListMeta{Count: 2, Total: 2},
+		},
+		{
+			name:            "sorted by impacted clusters",
+			query:           "?sort=impacted_clusters_count",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule2CompositeID, testdata.Rule1CompositeID},
+			expectedMeta:    types.ListMeta{Count: 2, Total: 2},
+		},
+		{
+			name:            "sorted by description descending",
+			query:           "?sort=-description&limit=1",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule2CompositeID},
+			expectedMeta:    types.ListMeta{Count: 1, Total: 2, Limit: 1},
+		},
+		{
+			name:            "second page",
+			query:           "?sort=total_risk&limit=1&offset=1",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule2CompositeID},
+			expectedMeta:    types.ListMeta{Count: 1, Total: 2, Limit: 1, Offset: 1},
+		},
+		{
+			name:            "offset out of range",
+			query:           "?offset=10",
+			expectedRuleIDs: []ctypes.RuleID{},
+			expectedMeta:    types.ListMeta{Count: 0, Total: 2, Offset: 10},
+		},
+		{
+			name:            "total risk range",
+			query:           "?total_risk_min=2&total_risk_max=4",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule2CompositeID},
+			expectedMeta:    types.ListMeta{Count: 1, Total: 1},
+		},
+		{
+			name:            "text search",
+			query:           "?text=DESCRIPTION1",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule1CompositeID},
+			expectedMeta:    types.ListMeta{Count: 1, Total: 1},
+		},
+		{
+			name:            "tags and category",
+			query:           "?tags=openshift,osd_customer&category=service_availability,security",
+			expectedRuleIDs: []ctypes.RuleID{testdata.Rule1CompositeID},
+			expectedMeta:    types.ListMeta{Count: 1, Total: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			helpers.RunTestWithTimeout(t, func(t testing.TB) {
+				defer helpers.CleanAfterGock(t)
+
+				testServer := expectRecommendationsFor2Rules2Clusters(t)
+				iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+					Method:      http.MethodGet,
+					Endpoint:    server.RecommendationsListEndpoint + tc.query,
+					XRHIdentity: goodXRHAuthToken,
+				}, &helpers.APIResponse{
+					StatusCode:  http.StatusOK,
+					Body:        "",
+					BodyChecker: recommendationListChecker(tc.expectedRuleIDs, tc.expectedMeta),
+				})
+			}, testTimeout)
+		})
+	}
+}
+
+// TestHTTPServer_RecommendationsListBadParams checks that improper list
+// parameters are refused
+func TestHTTPServer_RecommendationsListBadParams(t *testing.T) {
+	for _, query := range []string{
+		"?limit=-1",
+		"?offset=first",
+		"?sort=rule_id",
+		"?total_risk_min=5",
+		"?total_risk_min=3&total_risk_max=2",
+		"?category=unknown",
+	} {
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodGet,
+			Endpoint:    server.RecommendationsListEndpoint + query,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode:  http.StatusBadRequest,
+			Body:        "",
+			BodyChecker: func(testing.TB, []byte, []byte) {},
+		})
+	}
+}
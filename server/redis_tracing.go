@@ -0,0 +1,108 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// tracingRedis decorates the Redis client, so each call made while handling
+// the request is traced as a child span of the span stored in the context.
+// Methods not related to any organization are not traced.
+type tracingRedis struct {
+	services.RedisInterface
+	ctx context.Context
+}
+
+// tracedRedis method returns the Redis client tracing calls made while
+// handling the request with given context
+func (server *HTTPServer) tracedRedis(ctx context.Context) services.RedisInterface {
+	return tracingRedis{RedisInterface: server.redis, ctx: ctx}
+}
+
+// GetRequestIDsForClusterID method traces the call of decorated client
+func (client tracingRedis) GetRequestIDsForClusterID(
+	orgID types.OrgID, clusterID types.ClusterName, limit int,
+) (requestIDs []types.RequestID, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetRequestIDsForClusterID", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetRequestIDsForClusterID(orgID, clusterID, limit)
+}
+
+// GetTimestampsForRequestIDs method traces the call of decorated client
+func (client tracingRedis) GetTimestampsForRequestIDs(
+	orgID types.OrgID, clusterID types.ClusterName, requestIDs []types.RequestID, omitMissing bool,
+) (requestStatuses []types.RequestStatus, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetTimestampsForRequestIDs", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetTimestampsForRequestIDs(orgID, clusterID, requestIDs, omitMissing)
+}
+
+// GetRuleHitsForRequest method traces the call of decorated client
+func (client tracingRedis) GetRuleHitsForRequest(
+	orgID types.OrgID, clusterID types.ClusterName, requestID types.RequestID,
+) (ruleHits []types.RuleID, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetRuleHitsForRequest", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetRuleHitsForRequest(orgID, clusterID, requestID)
+}
+
+// GetRequestStatus method traces the call of decorated client
+func (client tracingRedis) GetRequestStatus(
+	orgID types.OrgID, clusterID types.ClusterName, requestID types.RequestID,
+) (err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetRequestStatus", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetRequestStatus(orgID, clusterID, requestID)
+}
+
+// GetRequestsForOrganization method traces the call of decorated client
+func (client tracingRedis) GetRequestsForOrganization(
+	orgID types.OrgID, from, to time.Time, offset, limit int,
+) (requests []types.ClusterRequestStatus, total int, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetRequestsForOrganization", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetRequestsForOrganization(orgID, from, to, offset, limit)
+}
+
+// DeleteRequestsForCluster method traces the call of decorated client
+func (client tracingRedis) DeleteRequestsForCluster(
+	orgID types.OrgID, clusterID types.ClusterName,
+) (deleted int, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.DeleteRequestsForCluster", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.DeleteRequestsForCluster(orgID, clusterID)
+}
+
+// GetLatestRequestsForClusters method traces the call of decorated client
+func (client tracingRedis) GetLatestRequestsForClusters(
+	orgID types.OrgID, clusterIDs []types.ClusterName,
+) (latestRequests map[types.ClusterName]types.ClusterLatestRequest, err error) {
+	_, span := tracing.StartSpan(client.ctx, "redis.GetLatestRequestsForClusters", tracing.OrgID(orgID))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return client.RedisInterface.GetLatestRequestsForClusters(orgID, clusterIDs)
+}
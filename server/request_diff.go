@@ -0,0 +1,187 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// getReportDiffForRequests method implements endpoint that should return
+// rules that appeared, disappeared and persisted in simplified result for
+// given request ID when compared with the result for previous request ID
+func (server *HTTPServer) getReportDiffForRequests(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	clusterID, successful := httputils.ReadClusterName(writer, request)
+	if !successful {
+		// error handled by function
+		return
+	}
+
+	requestID, err := readRequestID(writer, request)
+	if err != nil {
+		// error handled by function
+		return
+	}
+
+	previousRequestID, err := readRequestIDParam(writer, request, PreviousRequestIDParam)
+	if err != nil {
+		// error handled by function
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	// get rule hits for both requests from Redis
+	ruleHits, err := server.tracedRedis(request.Context()).GetRuleHitsForRequest(orgID, clusterID, requestID)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	previousRuleHits, err := server.tracedRedis(request.Context()).GetRuleHitsForRequest(orgID, clusterID, previousRequestID)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// get a map of acknowledged rules
+	ackedRulesMap, err := server.getRuleAcksMap(request.Context(), orgID)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// retrieve user disabled rules for given cluster
+	disabledRulesForCluster, err := server.getDisabledRulesForClusterMap(request.Context(), writer, orgID, clusterID)
+	if err != nil {
+		logger.Error().Err(err).Msg("problem getting user disabled rules for cluster")
+		// server error has been handled already
+		return
+	}
+
+	// rules hidden from the report are not part of the diff either
+	hidden := func(ruleID types.RuleID) bool {
+		return ackedRulesMap[ruleID] || disabledRulesForCluster[ruleID]
+	}
+
+	// prepare response
+	responseData := map[string]interface{}{}
+	responseData["cluster"] = string(clusterID)
+	responseData["requestID"] = requestID
+	responseData["previousRequestID"] = previousRequestID
+	responseData["status"] = OkMsg
+	responseData["diff"] = diffRuleHits(ruleHits, previousRuleHits, hidden)
+
+	// send response to client
+	err = responses.SendOK(writer, responseData)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+}
+
+// diffRuleHits function compares rule hits of two requests. Rules are
+// returned in the same order as they were stored in Redis.
+func diffRuleHits(
+	ruleHits, previousRuleHits []types.RuleID,
+	hidden func(types.RuleID) bool,
+) types.SimplifiedReportDiff {
+	// initialize all lists so they are not null in API response
+	diff := types.SimplifiedReportDiff{
+		Appeared:    []types.SimplifiedRuleHit{},
+		Disappeared: []types.SimplifiedRuleHit{},
+		Persisted:   []types.SimplifiedRuleHit{},
+	}
+
+	current := make(map[types.RuleID]bool, len(ruleHits))
+	for _, ruleID := range ruleHits {
+		current[ruleID] = true
+	}
+
+	previous := make(map[types.RuleID]bool, len(previousRuleHits))
+	for _, ruleID := range previousRuleHits {
+		previous[ruleID] = true
+	}
+
+	for _, ruleID := range ruleHits {
+		if hidden(ruleID) {
+			continue
+		}
+		ruleHit, found := simplifiedRuleHitWithContent(ruleID)
+		if !found {
+			continue
+		}
+		if previous[ruleID] {
+			diff.Persisted = append(diff.Persisted, ruleHit)
+		} else {
+			diff.Appeared = append(diff.Appeared, ruleHit)
+		}
+	}
+
+	for _, ruleID := range previousRuleHits {
+		if current[ruleID] || hidden(ruleID) {
+			continue
+		}
+		ruleHit, found := simplifiedRuleHitWithContent(ruleID)
+		if found {
+			diff.Disappeared = append(diff.Disappeared, ruleHit)
+		}
+	}
+
+	return diff
+}
+
+// simplifiedRuleHitWithContent function fills in simplified rule hit with
+// data from rule content. False is returned when the content is not found.
+func simplifiedRuleHitWithContent(compositeRuleID types.RuleID) (types.SimplifiedRuleHit, bool) {
+	ruleID, errorKey, err := types.RuleIDWithErrorKeyFromCompositeRuleID(ctypes.RuleID(compositeRuleID))
+	if err != nil {
+		log.Warn().Err(err).Interface(ruleIDStr, compositeRuleID).Msg("error getting rule module and error key from composite rule ID")
+		return types.SimplifiedRuleHit{}, false
+	}
+
+	ruleContent, err := content.GetRuleWithErrorKeyContent(ruleID, errorKey)
+	if err != nil {
+		// rule content not found, log and skip as in other endpoints
+		log.Warn().Err(err).Interface(ruleIDStr, compositeRuleID).Msg("error retrieving rule content for rule")
+		return types.SimplifiedRuleHit{}, false
+	}
+
+	return types.SimplifiedRuleHit{
+		RuleFQDN:    string(ruleID),
+		ErrorKey:    string(errorKey),
+		Description: ruleContent.Generic,
+		TotalRisk:   ruleContent.TotalRisk,
+	}, true
+}
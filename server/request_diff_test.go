@@ -0,0 +1,207 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// expectRuleHitsForRequest mocks reading of simplified report from Redis
+func expectRuleHitsForRequest(redisServer redismock.ClientMock, requestID string, ruleHits ...string) {
+	expectedKey := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, requestID)
+	redisServer.ExpectHMGet(
+		expectedKey, services.RequestIDFieldName, services.RuleHitsFieldName,
+	).SetVal([]interface{}{requestID, strings.Join(ruleHits, ",")})
+}
+
+// expectRuleDisablesForDiff mocks reading of acked rules and rules disabled
+// for the cluster
+func expectRuleDisablesForDiff(t *testing.T, ackedRules string) {
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+		EndpointArgs: []interface{}{testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       ackedRules,
+	})
+
+	reqBody, _ := json.Marshal([]types.ClusterName{testdata.ClusterName})
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodPost,
+		Endpoint:     ira_server.ListOfDisabledRulesForClusters,
+		EndpointArgs: []interface{}{testdata.OrgID},
+		Body:         reqBody,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       ResponseNoRulesDisabledPerCluster,
+	})
+}
+
+// simplifiedRuleHitJSON returns simplified rule hit as expected in response
+func simplifiedRuleHitJSON(ruleID interface{}, errorKey interface{}, ruleContent *ctypes.RuleWithContent) string {
+	return fmt.Sprintf(`{"rule_fqdn":"%v","error_key":"%v","description":"%v","total_risk":%v}`,
+		ruleID, errorKey, ruleContent.Generic, ruleContent.TotalRisk)
+}
+
+var (
+	rule1Hit = fmt.Sprintf("%v|%v", testdata.Rule1ID, testdata.ErrorKey1)
+	rule2Hit = fmt.Sprintf("%v|%v", testdata.Rule2ID, testdata.ErrorKey2)
+	rule3Hit = fmt.Sprintf("%v|%v", testdata.Rule3ID, testdata.ErrorKey3)
+)
+
+// TestGetReportDiffForRequests checks that rules are split into appeared,
+// disappeared and persisted ones
+func TestGetReportDiffForRequests(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectRuleHitsForRequest(redisServer, "requestID2", rule1Hit, rule2Hit)
+		expectRuleHitsForRequest(redisServer, "requestID1", rule2Hit, rule3Hit)
+		expectRuleDisablesForDiff(t, ResponseNoRulesDisabledSystemWide)
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.RuleHitsDiffForRequestIDs,
+			EndpointArgs: []interface{}{testdata.ClusterName, "requestID2", "requestID1"},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{
+				"cluster": "%v",
+				"status": "ok",
+				"requestID": "requestID2",
+				"previousRequestID": "requestID1",
+				"diff": {
+					"appeared": [%v],
+					"disappeared": [%v],
+					"persisted": [%v]
+				}
+			}`, testdata.ClusterName,
+				simplifiedRuleHitJSON(testdata.Rule1ID, testdata.ErrorKey1, &testdata.RuleWithContent1),
+				simplifiedRuleHitJSON(testdata.Rule3ID, testdata.ErrorKey3, &testdata.RuleWithContent3),
+				simplifiedRuleHitJSON(testdata.Rule2ID, testdata.ErrorKey2, &testdata.RuleWithContent2)),
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetReportDiffForRequestsAckedRule checks that acked rules are not part
+// of the diff
+func TestGetReportDiffForRequestsAckedRule(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		err := loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules)
+		assert.Nil(t, err)
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectRuleHitsForRequest(redisServer, "requestID2", rule2Hit)
+		expectRuleHitsForRequest(redisServer, "requestID1")
+		expectRuleDisablesForDiff(t, helpers.ToJSONString(ResponseRule2DisabledSystemWide))
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.RuleHitsDiffForRequestIDs,
+			EndpointArgs: []interface{}{testdata.ClusterName, "requestID2", "requestID1"},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf(`{
+				"cluster": "%v",
+				"status": "ok",
+				"requestID": "requestID2",
+				"previousRequestID": "requestID1",
+				"diff": {"appeared": [], "disappeared": [], "persisted": []}
+			}`, testdata.ClusterName),
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetReportDiffForRequestsPreviousNotFound checks that 404 is returned
+// when one of the reports is not stored in Redis
+func TestGetReportDiffForRequestsPreviousNotFound(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectRuleHitsForRequest(redisServer, "requestID2", rule1Hit)
+		expectedKey := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, "requestID1")
+		redisServer.ExpectHMGet(
+			expectedKey, services.RequestIDFieldName, services.RuleHitsFieldName,
+		).SetVal([]interface{}{nil, nil})
+
+		iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.RuleHitsDiffForRequestIDs,
+			EndpointArgs: []interface{}{testdata.ClusterName, "requestID2", "requestID1"},
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       `{"request_id":"test-request-id","status":"Item with ID requestID1 was not found in the storage"}`,
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
+
+// TestGetReportDiffForRequestsBadRequestID checks that previous request ID
+// is validated
+func TestGetReportDiffForRequestsBadRequestID(t *testing.T) {
+	redisClient, _ := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	iou_helpers.AssertAPIRequest(t, testServer, serverConfigXRH.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RuleHitsDiffForRequestIDs,
+		EndpointArgs: []interface{}{testdata.ClusterName, "requestID2", "request_1"},
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+		Body: `{
+			"request_id": "test-request-id",
+			"status": "Error during parsing param 'previous_request_id' with value 'request_1'. Error: 'invalid request ID: 'request_1''"
+		}`,
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// readTimestampParam function reads timestamp in RFC 3339 format from query.
+// Zero time is returned when the parameter is not provided.
+func readTimestampParam(name string, request *http.Request) (time.Time, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &RouterParsingError{
+			ParamName:  name,
+			ParamValue: value,
+			ErrString:  "timestamp in RFC 3339 format expected",
+		}
+	}
+	return timestamp, nil
+}
+
+// readRequestsRange function reads the time range of the list of requests
+// from query. The range is not limited by default.
+func readRequestsRange(request *http.Request) (from, to time.Time, err error) {
+	from, err = readTimestampParam(FromParam, request)
+	if err != nil {
+		return
+	}
+	to, err = readTimestampParam(ToParam, request)
+	if err != nil {
+		return
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		err = &RouterParsingError{
+			ParamName:  FromParam,
+			ParamValue: request.URL.Query().Get(FromParam),
+			ErrString:  "beginning of the range must not be after its end",
+		}
+	}
+	return
+}
+
+// getRequestsForOrganization method implements endpoint that should return
+// requests of all clusters of the organization received in the given time
+// range, the most recent ones first
+func (server *HTTPServer) getRequestsForOrganization(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	from, to, err := readRequestsRange(request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	pagination, err := readListPagination(request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	// only the requested page is read, not the whole list
+	page, total, err := server.tracedRedis(request.Context()).GetRequestsForOrganization(
+		orgID, from, to, pagination.offset, pagination.limit,
+	)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	responseData := map[string]interface{}{}
+	responseData["status"] = OkMsg
+	responseData["meta"] = types.ListMeta{
+		Count:  len(page),
+		Total:  total,
+		Limit:  pagination.limit,
+		Offset: pagination.offset,
+	}
+	responseData["requests"] = page
+
+	err = responses.SendOK(writer, responseData)
+	if err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// deleteRequestsForCluster method implements endpoint that deletes all
+// requests of given cluster together with their simplified reports
+func (server *HTTPServer) deleteRequestsForCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	clusterID, successful := httputils.ReadClusterName(writer, request)
+	if !successful {
+		// error handled by function
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	deleted, err := server.tracedRedis(request.Context()).DeleteRequestsForCluster(orgID, clusterID)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	responseData := map[string]interface{}{}
+	responseData["cluster"] = string(clusterID)
+	responseData["deleted"] = deleted
+	responseData["status"] = OkMsg
+
+	err = responses.SendOK(writer, responseData)
+	if err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
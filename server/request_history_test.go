@@ -0,0 +1,155 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// TestGetRequestsForOrganization checks that requests of all clusters of
+// the organization are listed with the most recent ones first
+func TestGetRequestsForOrganization(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	reports1 := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, "requestID1")
+	reports2 := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.GetRandomClusterID(), "requestID2")
+
+	redisServer.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{reports1, reports2}, 0)
+	redisServer.ExpectHMGet(
+		reports1, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID1", receivedTimestamp, latestProcessedTimestamp})
+	redisServer.ExpectHMGet(
+		reports2, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID2", "2025-12-31T10:00:00Z", "2025-12-31T10:05:00Z"})
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.RequestsForOrganization + "?from=2026-01-01T00:00:00Z&to=2026-01-02T00:00:00Z&limit=10",
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body: fmt.Sprintf(`{
+			"status": "ok",
+			"meta": {"count": 1, "total": 1, "limit": 10, "offset": 0},
+			"requests": [
+				{
+					"cluster": "%v",
+					"requestID": "requestID1",
+					"valid": true,
+					"received": "%v",
+					"processed": "%v"
+				}
+			]
+		}`, testdata.ClusterName, receivedTimestamp, latestProcessedTimestamp),
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestGetRequestsForOrganizationBadRange checks that improper time range is
+// refused without accessing Redis
+func TestGetRequestsForOrganizationBadRange(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RequestsForOrganization + "?from=yesterday",
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+		Body: `{
+			"status": "Error during parsing param 'from' with value 'yesterday'. Error: 'timestamp in RFC 3339 format expected'",
+			"request_id": "test-request-id"
+		}`,
+	})
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RequestsForOrganization + "?from=2026-01-02T00:00:00Z&to=2026-01-01T00:00:00Z",
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+		Body: `{
+			"status": "Error during parsing param 'from' with value '2026-01-02T00:00:00Z'. Error: 'beginning of the range must not be after its end'",
+			"request_id": "test-request-id"
+		}`,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestDeleteRequestsForCluster checks that all requests of the cluster are
+// deleted
+func TestDeleteRequestsForCluster(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	request1 := fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName, "requestID1")
+	reports1 := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, "requestID1")
+
+	redisServer.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName), services.ScanBatchCount).
+		SetVal([]string{request1, reports1}, 0)
+	redisServer.ExpectDel(request1).SetVal(1)
+	redisServer.ExpectDel(reports1).SetVal(1)
+	redisServer.ExpectDel(fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName)).SetVal(1)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodDelete,
+		Endpoint:     server.DeleteRequestsForCluster,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       fmt.Sprintf(`{"cluster": "%v", "deleted": 1, "status": "ok"}`, testdata.ClusterName),
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestDeleteRequestsForClusterRedisError checks that Redis error is
+// reported
+func TestDeleteRequestsForClusterRedisError(t *testing.T) {
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	redisServer.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName), services.ScanBatchCount).
+		SetErr(errors.New("Redis server failure"))
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodDelete,
+		Endpoint:     server.DeleteRequestsForCluster,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusInternalServerError,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	requestIDTag = "request_id"
+
+	// maxRequestIDLength is the maximum length of request ID accepted from
+	// the client
+	maxRequestIDLength = 128
+)
+
+// validRequestID matches request IDs that can be accepted from the client.
+// Other values are replaced by generated ID, so nothing harmful is written
+// into logs or forwarded to other services.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// requestInfo contains data about the request that are not known when the
+// request enters the middleware chain, but are needed for the access log
+type requestInfo struct {
+	orgID types.OrgID
+}
+
+// requestInfoKey is the key of context value with the request info
+type requestInfoKey struct{}
+
+// RequestIDMiddleware reads X-Request-ID header sent by the client or
+// generates new request ID. The ID is sent back in the response, attached to
+// the logger stored in the request context and forwarded to upstream
+// services.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestID := request.Header.Get(httpclient.RequestIDHeader)
+		if len(requestID) > maxRequestIDLength || !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+		request.Header.Set(httpclient.RequestIDHeader, requestID)
+		writer.Header().Set(httpclient.RequestIDHeader, requestID)
+
+		logger := log.With().Str(requestIDTag, requestID).Logger()
+		ctx := logger.WithContext(httpclient.ContextWithRequestID(request.Context(), requestID))
+
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
+
+// requestLogger function returns logger with the request ID attached. The
+// global logger is returned for requests without ID.
+func requestLogger(request *http.Request) *zerolog.Logger {
+	logger := zerolog.Ctx(request.Context())
+	if logger.GetLevel() == zerolog.Disabled {
+		return &log.Logger
+	}
+	return logger
+}
+
+// setRequestOrgID function records organization of the requester, so it can
+// be written into the access log
+func setRequestOrgID(ctx context.Context, orgID types.OrgID) {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.orgID = orgID
+	}
+}
+
+// AccessLogMiddleware writes one structured log entry for each handled
+// request with its route template, status, latency and size of the response
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		start := time.Now()
+		info := &requestInfo{}
+		rw := &responseWriter{ResponseWriter: writer, statusCode: http.StatusOK}
+
+		next.ServeHTTP(rw, request.WithContext(context.WithValue(request.Context(), requestInfoKey{}, info)))
+
+		event := requestLogger(request).Info().
+			Str("method", request.Method).
+			Str("route", getEndpointFromRequest(request)).
+			Str("path", request.URL.Path).
+			Int("status", rw.statusCode).
+			Dur("latency", time.Since(start)).
+			Int("size", rw.size)
+		if info.orgID != 0 {
+			event = event.Uint32("org_id", uint32(info.orgID))
+		}
+		event.Msg("Request handled")
+	})
+}
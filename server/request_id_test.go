@@ -0,0 +1,84 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// TestRequestIDForwarded checks that request ID sent by the client is
+// forwarded to aggregator and returned back in the response
+func TestRequestIDForwarded(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     ira_server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID, testdata.UserID},
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1},
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+			Headers:    map[string]string{"X-Request-ID": testRequestID},
+		})
+	}, testTimeout)
+}
+
+// TestRequestIDGenerated checks that new request ID is generated when the
+// client does not send any or sends an invalid one, and that the ID is
+// included in the error response
+func TestRequestIDGenerated(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+
+	for _, requestID := range []string{"", "invalid request\nID"} {
+		req, err := http.NewRequest(http.MethodGet, helpers.DefaultServerConfig.APIv2Prefix+server.AckListEndpoint, http.NoBody)
+		helpers.FailOnError(t, err)
+		req.Header.Set("X-Request-ID", requestID)
+
+		response := helpers.ExecuteRequest(testServer, req)
+		assert.Equal(t, http.StatusForbidden, response.Code)
+
+		generatedID := response.Header().Get("X-Request-ID")
+		assert.Regexp(t, "^[0-9a-f-]{36}$", generatedID)
+
+		var body map[string]string
+		helpers.FailOnError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, generatedID, body["request_id"])
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/rs/zerolog"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// StatusReceived is a status of request that has been received, but
+	// not processed yet
+	StatusReceived = "received"
+
+	// requestStatusEventName is the name of server-sent event with the
+	// status of request
+	requestStatusEventName = "status"
+
+	// default values used when the stream settings are not configured
+	defaultRequestStreamPollInterval = 30 * time.Second
+	defaultRequestStreamDuration     = 10 * time.Minute
+)
+
+// SetRequestWatcher method sets the watcher used to get notifications about
+// changes of requests stored in Redis
+func (server *HTTPServer) SetRequestWatcher(watcher services.RequestWatcher) {
+	server.requestWatcher = watcher
+}
+
+// requestStatusStream represents one connection of client watching statuses
+// of requests of one cluster
+type requestStatusStream struct {
+	server     *HTTPServer
+	writer     http.ResponseWriter
+	controller *http.ResponseController
+	logger     *zerolog.Logger
+	orgID      types.OrgID
+	clusterID  types.ClusterName
+	// last status sent to the client for each request ID
+	statuses map[string]string
+}
+
+// streamRequestStatuses method implements endpoint that streams statuses of
+// requests of given cluster as server-sent events. Statuses of all known
+// requests are sent first, then only changes are sent. Changes are detected
+// using Redis keyspace notifications when available, in which case only
+// requests not processed yet are read periodically. Otherwise all requests
+// are read periodically. The stream is closed after the configured duration or
+// when the server starts shutting down and clients are expected to
+// reconnect.
+func (server *HTTPServer) streamRequestStatuses(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Error().Msg(authTokenFormatError)
+		handleServerError(writer, err)
+		return
+	}
+
+	clusterID, successful := httputils.ReadClusterName(writer, request)
+	if !successful {
+		// error handled by function
+		return
+	}
+
+	// make sure we don't access server.redis when it's nil
+	if !server.checkRedisClientReadiness(writer) {
+		// error has been handled already
+		return
+	}
+
+	duration := durationOrDefault(server.Config.RequestStreamDuration, defaultRequestStreamDuration)
+	ctx, cancel := context.WithTimeout(request.Context(), duration)
+	defer cancel()
+
+	stream := requestStatusStream{
+		server:     server,
+		writer:     writer,
+		controller: http.NewResponseController(writer),
+		logger:     logger,
+		orgID:      orgID,
+		clusterID:  clusterID,
+		statuses:   map[string]string{},
+	}
+
+	// the stream is open longer than the usual write timeout
+	err = stream.controller.SetWriteDeadline(time.Now().Add(duration))
+	if err != nil {
+		logger.Warn().Err(err).Msg("Unable to set write deadline for the stream")
+	}
+
+	// subscribe before reading the statuses so no change is missed
+	var notifications <-chan types.RequestID
+	if server.requestWatcher != nil {
+		notifications, err = server.requestWatcher.WatchRequests(ctx, orgID, clusterID)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Unable to watch requests, only periodic checks will be used")
+		}
+	}
+
+	writer.Header().Set(contentTypeHeader, "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	// disable buffering in reverse proxies
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+
+	if err := stream.checkAllRequests(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(durationOrDefault(server.Config.RequestStreamPollInterval, defaultRequestStreamPollInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-server.shutdownChannel:
+			// clients reconnect to other instances
+			return
+		case requestID, ok := <-notifications:
+			if !ok {
+				// watching has been stopped, rely on periodic checks
+				notifications = nil
+				continue
+			}
+			err = stream.checkRequests([]types.RequestID{requestID})
+		case <-ticker.C:
+			if notifications != nil {
+				// new requests and their changes are notified, so only
+				// requests not processed yet are checked in case their
+				// notification has been missed
+				err = stream.checkPendingRequests()
+			} else {
+				err = stream.checkAllRequests()
+			}
+			if err == nil {
+				// keep the connection alive in proxies
+				err = stream.write(": keepalive\n\n")
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// checkAllRequests method reads statuses of all requests of the cluster and
+// sends those that have been changed
+func (stream *requestStatusStream) checkAllRequests() error {
+	requestIDs, err := stream.server.redis.GetRequestIDsForClusterID(stream.orgID, stream.clusterID, 0)
+	if err != nil {
+		// Redis might be temporarily unavailable, try it again later
+		stream.logger.Error().Err(err).Msg("Unable to read request IDs for the stream")
+		return nil
+	}
+
+	return stream.checkRequests(requestIDs)
+}
+
+// checkPendingRequests method reads statuses of requests sent to the client
+// that have not been processed yet and sends those that have been changed.
+// Statuses of processed requests don't change, so they are not read again.
+func (stream *requestStatusStream) checkPendingRequests() error {
+	var requestIDs []types.RequestID
+	for requestID, status := range stream.statuses {
+		if status == StatusReceived {
+			requestIDs = append(requestIDs, types.RequestID(requestID))
+		}
+	}
+	slices.Sort(requestIDs)
+
+	return stream.checkRequests(requestIDs)
+}
+
+// checkRequests method reads statuses of given requests and sends those that
+// have been changed
+func (stream *requestStatusStream) checkRequests(requestIDs []types.RequestID) error {
+	if len(requestIDs) == 0 {
+		return nil
+	}
+
+	requestStatuses, err := stream.server.redis.GetTimestampsForRequestIDs(stream.orgID, stream.clusterID, requestIDs, true)
+	if err != nil {
+		// Redis might be temporarily unavailable, try it again later
+		stream.logger.Error().Err(err).Msg("Unable to read statuses of requests for the stream")
+		return nil
+	}
+
+	for _, requestStatus := range requestStatuses {
+		event := types.RequestStatusEvent{
+			RequestID: requestStatus.RequestID,
+			Status:    StatusReceived,
+			Received:  requestStatus.Received,
+			Processed: requestStatus.Processed,
+		}
+		if requestStatus.Processed != "" {
+			event.Status = StatusProcessed
+		}
+
+		if stream.statuses[event.RequestID] == event.Status {
+			continue
+		}
+		if err := stream.sendEvent(&event); err != nil {
+			return err
+		}
+		stream.statuses[event.RequestID] = event.Status
+	}
+
+	return nil
+}
+
+// sendEvent method sends the status of request to the client
+func (stream *requestStatusStream) sendEvent(event *types.RequestStatusEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		stream.logger.Error().Err(err).Msg(responseDataError)
+		return err
+	}
+
+	return stream.write(fmt.Sprintf("event: %s\ndata: %s\n\n", requestStatusEventName, data))
+}
+
+// write method writes the data to the client immediately. An error is
+// returned when the client is disconnected.
+func (stream *requestStatusStream) write(data string) error {
+	if _, err := stream.writer.Write([]byte(data)); err != nil {
+		stream.logger.Debug().Err(err).Msg("Unable to write to the stream")
+		return err
+	}
+
+	err := stream.controller.Flush()
+	if err != nil {
+		stream.logger.Debug().Err(err).Msg("Unable to flush the stream")
+	}
+	return err
+}
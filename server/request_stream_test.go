@@ -0,0 +1,215 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// fakeRequestWatcher returns prepared notifications about changed requests
+type fakeRequestWatcher struct {
+	requestIDs chan types.RequestID
+	err        error
+}
+
+func (watcher *fakeRequestWatcher) WatchRequests(
+	_ context.Context, _ types.OrgID, _ types.ClusterName,
+) (<-chan types.RequestID, error) {
+	return watcher.requestIDs, watcher.err
+}
+
+// expectRequestStatus mocks reading of request status from Redis
+func expectRequestStatus(redisServer redismock.ClientMock, processed interface{}) {
+	key := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName, "requestID1")
+	redisServer.ExpectHMGet(
+		key, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID1", receivedTimestamp, processed})
+}
+
+// expectRequestIDs mocks reading of request IDs from Redis
+func expectRequestIDs(redisServer redismock.ClientMock) {
+	helpers.ExpectMissingRequestIDsIndex(redisServer, testdata.OrgID, testdata.ClusterName)
+	scanPattern := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName)
+	redisServer.ExpectScan(0, scanPattern, services.ScanBatchCount).SetVal([]string{"requestID1"}, 0)
+}
+
+// requestStatusEventsChecker returns body checker that compares events
+// sent in the stream ignoring keepalive comments
+func requestStatusEventsChecker(t testing.TB, expected, got []byte) {
+	events := []string{}
+	for _, event := range strings.Split(string(got), "\n\n") {
+		if event != "" && !strings.HasPrefix(event, ":") {
+			events = append(events, event)
+		}
+	}
+	assert.Equal(t, string(expected), strings.Join(events, "\n\n"))
+}
+
+// expectedRequestStatusEvents contains events sent when request1 is
+// received and then processed
+var expectedRequestStatusEvents = fmt.Sprintf(
+	"event: status\ndata: {\"requestID\":\"requestID1\",\"status\":\"received\",\"received\":\"%v\"}\n\n"+
+		"event: status\ndata: {\"requestID\":\"requestID1\",\"status\":\"processed\",\"received\":\"%v\",\"processed\":\"%v\"}",
+	receivedTimestamp, receivedTimestamp, latestProcessedTimestamp)
+
+// TestStreamRequestStatusesNotification checks that changes of requests are
+// sent immediately when notification is received
+func TestStreamRequestStatusesNotification(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.RequestStreamDuration = 300 * time.Millisecond
+	config.RequestStreamPollInterval = time.Hour
+
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, &redisClient, nil, nil, nil, nil)
+
+	watcher := fakeRequestWatcher{requestIDs: make(chan types.RequestID, 1)}
+	watcher.requestIDs <- "requestID1"
+	testServer.SetRequestWatcher(&watcher)
+
+	expectRequestIDs(redisServer)
+	expectRequestStatus(redisServer, nil)
+	expectRequestStatus(redisServer, latestProcessedTimestamp)
+
+	iou_helpers.AssertAPIRequest(t, testServer, config.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RequestStatusStream,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode:  http.StatusOK,
+		Headers:     map[string]string{"Content-Type": "text/event-stream"},
+		Body:        expectedRequestStatusEvents,
+		BodyChecker: requestStatusEventsChecker,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestStreamRequestStatusesPeriodicCheck checks that changes of requests
+// are detected by periodic checks when notifications are not available
+func TestStreamRequestStatusesPeriodicCheck(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.RequestStreamDuration = 500 * time.Millisecond
+	config.RequestStreamPollInterval = 50 * time.Millisecond
+
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, &redisClient, nil, nil, nil, nil)
+	testServer.SetRequestWatcher(&fakeRequestWatcher{err: errors.New("notifications are disabled")})
+
+	expectRequestIDs(redisServer)
+	expectRequestStatus(redisServer, nil)
+	// status is not changed
+	expectRequestIDs(redisServer)
+	expectRequestStatus(redisServer, nil)
+	expectRequestIDs(redisServer)
+	expectRequestStatus(redisServer, latestProcessedTimestamp)
+	// following checks fail as no more calls are expected, the stream
+	// has to stay open
+
+	iou_helpers.AssertAPIRequest(t, testServer, config.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RequestStatusStream,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode:  http.StatusOK,
+		Body:        expectedRequestStatusEvents,
+		BodyChecker: requestStatusEventsChecker,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestStreamRequestStatusesPendingCheck checks that only requests not
+// processed yet are read periodically when notifications are available
+func TestStreamRequestStatusesPendingCheck(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.RequestStreamDuration = 500 * time.Millisecond
+	config.RequestStreamPollInterval = 50 * time.Millisecond
+
+	redisClient, redisServer := helpers.GetMockRedis()
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, &redisClient, nil, nil, nil, nil)
+	testServer.SetRequestWatcher(&fakeRequestWatcher{requestIDs: make(chan types.RequestID)})
+
+	expectRequestIDs(redisServer)
+	expectRequestStatus(redisServer, nil)
+	// request IDs are not read again
+	expectRequestStatus(redisServer, nil)
+	expectRequestStatus(redisServer, latestProcessedTimestamp)
+	// following checks fail as no more calls are expected, processed
+	// requests are not read again
+
+	iou_helpers.AssertAPIRequest(t, testServer, config.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.RequestStatusStream,
+		EndpointArgs: []interface{}{testdata.ClusterName},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode:  http.StatusOK,
+		Body:        expectedRequestStatusEvents,
+		BodyChecker: requestStatusEventsChecker,
+	})
+
+	helpers.RedisExpectationsMet(t, redisServer)
+}
+
+// TestStreamRequestStatusesShutdown checks that the stream is closed as soon
+// as the server starts shutting down
+func TestStreamRequestStatusesShutdown(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		config := helpers.DefaultServerConfig
+		config.RequestStreamDuration = time.Hour
+		config.RequestStreamPollInterval = time.Hour
+
+		redisClient, redisServer := helpers.GetMockRedis()
+		testServer := helpers.CreateHTTPServer(&config, nil, nil, &redisClient, nil, nil, nil, nil)
+
+		expectRequestIDs(redisServer)
+		expectRequestStatus(redisServer, nil)
+
+		shutdown := time.AfterFunc(100*time.Millisecond, testServer.MarkShuttingDown)
+		defer shutdown.Stop()
+
+		iou_helpers.AssertAPIRequest(t, testServer, config.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.RequestStatusStream,
+			EndpointArgs: []interface{}{testdata.ClusterName},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body: fmt.Sprintf("event: status\ndata: {\"requestID\":\"requestID1\",\"status\":\"received\",\"received\":\"%v\"}",
+				receivedTimestamp),
+			BodyChecker: requestStatusEventsChecker,
+		})
+
+		helpers.RedisExpectationsMet(t, redisServer)
+	}, testTimeout)
+}
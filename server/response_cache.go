@@ -0,0 +1,113 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// kinds of responses stored in the response cache, used as part of cache
+// keys and as metrics labels
+const (
+	recommendationsCacheKind = "recommendations"
+	clusterReportsCacheKind  = "cluster_reports"
+	ackedRulesCacheKind      = "acked_rules"
+)
+
+// SetResponseCache method sets the cache used to store responses from
+// Insights Results Aggregator
+func (server *HTTPServer) SetResponseCache(responseCache cache.Cache) {
+	server.responseCache = responseCache
+}
+
+// readFromResponseCache method tries to read the response from cache
+func (server HTTPServer) readFromResponseCache(orgID types.OrgID, kind, key string) ([]byte, bool) {
+	if server.responseCache == nil {
+		return nil, false
+	}
+
+	value, found := server.responseCache.Get(orgID, kind+":"+key)
+	if found {
+		metrics.ResponseCacheHits.WithLabelValues(kind).Inc()
+		log.Debug().Int(orgIDTag, int(orgID)).Str("kind", kind).Msg("Response read from cache")
+	} else {
+		metrics.ResponseCacheMisses.WithLabelValues(kind).Inc()
+	}
+
+	return value, found
+}
+
+// storeToResponseCache method stores the response into cache
+func (server HTTPServer) storeToResponseCache(orgID types.OrgID, kind, key string, value []byte) {
+	if server.responseCache == nil {
+		return
+	}
+
+	server.responseCache.Set(orgID, kind+":"+key, value)
+}
+
+// invalidateResponseCache method removes all responses cached for the
+// organization
+func (server HTTPServer) invalidateResponseCache(orgID types.OrgID) {
+	if server.responseCache == nil {
+		return
+	}
+
+	log.Debug().Int(orgIDTag, int(orgID)).Msg("Invalidating response cache")
+	server.responseCache.InvalidateOrg(orgID)
+}
+
+// invalidatingResponseCache method wraps handler that changes data stored
+// in aggregator (votes, rule disables etc.). Responses cached for the
+// organization are removed once the handler finishes.
+func (server *HTTPServer) invalidatingResponseCache(handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		handler(writer, request)
+
+		if server.responseCache == nil {
+			return
+		}
+
+		orgID, err := server.GetCurrentOrgID(request)
+		if err != nil {
+			log.Debug().Err(err).Msg("Unable to get organization for cache invalidation")
+			return
+		}
+		server.invalidateResponseCache(orgID)
+	}
+}
+
+// clusterListCacheKey function computes cache key for list of clusters. The
+// key doesn't depend on order of clusters in the list.
+func clusterListCacheKey[T ~string](clusters []T) string {
+	sorted := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		sorted[i] = string(cluster)
+	}
+	sort.Strings(sorted)
+
+	hash := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return hex.EncodeToString(hash[:])
+}
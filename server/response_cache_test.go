@@ -0,0 +1,121 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+const emptyAckListResponse = `
+{
+	"data":[],
+	"meta": {
+		"count": 0
+	}
+}
+`
+
+// expectAckListFromAggregator mocks one request for list of acked rules
+// made to aggregator
+func expectAckListFromAggregator(t testing.TB) {
+	helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+		EndpointArgs: []interface{}{testdata.OrgID},
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       `{"disabledRules":[], "status":"ok"}`,
+	})
+}
+
+// assertAckList sends request for list of acked rules and checks that the
+// list is empty
+func assertAckList(t testing.TB, testServer *server.HTTPServer) {
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.AckListEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       emptyAckListResponse,
+	})
+}
+
+// TestResponseCacheHit checks that the second request is served from cache
+// without calling aggregator
+func TestResponseCacheHit(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		testServer.SetResponseCache(cache.NewMemoryCache(time.Minute))
+
+		// aggregator is expected to be called just once
+		expectAckListFromAggregator(t)
+
+		assertAckList(t, testServer)
+		assertAckList(t, testServer)
+	}, testTimeout)
+}
+
+// TestResponseCacheInvalidation checks that cached responses are removed
+// when user changes data stored in aggregator
+func TestResponseCacheInvalidation(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		testServer.SetResponseCache(cache.NewMemoryCache(time.Minute))
+
+		expectAckListFromAggregator(t)
+		assertAckList(t, testServer)
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     ira_server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID, testdata.UserID},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+
+		// cache has been invalidated so aggregator needs to be called again
+		expectAckListFromAggregator(t)
+		assertAckList(t, testServer)
+	}, testTimeout)
+}
@@ -42,15 +42,19 @@ const (
 	RuleIDParamName = "rule_id"
 	// RequestIDParam parameter name in the URL for request IDs
 	RequestIDParam = "request_id"
+	// PreviousRequestIDParam parameter name in the URL for request ID the
+	// report is compared with
+	PreviousRequestIDParam = "previous_request_id"
 	// NamespaceIDParam parameter name in the URL for namespace UUIDs
 	NamespaceIDParam = "namespace"
 )
 
 func readRuleIDWithErrorKey(writer http.ResponseWriter, request *http.Request) (ctypes.RuleID, ctypes.ErrorKey, error) {
+	logger := requestLogger(request)
 	ruleIDWithErrorKey, err := httputils.GetRouterParam(request, RuleIDParamName)
 	if err != nil {
 		const message = "unable to get rule id"
-		log.Error().Err(err).Msg(message)
+		logger.Error().Err(err).Msg(message)
 		handleServerError(writer, err)
 		return ctypes.RuleID(""), ctypes.ErrorKey(""), err
 	}
@@ -63,7 +67,7 @@ func readRuleIDWithErrorKey(writer http.ResponseWriter, request *http.Request) (
 			RuleIDParamName, ruleIDWithErrorKey, err.Error(),
 		))
 		if respErr != nil {
-			log.Error().Err(respErr).Msg("Error sending bad request response")
+			logger.Error().Err(respErr).Msg("Error sending bad request response")
 		}
 		return ctypes.RuleID(""), ctypes.ErrorKey(""), err
 	}
@@ -200,7 +204,13 @@ func ValidateRequestID(requestID string) (types.RequestID, error) {
 // readRequestID retrieves request ID from request
 // if it's not possible, it writes http error to the writer and returns error
 func readRequestID(writer http.ResponseWriter, request *http.Request) (types.RequestID, error) {
-	requestID, err := httputils.GetRouterParam(request, RequestIDParam)
+	return readRequestIDParam(writer, request, RequestIDParam)
+}
+
+// readRequestIDParam retrieves request ID stored in given router parameter
+// if it's not possible, it writes http error to the writer and returns error
+func readRequestIDParam(writer http.ResponseWriter, request *http.Request, paramName string) (types.RequestID, error) {
+	requestID, err := httputils.GetRouterParam(request, paramName)
 	if err != nil {
 		handleServerError(writer, err)
 		return "", err
@@ -209,7 +219,7 @@ func readRequestID(writer http.ResponseWriter, request *http.Request) (types.Req
 	validatedRequestID, err := ValidateRequestID(requestID)
 	if err != nil {
 		err := &RouterParsingError{
-			ParamName:  RequestIDParam,
+			ParamName:  paramName,
 			ParamValue: requestID,
 			ErrString:  err.Error(),
 		}
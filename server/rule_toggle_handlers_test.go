@@ -118,7 +118,7 @@ func TestEnableEndpointBadErrorKey(t *testing.T) {
 	assert.Nil(t, err)
 	helpers.RunTestWithTimeout(t, func(t testing.TB) {
 		expectedBody := fmt.Sprintf(
-			`{"status":"Item with ID %s/%s was not found in the storage"}`,
+			`{"status":"Item with ID %s/%s was not found in the storage","request_id":"test-request-id"}`,
 			testdata.Rule1ID,
 			testdata.ErrorKey1,
 		)
@@ -134,6 +134,7 @@ func TestEnableEndpointBadErrorKey(t *testing.T) {
 				Endpoint:     server.EnableRuleForClusterEndpoint,
 				EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			},
 			&helpers.APIResponse{
 				StatusCode: http.StatusNotFound,
@@ -148,7 +149,7 @@ func TestDisableEndpointBadErrorKey(t *testing.T) {
 	assert.Nil(t, err)
 	helpers.RunTestWithTimeout(t, func(t testing.TB) {
 		expectedBody := fmt.Sprintf(
-			`{"status":"Item with ID %s/%s was not found in the storage"}`,
+			`{"status":"Item with ID %s/%s was not found in the storage","request_id":"test-request-id"}`,
 			testdata.Rule1ID,
 			testdata.ErrorKey1,
 		)
@@ -164,6 +165,7 @@ func TestDisableEndpointBadErrorKey(t *testing.T) {
 				Endpoint:     server.DisableRuleForClusterEndpoint,
 				EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1},
 				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			},
 			&helpers.APIResponse{
 				StatusCode: http.StatusNotFound,
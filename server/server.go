@@ -32,6 +32,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	// we just have to import this package in order to expose pprof
@@ -49,10 +51,18 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
 	"github.com/RedHatInsights/insights-results-smart-proxy/amsclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
 	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
 
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
 )
@@ -102,6 +112,7 @@ const (
 	compositeRuleIDError = "Error generating composite rule ID"
 	clusterListError     = "problem reading cluster list for org"
 	ruleContentError     = "unable to get content for rule"
+	shuttingDownMessage  = "Service is shutting down"
 )
 
 // HTTPServer is an implementation of Server interface
@@ -114,8 +125,22 @@ type HTTPServer struct {
 	ErrorFoundChannel chan bool
 	ErrorChannel      chan error
 	Serv              *http.Server
+	servMutex         *sync.Mutex
 	redis             services.RedisInterface
 	rbacClient        auth.RBACClient
+	shuttingDown      *atomic.Bool
+	responseCache     cache.Cache
+	rateLimiter       *ratelimit.RateLimiter
+	trendsStore       trends.Store
+	webhookStore      webhooks.Store
+	webhooksConf      webhooks.Configuration
+	ackExpiryStore    ackexpiry.Store
+	auditStore        audit.Store
+	requestWatcher    services.RequestWatcher
+	upstreamStatuses  *dependencyStatusCache
+	// closed when the server starts draining requests, so long-lived
+	// connections can be finished immediately
+	shutdownChannel chan struct{}
 }
 
 // RequestModifier is a type of function which modifies request when proxying
@@ -151,11 +176,42 @@ func New(config Configuration,
 		ErrorFoundChannel: errorFoundChannel,
 		ErrorChannel:      errorChannel,
 		rbacClient:        rbacClient,
+		servMutex:         &sync.Mutex{},
+		shuttingDown:      &atomic.Bool{},
+		shutdownChannel:   make(chan struct{}),
+		upstreamStatuses:  &dependencyStatusCache{},
 	}
 }
 
+// MarkShuttingDown method switches the server into draining mode. Requests
+// that are already being processed are finished normally, but the main
+// endpoint starts to report the service as unavailable so the load balancer
+// stops sending new requests to this instance.
+func (server *HTTPServer) MarkShuttingDown() {
+	if server.shuttingDown == nil {
+		return
+	}
+	if server.shuttingDown.CompareAndSwap(false, true) && server.shutdownChannel != nil {
+		close(server.shutdownChannel)
+	}
+}
+
+// IsShuttingDown method returns true if the server is draining requests
+// before it is stopped.
+func (server *HTTPServer) IsShuttingDown() bool {
+	return server.shuttingDown != nil && server.shuttingDown.Load()
+}
+
 // mainEndpoint method handles requests to the main endpoint.
 func (server *HTTPServer) mainEndpoint(writer http.ResponseWriter, _ *http.Request) {
+	if server.IsShuttingDown() {
+		err := responses.SendServiceUnavailable(writer, shuttingDownMessage)
+		if err != nil {
+			log.Error().Err(err).Msg(responseDataError)
+		}
+		return
+	}
+
 	err := responses.SendOK(writer, responses.BuildOkResponse())
 	if err != nil {
 		log.Error().Err(err).Msg(responseDataError)
@@ -168,7 +224,12 @@ func (server *HTTPServer) Initialize() http.Handler {
 	log.Info().Msgf("Initializing HTTP server at '%s'", server.Config.Address)
 
 	router := mux.NewRouter().StrictSlash(true)
-	router.Use(httputils.LogRequest)
+	// write deadline has to be changed using the original response writer,
+	// so this middleware must be the first one
+	router.Use(server.RouteWriteTimeoutMiddleware)
+	router.Use(RequestIDMiddleware)
+	router.Use(TracingMiddleware)
+	router.Use(AccessLogMiddleware)
 
 	// Add custom metrics middleware to capture user-agent information
 	router.Use(MetricsMiddleware)
@@ -176,6 +237,10 @@ func (server *HTTPServer) Initialize() http.Handler {
 	// Set up authentication and authorization middleware
 	server.setupAuthMiddleware(router)
 
+	// rate limits are counted per organization, so the identity has to be
+	// known at this point
+	router.Use(server.RateLimitMiddleware)
+
 	if server.Config.EnableCORS {
 		headersOK := handlers.AllowedHeaders([]string{
 			"Content-Type",
@@ -211,24 +276,46 @@ func (server *HTTPServer) addEndpointsToRouter(router *mux.Router) {
 	server.addV2EndpointsToRouter(router)
 }
 
-// Start method starts HTTP or HTTPS server.
+// Start method starts HTTP or HTTPS server. Start is usually called in its
+// own goroutine, so the HTTP server is published under the mutex and it is
+// not started at all when Stop has been called already.
 func (server *HTTPServer) Start() error {
 	address := server.Config.Address
 	log.Info().Msgf("Starting HTTP server at '%s'", address)
 	router := server.Initialize()
-	server.Serv = &http.Server{
-		Addr:              address,
-		Handler:           router,
-		ReadTimeout:       1 * time.Minute,
-		ReadHeaderTimeout: 5 * time.Second,
-		WriteTimeout:      30 * time.Second,
+	serv := server.newHTTPServer(router)
+	// streams would otherwise block the shutdown until they are closed
+	serv.RegisterOnShutdown(server.MarkShuttingDown)
+
+	if server.Config.UseHTTPS {
+		tlsConfig, err := newTLSConfig(&server.Config)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to configure TLS")
+			return err
+		}
+		serv.TLSConfig = tlsConfig
+	}
+
+	server.servMutex.Lock()
+	if server.IsShuttingDown() {
+		server.servMutex.Unlock()
+		log.Info().Msg("Server is shutting down, HTTP/S server is not started")
+		return nil
+	}
+	server.Serv = serv
+	server.servMutex.Unlock()
+
+	listener, err := server.listen()
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to start HTTP/S server")
+		return err
 	}
-	var err error
 
 	if server.Config.UseHTTPS {
-		err = server.Serv.ListenAndServeTLS("server.crt", "server.key")
+		// certificate is provided by TLSConfig.GetCertificate
+		err = serv.ServeTLS(listener, "", "")
 	} else {
-		err = server.Serv.ListenAndServe()
+		err = serv.Serve(listener)
 	}
 	if err != nil && err != http.ErrServerClosed {
 		log.Error().Err(err).Msg("Unable to start HTTP/S server")
@@ -238,9 +325,22 @@ func (server *HTTPServer) Start() error {
 	return nil
 }
 
-// Stop method stops server's execution.
+// Stop method stops server's execution. Listeners are closed immediately,
+// while requests that are in progress are allowed to finish until the
+// context deadline is reached.
 func (server *HTTPServer) Stop(ctx context.Context) error {
-	return server.Serv.Shutdown(ctx)
+	// marked before the HTTP server is read, so Start called concurrently
+	// either publishes the HTTP server first or does not start it at all
+	server.MarkShuttingDown()
+
+	server.servMutex.Lock()
+	serv := server.Serv
+	server.servMutex.Unlock()
+
+	if serv == nil {
+		return nil
+	}
+	return serv.Shutdown(ctx)
 }
 
 // modifyRequest function modifies HTTP request during proxying it to another
@@ -295,8 +395,8 @@ func (server *HTTPServer) proxyTo(baseURL string, options *ProxyOptions) func(ht
 			return
 		}
 
-		client := http.Client{}
-		req, err := http.NewRequest(request.Method, endpointURL.String(), request.Body)
+		client := server.upstreamClient(baseURL)
+		req, err := http.NewRequestWithContext(request.Context(), request.Method, endpointURL.String(), request.Body)
 		if err != nil {
 			panic(err)
 		}
@@ -319,6 +419,54 @@ func (server *HTTPServer) proxyTo(baseURL string, options *ProxyOptions) func(ht
 	}
 }
 
+// upstreamClient method returns HTTP client for the upstream service
+// with given base URL
+func (server *HTTPServer) upstreamClient(baseURL string) *http.Client {
+	if baseURL == server.ServicesConfig.ContentBaseEndpoint {
+		return httpclient.Upstream(httpclient.ContentServiceUpstream)
+	}
+	return aggregatorClient()
+}
+
+// aggregatorClient function returns HTTP client used to call Insights
+// Results Aggregator
+func aggregatorClient() *http.Client {
+	return httpclient.Upstream(httpclient.AggregatorUpstream)
+}
+
+// aggregatorGet function sends GET request to Insights Results Aggregator.
+// The context of the incoming request is used, so the trace context is
+// propagated to aggregator.
+func aggregatorGet(ctx context.Context, aggregatorURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aggregatorURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	return aggregatorClient().Do(req)
+}
+
+// aggregatorPost function sends POST request with JSON body to Insights
+// Results Aggregator within the context of the incoming request
+func aggregatorPost(ctx context.Context, aggregatorURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, aggregatorURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(contentTypeHeader, JSONContentType)
+	return aggregatorClient().Do(req)
+}
+
+// aggregatorPut function sends PUT request with JSON body to Insights
+// Results Aggregator within the context of the incoming request
+func aggregatorPut(ctx context.Context, aggregatorURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, aggregatorURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(contentTypeHeader, JSONContentType)
+	return aggregatorClient().Do(req)
+}
+
 // evaluateProxyError handles detected error in proxyTo
 // according to its type and the requested baseURL
 func (server *HTTPServer) evaluateProxyError(writer http.ResponseWriter, err error, baseURL string) {
@@ -337,7 +485,7 @@ func (server *HTTPServer) evaluateProxyError(writer http.ResponseWriter, err err
 }
 
 func sendRequest(
-	client http.Client, req *http.Request, options *ProxyOptions,
+	client *http.Client, req *http.Request, options *ProxyOptions,
 ) (*http.Response, []byte, error) {
 	log.Debug().Msgf("Connecting to %s", req.URL.RequestURI())
 	response, err := client.Do(req)
@@ -366,9 +514,7 @@ func sendRequest(
 }
 
 func (server *HTTPServer) composeEndpoint(baseEndpoint, currentEndpoint string) (*url.URL, error) {
-	endpoint := strings.TrimPrefix(currentEndpoint, server.Config.APIv1Prefix)
-	endpoint = strings.TrimPrefix(endpoint, server.Config.APIv2Prefix)
-	endpoint = strings.TrimPrefix(endpoint, server.Config.APIdbgPrefix)
+	endpoint := server.trimAPIPrefix(currentEndpoint)
 
 	joinedURL, err := url.JoinPath(baseEndpoint, endpoint)
 	if err != nil {
@@ -386,10 +532,16 @@ func copyHeader(srcHeaders, dstHeaders http.Header) {
 	}
 }
 
-func (server HTTPServer) getClusterInfoFromAMS(orgID ctypes.OrgID) (
+func (server HTTPServer) getClusterInfoFromAMS(ctx context.Context, orgID ctypes.OrgID) (
 	clusterInfoList []types.ClusterInfo,
 	err error,
 ) {
+	_, span := tracing.StartSpan(ctx, "ams.GetClustersForOrganization", tracing.OrgID(orgID))
+	defer func() {
+		span.SetAttributes(tracing.ClusterCount(len(clusterInfoList)))
+		tracing.EndSpan(span, err)
+	}()
+
 	// providing nil filters will mean default filters will be applied
 	clusterInfoList, err = server.amsClient.GetClustersForOrganization(orgID, nil, nil)
 	if err != nil {
@@ -401,12 +553,12 @@ func (server HTTPServer) getClusterInfoFromAMS(orgID ctypes.OrgID) (
 }
 
 // readClusterInfoForOrgID returns a list of cluster info types and a map of cluster display names
-func (server HTTPServer) readClusterInfoForOrgID(orgID ctypes.OrgID) (
+func (server HTTPServer) readClusterInfoForOrgID(ctx context.Context, orgID ctypes.OrgID) (
 	[]types.ClusterInfo,
 	error,
 ) {
 	if server.amsClient != nil {
-		clusterInfoList, err := server.getClusterInfoFromAMS(orgID)
+		clusterInfoList, err := server.getClusterInfoFromAMS(ctx, orgID)
 		if err != nil {
 			log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg("Error retrieving cluster info from AMS API")
 			return clusterInfoList, err
@@ -422,7 +574,7 @@ func (server HTTPServer) readClusterInfoForOrgID(orgID ctypes.OrgID) (
 	}
 
 	log.Info().Msg("amsclient not initialized. Using fallback mechanism")
-	clusterIDs, err := server.getClusterDetailsFromAggregator(orgID)
+	clusterIDs, err := server.getClusterDetailsFromAggregator(ctx, orgID)
 	if err != nil {
 		log.Error().Err(err).Msg("error retrieving clusters from aggregator")
 		return nil, err
@@ -441,7 +593,7 @@ func (server HTTPServer) readClusterInfoForOrgID(orgID ctypes.OrgID) (
 }
 
 // getClusterDetailsFromAggregator reads the list of clusters for a given organization from aggregator
-func (server HTTPServer) getClusterDetailsFromAggregator(orgID ctypes.OrgID) ([]ctypes.ClusterName, error) {
+func (server HTTPServer) getClusterDetailsFromAggregator(ctx context.Context, orgID ctypes.OrgID) ([]ctypes.ClusterName, error) {
 	log.Debug().Msg("retrieving cluster IDs from aggregator")
 
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -451,7 +603,7 @@ func (server HTTPServer) getClusterDetailsFromAggregator(orgID ctypes.OrgID) ([]
 	)
 
 	// #nosec G107
-	response, err := http.Get(aggregatorURL)
+	response, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		log.Error().Err(err).Msg("problem getting cluster list from aggregator")
 		if _, ok := err.(*url.Error); ok {
@@ -475,6 +627,7 @@ func (server HTTPServer) getClusterDetailsFromAggregator(orgID ctypes.OrgID) ([]
 // handles errors by sending corresponding message to the user.
 // Returns report and bool value set to true if there was no errors
 func (server HTTPServer) readAggregatorReportForClusterID(
+	ctx context.Context,
 	orgID ctypes.OrgID, clusterID ctypes.ClusterName, userID ctypes.UserID, writer http.ResponseWriter,
 ) (*ctypes.ReportResponse, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -486,7 +639,7 @@ func (server HTTPServer) readAggregatorReportForClusterID(
 	)
 
 	// #nosec G107
-	aggregatorResp, err := http.Get(aggregatorURL)
+	aggregatorResp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
@@ -533,6 +686,7 @@ func (server HTTPServer) readAggregatorReportForClusterID(
 // handles errors by sending corresponding message to the user.
 // Returns report and bool value set to true if there was no errors
 func (server HTTPServer) readAggregatorReportMetainfoForClusterID(
+	ctx context.Context,
 	orgID ctypes.OrgID, clusterID ctypes.ClusterName, userID ctypes.UserID, writer http.ResponseWriter,
 ) (*ctypes.ReportResponseMetainfo, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -544,7 +698,7 @@ func (server HTTPServer) readAggregatorReportMetainfoForClusterID(
 	)
 
 	// #nosec G107
-	aggregatorResp, err := http.Get(aggregatorURL)
+	aggregatorResp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
@@ -585,9 +739,41 @@ func (server HTTPServer) readAggregatorReportMetainfoForClusterID(
 }
 
 func (server HTTPServer) readAggregatorReportForClusterList(
+	ctx context.Context,
 	orgID ctypes.OrgID, clusterList []string, writer http.ResponseWriter,
 ) (*ctypes.ClusterReports, bool) {
+	cacheKey := clusterListCacheKey(clusterList)
+	responseBytes, found := server.readFromResponseCache(orgID, clusterReportsCacheKind, cacheKey)
+	if !found {
+		var ok bool
+		responseBytes, ok = server.readRawAggregatorReportForClusterList(ctx, orgID, clusterList, writer)
+		if !ok {
+			return nil, false
+		}
+		server.storeToResponseCache(orgID, clusterReportsCacheKind, cacheKey, responseBytes)
+	}
+
+	var aggregatorResponse ctypes.ClusterReports
+
+	err := json.Unmarshal(responseBytes, &aggregatorResponse)
+	if err != nil {
+		handleServerError(writer, err)
+		return nil, false
+	}
+	logClustersReport(orgID, aggregatorResponse.Reports)
+
+	return &aggregatorResponse, true
+}
+
+// readRawAggregatorReportForClusterList method reads reports for list of
+// clusters from aggregator. Error response is sent to the client when
+// aggregator is not able to provide the reports.
+func (server HTTPServer) readRawAggregatorReportForClusterList(
+	ctx context.Context,
+	orgID ctypes.OrgID, clusterList []string, writer http.ResponseWriter,
+) ([]byte, bool) {
 	clist := strings.Join(clusterList, ",")
+	tracing.SetAttributes(ctx, tracing.ClusterCount(len(clusterList)))
 	aggregatorURL := httputils.MakeURLToEndpoint(
 		server.ServicesConfig.AggregatorBaseEndpoint,
 		ira_server.ReportForListOfClustersEndpoint,
@@ -595,7 +781,7 @@ func (server HTTPServer) readAggregatorReportForClusterList(
 		clist)
 
 	// #nosec G107
-	aggregatorResp, err := http.Get(aggregatorURL)
+	aggregatorResp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
@@ -605,8 +791,6 @@ func (server HTTPServer) readAggregatorReportForClusterList(
 		return nil, false
 	}
 
-	var aggregatorResponse ctypes.ClusterReports
-
 	defer services.CloseResponseBody(aggregatorResp)
 
 	responseBytes, err := io.ReadAll(aggregatorResp.Body)
@@ -623,17 +807,11 @@ func (server HTTPServer) readAggregatorReportForClusterList(
 		return nil, false
 	}
 
-	err = json.Unmarshal(responseBytes, &aggregatorResponse)
-	if err != nil {
-		handleServerError(writer, err)
-		return nil, false
-	}
-	logClustersReport(orgID, aggregatorResponse.Reports)
-
-	return &aggregatorResponse, true
+	return responseBytes, true
 }
 
 func (server HTTPServer) readAggregatorReportForClusterListFromBody(
+	ctx context.Context,
 	orgID ctypes.OrgID, request *http.Request, writer http.ResponseWriter,
 ) (*ctypes.ClusterReports, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -648,7 +826,7 @@ func (server HTTPServer) readAggregatorReportForClusterListFromBody(
 		return nil, false
 	}
 	// #nosec G107
-	aggregatorResp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(body))
+	aggregatorResp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(body))
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
@@ -700,6 +878,7 @@ func handleReportsResponse(response *http.Response, writer http.ResponseWriter)
 // handles errors by sending corresponding message to the user.
 // Returns report and bool value set to true if there was no errors
 func (server HTTPServer) readAggregatorRuleForClusterID(
+	ctx context.Context,
 	orgID ctypes.OrgID, clusterID ctypes.ClusterName, userID ctypes.UserID, ruleID ctypes.RuleID, errorKey ctypes.ErrorKey, writer http.ResponseWriter,
 ) (*ctypes.RuleOnReport, bool) {
 	aggregatorURL := httputils.MakeURLToEndpoint(
@@ -712,7 +891,7 @@ func (server HTTPServer) readAggregatorRuleForClusterID(
 	)
 
 	// #nosec G107
-	aggregatorResp, err := http.Get(aggregatorURL)
+	aggregatorResp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
@@ -771,7 +950,7 @@ func (server HTTPServer) fetchAggregatorReport(
 	}
 	log.Info().Msgf("fetchAggregatorReport orgID %v userID %v for cluster %v", orgID, userID, clusterID)
 
-	aggregatorResponse, successful = server.readAggregatorReportForClusterID(orgID, clusterID, userID, writer)
+	aggregatorResponse, successful = server.readAggregatorReportForClusterID(request.Context(), orgID, clusterID, userID, writer)
 	if !successful {
 		log.Warn().Msg("fetchAggregatorReport unable to get response from aggregator")
 		return
@@ -796,7 +975,7 @@ func (server HTTPServer) fetchAggregatorReportMetainfo(
 		return
 	}
 
-	aggregatorResponse, successful = server.readAggregatorReportMetainfoForClusterID(orgID, clusterID, userID, writer)
+	aggregatorResponse, successful = server.readAggregatorReportMetainfoForClusterID(request.Context(), orgID, clusterID, userID, writer)
 	if !successful {
 		return
 	}
@@ -822,7 +1001,7 @@ func (server HTTPServer) fetchAggregatorReports(
 		return nil, false
 	}
 
-	aggregatorResponse, successful := server.readAggregatorReportForClusterList(orgID, clusterList, writer)
+	aggregatorResponse, successful := server.readAggregatorReportForClusterList(request.Context(), orgID, clusterList, writer)
 	if !successful {
 		return nil, false
 	}
@@ -841,7 +1020,7 @@ func (server HTTPServer) fetchAggregatorReportsUsingRequestBodyClusterList(
 		return nil, false
 	}
 
-	aggregatorResponse, successful := server.readAggregatorReportForClusterListFromBody(orgID, request, writer)
+	aggregatorResponse, successful := server.readAggregatorReportForClusterListFromBody(request.Context(), orgID, request, writer)
 	if !successful {
 		return nil, false
 	}
@@ -883,14 +1062,14 @@ func (server HTTPServer) buildReportEndpointResponse(
 		return
 	}
 
-	acks, err := server.readListOfAckedRules(orgID)
+	acks, err := server.readListOfAckedRules(request.Context(), orgID)
 	if err != nil {
 		log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg("Unable to retrieve list of acked rules for given organization")
 		// server error has been handled already
 		return
 	}
 
-	systemWideRuleDisables := generateRuleAckMap(acks)
+	systemWideRuleDisables := generateRuleAckMap(acks, server.readAckExpirations(orgID))
 
 	visibleRules, noContentRulesCnt, disabledRulesCnt, err := filterRulesInResponse(
 		aggregatorResponse.Report, osdFlag, includeDisabled, systemWideRuleDisables,
@@ -922,11 +1101,12 @@ func sendReportReponse(writer http.ResponseWriter, report interface{}) {
 // as expected, but we must fix this behaviour for request made by earlier versions. For more info, see
 // https://issues.redhat.com/browse/CCXDEV-9393 and the linked issue.
 func (server HTTPServer) reportEndpointV1(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	var managedCluster bool
 
 	orgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Msg(authTokenFormatError)
+		logger.Error().Msg(authTokenFormatError)
 		handleServerError(writer, err)
 		return
 	}
@@ -952,7 +1132,7 @@ func (server HTTPServer) reportEndpointV1(writer http.ResponseWriter, request *h
 		if server.amsClient != nil {
 			clusterInfo, err := server.amsClient.GetSingleClusterInfoForOrganization(orgID, clusterID)
 			if err != nil {
-				log.Warn().Err(err).Msg("unable to retrieve info from AMS API")
+				logger.Warn().Err(err).Msg("unable to retrieve info from AMS API")
 				handleServerError(writer, err)
 				return
 			}
@@ -964,7 +1144,7 @@ func (server HTTPServer) reportEndpointV1(writer http.ResponseWriter, request *h
 		if err != nil {
 			log.Err(err).Msgf("Cluster ID: %v; Got error while parsing `%s` value", clusterID, OSDEligibleParam)
 		}
-		log.Debug().Msgf("Cluster ID: %v; %s flag = %t", clusterID, OSDEligibleParam, managedCluster)
+		logger.Debug().Msgf("Cluster ID: %v; %s flag = %t", clusterID, OSDEligibleParam, managedCluster)
 	}
 
 	if report.Data, report.Meta.Count, err = server.buildReportEndpointResponse(
@@ -1023,23 +1203,24 @@ func fillImpacted(
 }
 
 func (server HTTPServer) getKnownUserAgentProduct(request *http.Request) (userAgentProduct string) {
+	logger := requestLogger(request)
 	userAgentProduct = readUserAgentHeaderProduct(request)
 
 	switch userAgentProduct {
 	case insightsOperatorUserAgent:
-		log.Debug().Msg("request made by Insights Operator to be shown in the OCP Web console")
+		logger.Debug().Msg("request made by Insights Operator to be shown in the OCP Web console")
 	case acmUserAgent:
-		log.Debug().Msg("request made by ACM Operator to be shown in the the Advanced Cluster Management")
+		logger.Debug().Msg("request made by ACM Operator to be shown in the the Advanced Cluster Management")
 	case browserUserAgent:
-		log.Debug().Msg("request made by a regular web browser")
+		logger.Debug().Msg("request made by a regular web browser")
 	case openAPIGeneratorUserAgent:
-		log.Debug().Msg("request made by OpenAPI-generated test client from iqe tests")
+		logger.Debug().Msg("request made by OpenAPI-generated test client from iqe tests")
 	case pythonRequestsUserAgent:
-		log.Debug().Msg("request made by Python requests library probably from iqe tests")
+		logger.Debug().Msg("request made by Python requests library probably from iqe tests")
 	case nonRelevantUserAgent:
-		log.Debug().Msg("request made by non-relevant-user-agent test case from iqe tests")
+		logger.Debug().Msg("request made by non-relevant-user-agent test case from iqe tests")
 	default:
-		log.Warn().Str(userAgentHeader, request.Header.Get(userAgentHeader)).
+		logger.Warn().Str(userAgentHeader, request.Header.Get(userAgentHeader)).
 			Str("userAgentProduct", userAgentProduct).
 			Msg("improper or unknown user agent product")
 	}
@@ -1051,16 +1232,17 @@ func (server HTTPServer) getKnownUserAgentProduct(request *http.Request) (userAg
 // Aggregator's database and return the retrieved info to requester via
 // response payload. The payload has type types.ReportResponseMetainfo
 func (server HTTPServer) reportMetainfoEndpoint(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	aggregatorResponse, successful, clusterID := server.fetchAggregatorReportMetainfo(writer, request)
 	if !successful {
 		return
 	}
 
-	log.Debug().Msgf("Metainfo returned by aggregator for cluster %s: %v", clusterID, aggregatorResponse)
+	logger.Debug().Msgf("Metainfo returned by aggregator for cluster %s: %v", clusterID, aggregatorResponse)
 
 	err := responses.SendOK(writer, responses.BuildOkResponseWithData("metainfo", aggregatorResponse))
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
@@ -1088,6 +1270,7 @@ func (server HTTPServer) getRuleCount(visibleRules []types.RuleWithContentRespon
 // request path. List of clusters is specified in request path as well which
 // means that clients needs to deal with URL limit (around 2000 characters).
 func (server HTTPServer) reportForListOfClustersEndpoint(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	// try to read results from Insights Results Aggregator service
 	aggregatorResponse, successful := server.fetchAggregatorReports(writer, request)
 	if !successful {
@@ -1097,7 +1280,7 @@ func (server HTTPServer) reportForListOfClustersEndpoint(writer http.ResponseWri
 	// send the response back to client
 	err := responses.Send(http.StatusOK, writer, aggregatorResponse)
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
@@ -1106,6 +1289,7 @@ func (server HTTPServer) reportForListOfClustersEndpoint(writer http.ResponseWri
 // request path. List of clusters is specified in request body which means that
 // clients can use as many cluster ID as they want without any (real) limits.
 func (server HTTPServer) reportForListOfClustersPayloadEndpoint(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	// try to read results from Insights Results Aggregator service
 	aggregatorResponse, successful := server.fetchAggregatorReportsUsingRequestBodyClusterList(writer, request)
 	if !successful {
@@ -1115,7 +1299,7 @@ func (server HTTPServer) reportForListOfClustersPayloadEndpoint(writer http.Resp
 	// send the response back to client
 	err := responses.Send(http.StatusOK, writer, aggregatorResponse)
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
@@ -1139,7 +1323,7 @@ func (server HTTPServer) fetchAggregatorReportRule(
 		return nil, false
 	}
 
-	aggregatorResponse, successful := server.readAggregatorRuleForClusterID(orgID, clusterID, userID, ruleID, errorKey, writer)
+	aggregatorResponse, successful := server.readAggregatorRuleForClusterID(request.Context(), orgID, clusterID, userID, ruleID, errorKey, writer)
 	if !successful {
 		return nil, false
 	}
@@ -1147,6 +1331,7 @@ func (server HTTPServer) fetchAggregatorReportRule(
 }
 
 func (server HTTPServer) singleRuleEndpoint(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	var rule *types.RuleWithContentResponse
 	var filtered bool
 	var err error
@@ -1171,7 +1356,7 @@ func (server HTTPServer) singleRuleEndpoint(writer http.ResponseWriter, request
 	if rule.Internal {
 		err = server.checkInternalRulePermissions(request)
 		if err != nil {
-			log.Error().Err(err).Send()
+			logger.Error().Err(err).Send()
 			handleServerError(writer, err)
 			return
 		}
@@ -1179,7 +1364,7 @@ func (server HTTPServer) singleRuleEndpoint(writer http.ResponseWriter, request
 
 	err = responses.SendOK(writer, responses.BuildOkResponseWithData(reportStr, *rule))
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
@@ -1201,20 +1386,21 @@ func handleFetchRuleContentError(writer http.ResponseWriter, err error) {
 // whether that ID is on the list of allowed organizations to access internal
 // rules
 func (server *HTTPServer) checkInternalRulePermissions(request *http.Request) error {
+	logger := requestLogger(request)
 	if !server.Config.EnableInternalRulesOrganizations || !server.Config.Auth {
 		return nil
 	}
 
 	requestOrgID, err := server.GetCurrentOrgID(request)
 	if err != nil {
-		log.Error().Err(err).Msg("error retrieving org_id from token")
+		logger.Error().Err(err).Msg("error retrieving org_id from token")
 		return err
 	}
 
-	log.Debug().Msgf("Checking internal rule permissions for Organization ID: %v", requestOrgID)
+	logger.Debug().Msgf("Checking internal rule permissions for Organization ID: %v", requestOrgID)
 	for _, allowedID := range server.Config.InternalRulesOrganizations {
 		if requestOrgID == allowedID {
-			log.Info().Msgf("Organization %v is allowed access to internal rules", requestOrgID)
+			logger.Info().Msgf("Organization %v is allowed access to internal rules", requestOrgID)
 			return nil
 		}
 	}
@@ -1339,7 +1525,7 @@ func filterRulesInResponse(aggregatorReport []ctypes.RuleOnReport, filterOSD, ge
 
 // Method readListOfClusterDisabledRules returns rules with a list of clusters for which the user had
 // disabled the rule (if any)
-func (server *HTTPServer) readListOfClusterDisabledRules(orgID types.OrgID) ([]ctypes.DisabledRule, error) {
+func (server *HTTPServer) readListOfClusterDisabledRules(ctx context.Context, orgID types.OrgID) ([]ctypes.DisabledRule, error) {
 	// wont be used anywhere else
 	var response struct {
 		Status        string                `json:"status"`
@@ -1353,7 +1539,7 @@ func (server *HTTPServer) readListOfClusterDisabledRules(orgID types.OrgID) ([]c
 	)
 
 	// #nosec G107
-	resp, err := http.Get(aggregatorURL)
+	resp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		return nil, err
 	}
@@ -1376,6 +1562,7 @@ func (server *HTTPServer) readListOfClusterDisabledRules(orgID types.OrgID) ([]c
 
 // Method readListOfClusterDisabledRules returns user disabled rules for given cluster list
 func (server *HTTPServer) readListOfDisabledRulesForClusters(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID ctypes.OrgID,
 	clusterList []ctypes.ClusterName,
@@ -1400,7 +1587,7 @@ func (server *HTTPServer) readListOfDisabledRulesForClusters(
 	}
 
 	// #nosec G107
-	resp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(jsonMarshalled))
+	resp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(jsonMarshalled))
 	if err != nil {
 		log.Error().Err(err).Msg("readListOfDisabledRulesForClusters problem getting response from aggregator")
 		if _, ok := err.(*url.Error); ok {
@@ -1436,6 +1623,7 @@ func (server *HTTPServer) readListOfDisabledRulesForClusters(
 // getClusterListAndUserData returns a list of clusters, rule hits for these clusters from
 // aggregator, as well as rule acknowledgements and user disabled rules
 func (server *HTTPServer) getClusterListAndUserData(
+	ctx context.Context,
 	writer http.ResponseWriter,
 	orgID types.OrgID,
 	userID types.UserID,
@@ -1447,7 +1635,7 @@ func (server *HTTPServer) getClusterListAndUserData(
 ) {
 	tStart := time.Now()
 	// get list of clusters from AMS API or aggregator
-	clusterInfoList, err := server.readClusterInfoForOrgID(orgID)
+	clusterInfoList, err := server.readClusterInfoForOrgID(ctx, orgID)
 	if err != nil {
 		log.Error().Err(err).Int(orgIDTag, int(orgID)).Msg(clusterListError)
 		handleServerError(writer, err)
@@ -1455,7 +1643,7 @@ func (server *HTTPServer) getClusterListAndUserData(
 	}
 	log.Info().Uint32(orgIDTag, uint32(orgID)).Msgf("time spent in AMS API %s", time.Since(tStart))
 
-	clusterRecommendationMap, err = server.getClustersAndRecommendations(writer, orgID, userID, types.GetClusterNames(clusterInfoList))
+	clusterRecommendationMap, err = server.getClustersAndRecommendations(ctx, writer, orgID, userID, types.GetClusterNames(clusterInfoList))
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -1467,14 +1655,14 @@ func (server *HTTPServer) getClusterListAndUserData(
 	}
 
 	// get a map of acknowledged rules
-	ackedRulesMap, err = server.getRuleAcksMap(orgID)
+	ackedRulesMap, err = server.getRuleAcksMap(ctx, orgID)
 	if err != nil {
 		handleServerError(writer, err)
 		return
 	}
 
 	// retrieve list of cluster IDs and single disabled rules for each cluster
-	disabledRulesPerCluster = server.getUserDisabledRulesPerCluster(orgID)
+	disabledRulesPerCluster = server.getUserDisabledRulesPerCluster(ctx, orgID)
 	log.Debug().Uint32(orgIDTag, uint32(orgID)).Msgf("time since getClusterListAndUserData start %s", time.Since(tStart))
 
 	return
@@ -1483,6 +1671,7 @@ func (server *HTTPServer) getClusterListAndUserData(
 // getWorkloadsForCluster returns []types.WorkloadsForCluster{} when no workloads were found for given cluster/namespace.
 // returns nil upon receiving an unexpected error from aggregator.
 func (server *HTTPServer) getWorkloadsForCluster(
+	ctx context.Context,
 	orgID types.OrgID,
 	clusterID types.ClusterName,
 	namespace types.Namespace,
@@ -1501,7 +1690,7 @@ func (server *HTTPServer) getWorkloadsForCluster(
 	)
 
 	// #nosec G107
-	resp, err := http.Get(aggregatorURL)
+	resp, err := aggregatorGet(ctx, aggregatorURL)
 	if err != nil {
 		return
 	}
@@ -1531,6 +1720,7 @@ func (server *HTTPServer) getWorkloadsForCluster(
 // Empty slice is returned when aggregator responds with 404 Not Found.
 // Nil is returned when any other unexpected error occurs.
 func (server *HTTPServer) getWorkloadsForOrganization(
+	ctx context.Context,
 	orgID types.OrgID, writer http.ResponseWriter, clusterInfo []types.ClusterInfo,
 ) ([]types.WorkloadsForNamespace, error) {
 	// wont be used anywhere else
@@ -1558,7 +1748,7 @@ func (server *HTTPServer) getWorkloadsForOrganization(
 	}
 
 	// #nosec G107
-	resp, err := http.Post(aggregatorURL, JSONContentType, bytes.NewBuffer(body))
+	resp, err := aggregatorPost(ctx, aggregatorURL, bytes.NewBuffer(body))
 	if err != nil {
 		if _, ok := err.(*url.Error); ok {
 			handleServerError(writer, &AggregatorServiceUnavailableError{})
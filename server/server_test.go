@@ -17,6 +17,7 @@ limitations under the License.
 package server_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -43,6 +44,9 @@ const (
 	testTimeout            = 10 * time.Second
 	internalTestRuleModule = "ccx_ocp_rules.internal.bar"
 	internalRuleID         = internalTestRuleModule + "|" + testdata.ErrorKey1
+
+	// testRequestID is sent by tests that check bodies of error responses
+	testRequestID = "test-request-id"
 )
 
 // TODO: consider moving to data repo
@@ -56,8 +60,11 @@ var (
 	// anemicXRHAuthToken has correct structure, but is missing account_number
 	anemicXRHAuthToken  = `eyJpZGVudGl0eSI6eyJvcmdfaWQiOiIxIiwidHlwZSI6IlVzZXIiLCJ1c2VyIjp7InVzZXJuYW1lIjoiamRvZSIsInVzZXJfaWQiOiIxIiwiZW1haWwiOiJqZG9lQGFjbWUuY29tIiwiZmlyc3RfbmFtZSI6IkpvaG4iLCJsYXN0X25hbWUiOiJEb2UiLCJpc19hY3RpdmUiOnRydWUsImlzX29yZ19hZG1pbiI6ZmFsc2UsImlzX2ludGVybmFsIjpmYWxzZSwibG9jYWxlIjoiZW5fVVMifSwiaW50ZXJuYWwiOnsib3JnX2lkIjoiMSIsImF1dGhfdHlwZSI6ImJhc2ljLWF1dGgiLCJhdXRoX3RpbWUiOjYzMDB9fX0K`
 	invalidXRHAuthToken = `invalid token`
-	testTimeStr         = "2021-01-02T15:04:05Z"
-	testTimestamp       = types.Timestamp(testTimeStr)
+	// requestIDHeader contains fixed X-Request-ID, so the ID included in
+	// error responses is known
+	requestIDHeader = http.Header{"X-Request-ID": []string{testRequestID}}
+	testTimeStr     = "2021-01-02T15:04:05Z"
+	testTimestamp   = types.Timestamp(testTimeStr)
 
 	serverConfigXRH = server.Configuration{
 		Address:                          ":8081",
@@ -606,9 +613,11 @@ var (
 
 	GetRecommendationsResponse1Rule2Cluster = struct {
 		Status          string                         `json:"status"`
+		Meta            types.ListMeta                 `json:"meta"`
 		Recommendations []types.RecommendationListView `json:"recommendations"`
 	}{
 		Status: "ok",
+		Meta:   types.ListMeta{Count: 1, Total: 1},
 		Recommendations: []types.RecommendationListView{
 			{
 				RuleID:              testdata.Rule1CompositeID,
@@ -816,9 +825,11 @@ var (
 
 	GetRecommendationsResponse0Rules = struct {
 		Status          string                         `json:"status"`
+		Meta            types.ListMeta                 `json:"meta"`
 		Recommendations []types.RecommendationListView `json:"recommendations"`
 	}{
 		Status:          "ok",
+		Meta:            types.ListMeta{},
 		Recommendations: []types.RecommendationListView{},
 	}
 
@@ -928,7 +939,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 0,
+			"count":    0,
+			"total":    0,
+			"filtered": 0,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status:   "ok",
 		Clusters: []types.ClusterListView{},
@@ -941,7 +956,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -969,7 +988,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -995,7 +1018,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -1030,7 +1057,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -1064,7 +1095,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -1100,7 +1135,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -1135,7 +1174,11 @@ var (
 		Clusters []types.ClusterListView `json:"data"`
 	}{
 		Meta: map[string]interface{}{
-			"count": 2,
+			"count":    2,
+			"total":    2,
+			"filtered": 2,
+			"limit":    0,
+			"offset":   0,
 		},
 		Status: "ok",
 		Clusters: []types.ClusterListView{
@@ -1192,9 +1235,11 @@ var (
 	}
 
 	ReportMetainfoAPIResponseInvalidJSON = struct {
-		Status string `json:"status"`
+		Status    string `json:"status"`
+		RequestID string `json:"request_id"`
 	}{
-		Status: "invalid character 'T' looking for beginning of value",
+		Status:    "invalid character 'T' looking for beginning of value",
+		RequestID: testRequestID,
 	}
 
 	ReportMetainfoAPIResponseInvalidClusterName = struct {
@@ -1261,6 +1306,70 @@ func TestServerStartError(t *testing.T) {
 	assert.EqualError(t, err, "listen tcp: address 99999: invalid port")
 }
 
+// TestStartAfterStop checks that the server is not started when it has
+// been stopped before the goroutine running Start got to it
+func TestStartAfterStop(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.Address = "localhost:0"
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+
+	helpers.FailOnError(t, testServer.Stop(context.Background()))
+	assert.NoError(t, testServer.Start())
+}
+
+// TestStopRunningServer checks that the server started in another goroutine
+// can be stopped at any time
+func TestStopRunningServer(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(tt testing.TB) {
+		config := helpers.DefaultServerConfig
+		config.Address = "localhost:0"
+		testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+
+		started := make(chan error)
+		go func() {
+			started <- testServer.Start()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		helpers.FailOnError(tt, testServer.Stop(context.Background()))
+		assert.NoError(tt, <-started)
+	}, testTimeout)
+}
+
+// TestMainEndpointDuringShutdown checks that the main endpoint reports the
+// service as unavailable once the server starts draining requests
+func TestMainEndpointDuringShutdown(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.Auth = false
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+	assert.False(t, testServer.IsShuttingDown())
+
+	iou_helpers.AssertAPIRequest(t, testServer, config.APIv1Prefix, &helpers.APIRequest{
+		Method:   http.MethodGet,
+		Endpoint: server.MainEndpoint,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+	})
+
+	testServer.MarkShuttingDown()
+	assert.True(t, testServer.IsShuttingDown())
+
+	iou_helpers.AssertAPIRequest(t, testServer, config.APIv2Prefix, &helpers.APIRequest{
+		Method:   http.MethodGet,
+		Endpoint: server.MainEndpoint,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusServiceUnavailable,
+	})
+}
+
+// TestServerStopNotStarted checks that stopping server that was not started
+// yet does not fail
+func TestServerStopNotStarted(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, testServer.Stop(context.Background()))
+	assert.True(t, testServer.IsShuttingDown())
+}
+
 func TestAddCORSHeaders(t *testing.T) {
 	helpers.AssertAPIRequest(t, &helpers.DefaultServerConfigCORS, &helpers.DefaultServicesConfig, nil, nil, nil, &helpers.APIRequest{
 		Method:   http.MethodOptions,
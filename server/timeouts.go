@@ -0,0 +1,115 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/netutil"
+)
+
+// default values used when the timeouts are not configured
+const (
+	defaultReadTimeout       = 1 * time.Minute
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+)
+
+// durationOrDefault function returns the configured duration or the default
+// value when the duration is not configured
+func durationOrDefault(configured, defaultValue time.Duration) time.Duration {
+	if configured <= 0 {
+		return defaultValue
+	}
+	return configured
+}
+
+// newHTTPServer method constructs http.Server with timeouts and limits taken
+// from the server configuration
+func (server *HTTPServer) newHTTPServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              server.Config.Address,
+		Handler:           handler,
+		ReadTimeout:       durationOrDefault(server.Config.ReadTimeout, defaultReadTimeout),
+		ReadHeaderTimeout: durationOrDefault(server.Config.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		WriteTimeout:      durationOrDefault(server.Config.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       server.Config.IdleTimeout,
+		MaxHeaderBytes:    server.Config.MaxHeaderBytes,
+	}
+}
+
+// listen method opens the listener for HTTP server. Number of simultaneously
+// accepted connections is limited when MaxConnections is configured.
+func (server *HTTPServer) listen() (net.Listener, error) {
+	address := server.Config.Address
+	if address == "" {
+		address = ":http"
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if server.Config.MaxConnections > 0 {
+		log.Info().Int("maxConnections", server.Config.MaxConnections).Msg("Limiting number of concurrent connections")
+		listener = netutil.LimitListener(listener, server.Config.MaxConnections)
+	}
+
+	return listener, nil
+}
+
+// trimAPIPrefix method removes API prefix from the endpoint
+func (server *HTTPServer) trimAPIPrefix(endpoint string) string {
+	endpoint = strings.TrimPrefix(endpoint, server.Config.APIv1Prefix)
+	endpoint = strings.TrimPrefix(endpoint, server.Config.APIv2Prefix)
+	return strings.TrimPrefix(endpoint, server.Config.APIdbgPrefix)
+}
+
+// routeWriteTimeout method returns write timeout configured for given
+// endpoint template. Second return value is false if there is no override
+// for the endpoint.
+func (server *HTTPServer) routeWriteTimeout(endpoint string) (time.Duration, bool) {
+	if len(server.Config.RouteWriteTimeouts) == 0 {
+		return 0, false
+	}
+
+	timeout, found := server.Config.RouteWriteTimeouts[server.trimAPIPrefix(endpoint)]
+	if !found || timeout <= 0 {
+		return 0, false
+	}
+	return timeout, true
+}
+
+// RouteWriteTimeoutMiddleware method overrides the write deadline of the
+// connection for endpoints that are known to be slow, for example those
+// aggregating data for large organizations.
+func (server *HTTPServer) RouteWriteTimeoutMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		timeout, found := server.routeWriteTimeout(getEndpointFromRequest(request))
+		if found {
+			err := http.NewResponseController(writer).SetWriteDeadline(time.Now().Add(timeout))
+			if err != nil {
+				log.Warn().Err(err).Msg("Unable to set write deadline for the request")
+			}
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
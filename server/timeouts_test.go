@@ -0,0 +1,126 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// TestNewHTTPServerDefaultTimeouts checks that default timeouts are used
+// when they are not configured
+func TestNewHTTPServerDefaultTimeouts(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(nil, nil, nil, nil, nil, nil, nil, nil)
+	httpServer := server.NewHTTPServer(testServer, http.NotFoundHandler())
+
+	assert.Equal(t, helpers.DefaultServerConfig.Address, httpServer.Addr)
+	assert.Equal(t, 1*time.Minute, httpServer.ReadTimeout)
+	assert.Equal(t, 5*time.Second, httpServer.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, httpServer.WriteTimeout)
+	assert.Equal(t, time.Duration(0), httpServer.IdleTimeout)
+	assert.Equal(t, 0, httpServer.MaxHeaderBytes)
+}
+
+// TestNewHTTPServerConfiguredTimeouts checks that configured timeouts and
+// limits are used
+func TestNewHTTPServerConfiguredTimeouts(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.ReadTimeout = 2 * time.Minute
+	config.ReadHeaderTimeout = 10 * time.Second
+	config.WriteTimeout = 45 * time.Second
+	config.IdleTimeout = 3 * time.Minute
+	config.MaxHeaderBytes = 8192
+
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+	httpServer := server.NewHTTPServer(testServer, http.NotFoundHandler())
+
+	assert.Equal(t, 2*time.Minute, httpServer.ReadTimeout)
+	assert.Equal(t, 10*time.Second, httpServer.ReadHeaderTimeout)
+	assert.Equal(t, 45*time.Second, httpServer.WriteTimeout)
+	assert.Equal(t, 3*time.Minute, httpServer.IdleTimeout)
+	assert.Equal(t, 8192, httpServer.MaxHeaderBytes)
+}
+
+// TestRouteWriteTimeout checks lookup of per-route write timeouts
+func TestRouteWriteTimeout(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.RouteWriteTimeouts = map[string]time.Duration{
+		server.ClustersRecommendationsEndpoint: 2 * time.Minute,
+		server.DVONamespaceListEndpoint:        3 * time.Minute,
+	}
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+
+	timeout, found := server.RouteWriteTimeout(testServer, config.APIv2Prefix+server.ClustersRecommendationsEndpoint)
+	assert.True(t, found)
+	assert.Equal(t, 2*time.Minute, timeout)
+
+	timeout, found = server.RouteWriteTimeout(testServer, config.APIv2Prefix+server.DVONamespaceListEndpoint)
+	assert.True(t, found)
+	assert.Equal(t, 3*time.Minute, timeout)
+
+	_, found = server.RouteWriteTimeout(testServer, config.APIv2Prefix+server.RuleGroupsEndpoint)
+	assert.False(t, found)
+}
+
+// TestRouteWriteTimeoutMiddleware checks that slow endpoint with write
+// timeout override is able to send the response, while other endpoints are
+// cut off by the server write timeout
+func TestRouteWriteTimeoutMiddleware(t *testing.T) {
+	config := helpers.DefaultServerConfig
+	config.RouteWriteTimeouts = map[string]time.Duration{
+		server.ClustersRecommendationsEndpoint: 5 * time.Second,
+	}
+	testServer := helpers.CreateHTTPServer(&config, nil, nil, nil, nil, nil, nil, nil)
+
+	slowHandler := func(writer http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = writer.Write([]byte("done"))
+	}
+
+	router := mux.NewRouter()
+	router.Use(testServer.RouteWriteTimeoutMiddleware)
+	router.HandleFunc(config.APIv2Prefix+server.ClustersRecommendationsEndpoint, slowHandler)
+	router.HandleFunc(config.APIv2Prefix+server.RuleGroupsEndpoint, slowHandler)
+
+	httpServer := httptest.NewUnstartedServer(router)
+	httpServer.Config.WriteTimeout = 50 * time.Millisecond
+	httpServer.Start()
+	defer httpServer.Close()
+
+	// #nosec G107
+	resp, err := http.Get(httpServer.URL + config.APIv2Prefix + server.ClustersRecommendationsEndpoint)
+	helpers.FailOnError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	helpers.FailOnError(t, err)
+	helpers.FailOnError(t, resp.Body.Close())
+	assert.Equal(t, "done", string(body))
+
+	// #nosec G107
+	resp, err = http.Get(httpServer.URL + config.APIv2Prefix + server.RuleGroupsEndpoint)
+	if err == nil {
+		_, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultTLSCertFile is used when no certificate file is configured
+	defaultTLSCertFile = "server.crt"
+	// defaultTLSKeyFile is used when no private key file is configured
+	defaultTLSKeyFile = "server.key"
+)
+
+// tlsVersions maps TLS versions that can be used in configuration to
+// constants used by crypto/tls package
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// certificateReloader keeps the server certificate loaded from the files and
+// reloads it when any of the files is changed, so rotated certificates are
+// used without restarting the service
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// newCertificateReloader function loads the certificate and private key from
+// the given files
+func newCertificateReloader(certFile, keyFile string) (*certificateReloader, error) {
+	reloader := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	certModTime, keyModTime, err := reloader.modTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reloader.load(certModTime, keyModTime); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// modTimes method returns modification times of the certificate and key files
+func (reloader *certificateReloader) modTimes() (certModTime, keyModTime time.Time, err error) {
+	certInfo, err := os.Stat(reloader.certFile)
+	if err != nil {
+		return
+	}
+	keyInfo, err := os.Stat(reloader.keyFile)
+	if err != nil {
+		return
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// load method reads the certificate and key from the files
+func (reloader *certificateReloader) load(certModTime, keyModTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(reloader.certFile, reloader.keyFile)
+	if err != nil {
+		return err
+	}
+
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	reloader.certificate = &certificate
+	reloader.certModTime = certModTime
+	reloader.keyModTime = keyModTime
+
+	return nil
+}
+
+// GetCertificate method is used as tls.Config.GetCertificate callback. The
+// certificate is reloaded when the files were changed since the last load.
+// When the new certificate can't be loaded (for example when only one of
+// the files has been rotated yet) the previous one is used.
+func (reloader *certificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certModTime, keyModTime, err := reloader.modTimes()
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to check TLS certificate files, using the loaded certificate")
+		return reloader.current(), nil
+	}
+
+	reloader.mutex.RLock()
+	changed := !certModTime.Equal(reloader.certModTime) || !keyModTime.Equal(reloader.keyModTime)
+	reloader.mutex.RUnlock()
+
+	if changed {
+		if err := reloader.load(certModTime, keyModTime); err != nil {
+			log.Error().Err(err).Msg("Unable to reload TLS certificate, using the loaded certificate")
+		} else {
+			log.Info().Str("certFile", reloader.certFile).Msg("TLS certificate reloaded")
+		}
+	}
+
+	return reloader.current(), nil
+}
+
+// current method returns the certificate that is currently loaded
+func (reloader *certificateReloader) current() *tls.Certificate {
+	reloader.mutex.RLock()
+	defer reloader.mutex.RUnlock()
+
+	return reloader.certificate
+}
+
+// newTLSConfig function constructs TLS configuration for HTTPS server from
+// the server configuration
+func newTLSConfig(config *Configuration) (*tls.Config, error) {
+	certFile := config.TLSCertFile
+	if certFile == "" {
+		certFile = defaultTLSCertFile
+	}
+	keyFile := config.TLSKeyFile
+	if keyFile == "" {
+		keyFile = defaultTLSKeyFile
+	}
+
+	reloader, err := newCertificateReloader(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TLS certificate: %w", err)
+	}
+
+	var minVersion uint16 = tls.VersionTLS12
+	if config.TLSMinVersion != "" {
+		version, found := tlsVersions[config.TLSMinVersion]
+		if !found {
+			return nil, fmt.Errorf("unsupported minimum TLS version '%s'", config.TLSMinVersion)
+		}
+		minVersion = version
+	}
+
+	// #nosec G402
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if len(config.TLSCipherSuites) > 0 {
+		tlsConfig.CipherSuites, err = cipherSuitesFromNames(config.TLSCipherSuites)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.TLSClientCAFile != "" {
+		pemData, err := os.ReadFile(filepath.Clean(config.TLSClientCAFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client CA file: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pemData) {
+			return nil, errors.New("no certificate found in client CA file")
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// cipherSuitesFromNames function converts names of cipher suites to their
+// IDs. Only cipher suites considered secure by crypto/tls can be used.
+func cipherSuitesFromNames(names []string) ([]uint16, error) {
+	available := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		available[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, found := available[name]
+		if !found {
+			return nil, fmt.Errorf("unsupported TLS cipher suite '%s'", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
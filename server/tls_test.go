@@ -0,0 +1,172 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// writeSelfSignedCertificate generates new self-signed certificate and
+// stores it together with its private key into the given files. The DER
+// encoded certificate is returned.
+func writeSelfSignedCertificate(t *testing.T, certFile, keyFile string, serial int64) []byte {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	helpers.FailOnError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	helpers.FailOnError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	helpers.FailOnError(t, err)
+
+	helpers.FailOnError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	helpers.FailOnError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return der
+}
+
+// tlsTestConfig prepares certificate files and returns server configuration
+// that uses them
+func tlsTestConfig(t *testing.T) (server.Configuration, []byte) {
+	dir := t.TempDir()
+	config := helpers.DefaultServerConfig
+	config.UseHTTPS = true
+	config.TLSCertFile = filepath.Join(dir, "server.crt")
+	config.TLSKeyFile = filepath.Join(dir, "server.key")
+
+	der := writeSelfSignedCertificate(t, config.TLSCertFile, config.TLSKeyFile, 1)
+	return config, der
+}
+
+// TestNewTLSConfigDefaults checks TLS configuration with default settings
+func TestNewTLSConfigDefaults(t *testing.T) {
+	config, der := tlsTestConfig(t)
+
+	tlsConfig, err := server.NewTLSConfig(&config)
+	helpers.FailOnError(t, err)
+
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	assert.Nil(t, tlsConfig.CipherSuites)
+
+	certificate, err := tlsConfig.GetCertificate(nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, der, certificate.Certificate[0])
+}
+
+// TestNewTLSConfigClientCA checks that client certificates are required when
+// client CA is configured
+func TestNewTLSConfigClientCA(t *testing.T) {
+	config, _ := tlsTestConfig(t)
+	config.TLSClientCAFile = config.TLSCertFile
+	config.TLSMinVersion = "1.3"
+	config.TLSCipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}
+
+	tlsConfig, err := server.NewTLSConfig(&config)
+	helpers.FailOnError(t, err)
+
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	assert.NotNil(t, tlsConfig.ClientCAs)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, tlsConfig.CipherSuites)
+}
+
+// TestNewTLSConfigErrors checks handling of improper TLS settings
+func TestNewTLSConfigErrors(t *testing.T) {
+	t.Run("missing certificate", func(t *testing.T) {
+		config, _ := tlsTestConfig(t)
+		config.TLSCertFile = filepath.Join(t.TempDir(), "missing.crt")
+		_, err := server.NewTLSConfig(&config)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported TLS version", func(t *testing.T) {
+		config, _ := tlsTestConfig(t)
+		config.TLSMinVersion = "1.0"
+		_, err := server.NewTLSConfig(&config)
+		assert.EqualError(t, err, "unsupported minimum TLS version '1.0'")
+	})
+
+	t.Run("unsupported cipher suite", func(t *testing.T) {
+		config, _ := tlsTestConfig(t)
+		config.TLSCipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"}
+		_, err := server.NewTLSConfig(&config)
+		assert.EqualError(t, err, "unsupported TLS cipher suite 'TLS_RSA_WITH_RC4_128_SHA'")
+	})
+
+	t.Run("client CA without certificates", func(t *testing.T) {
+		config, _ := tlsTestConfig(t)
+		config.TLSClientCAFile = filepath.Join(t.TempDir(), "ca.crt")
+		helpers.FailOnError(t, os.WriteFile(config.TLSClientCAFile, []byte("not a certificate"), 0600))
+		_, err := server.NewTLSConfig(&config)
+		assert.EqualError(t, err, "no certificate found in client CA file")
+	})
+}
+
+// TestTLSCertificateReload checks that rotated certificate is used without
+// creating new TLS configuration
+func TestTLSCertificateReload(t *testing.T) {
+	config, der := tlsTestConfig(t)
+
+	tlsConfig, err := server.NewTLSConfig(&config)
+	helpers.FailOnError(t, err)
+
+	certificate, err := tlsConfig.GetCertificate(nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, der, certificate.Certificate[0])
+
+	rotatedDer := writeSelfSignedCertificate(t, config.TLSCertFile, config.TLSKeyFile, 2)
+	// make sure modification time is changed even on file systems with
+	// coarse timestamps
+	rotated := time.Now().Add(time.Minute)
+	helpers.FailOnError(t, os.Chtimes(config.TLSCertFile, rotated, rotated))
+	helpers.FailOnError(t, os.Chtimes(config.TLSKeyFile, rotated, rotated))
+
+	certificate, err = tlsConfig.GetCertificate(nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, rotatedDer, certificate.Certificate[0])
+
+	// broken files must not replace the valid certificate
+	helpers.FailOnError(t, os.WriteFile(config.TLSKeyFile, []byte("broken"), 0600))
+	certificate, err = tlsConfig.GetCertificate(nil)
+	helpers.FailOnError(t, err)
+	assert.Equal(t, rotatedDer, certificate.Certificate[0])
+}
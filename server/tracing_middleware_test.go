@@ -0,0 +1,132 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+)
+
+// TestTracingProxiedRequest checks that server span is created for incoming
+// request, client span for the call to aggregator, and that the trace
+// context is propagated to aggregator
+func TestTracingProxiedRequest(t *testing.T) {
+	_, err := tracing.Init(tracing.Configuration{})
+	helpers.FailOnError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	originalProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewTracerProvider(tracing.Configuration{}, sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(originalProvider)
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+		assert.Nil(t, loadMockRuleContentDir(&testdata.RuleContentDirectory3Rules))
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     ira_server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1, testdata.OrgID, testdata.UserID},
+			ExtraHeaders: http.Header{"traceparent": []string{"^00-[0-9a-f]{32}-[0-9a-f]{16}-01$"}},
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:       http.MethodPut,
+			Endpoint:     server.LikeRuleEndpoint,
+			EndpointArgs: []interface{}{testdata.ClusterName, testdata.Rule1ID, testdata.ErrorKey1},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"status": "ok"}`,
+		})
+	}, testTimeout)
+
+	var serverSpan, clientSpan tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		switch span.SpanKind {
+		case trace.SpanKindServer:
+			serverSpan = span
+		case trace.SpanKindClient:
+			clientSpan = span
+		}
+	}
+
+	assert.Equal(t, "PUT "+helpers.DefaultServerConfig.APIv1Prefix+server.LikeRuleEndpoint, serverSpan.Name)
+	assert.Contains(t, serverSpan.Attributes, tracing.OrgID(testdata.OrgID))
+
+	assert.Equal(t, "HTTP PUT", clientSpan.Name)
+	assert.Equal(t, serverSpan.SpanContext.TraceID(), clientSpan.SpanContext.TraceID())
+	assert.Equal(t, serverSpan.SpanContext.SpanID(), clientSpan.Parent.SpanID())
+}
+
+// TestTracingRedisCall checks that calls to Redis made while handling the
+// request are traced as children of the server span
+func TestTracingRedisCall(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	originalProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewTracerProvider(tracing.Configuration{}, sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(originalProvider)
+
+	redisClient, redisServer := helpers.GetMockRedis()
+	redisServer.ExpectExists(fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName, "requestID1")).
+		SetVal(1)
+
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, &redisClient, nil, nil, nil, nil)
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodGet,
+		Endpoint:     server.StatusOfRequestID,
+		EndpointArgs: []interface{}{testdata.ClusterName, "requestID1"},
+		XRHIdentity:  goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       `{"cluster":"` + string(testdata.ClusterName) + `","requestID":"requestID1","status":"processed"}`,
+	})
+	helpers.RedisExpectationsMet(t, redisServer)
+
+	var serverSpan, redisSpan tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		switch span.Name {
+		case "redis.GetRequestStatus":
+			redisSpan = span
+		default:
+			if span.SpanKind == trace.SpanKindServer {
+				serverSpan = span
+			}
+		}
+	}
+
+	assert.Contains(t, redisSpan.Attributes, tracing.OrgID(testdata.OrgID))
+	assert.Equal(t, serverSpan.SpanContext.TraceID(), redisSpan.SpanContext.TraceID())
+	assert.Equal(t, serverSpan.SpanContext.SpanID(), redisSpan.Parent.SpanID())
+}
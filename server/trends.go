@@ -0,0 +1,150 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	"github.com/rs/zerolog"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// FromParam is the beginning of the time range, the first day (in
+	// YYYY-MM-DD format) of the trends or the first timestamp (in RFC 3339
+	// format) of the list of requests
+	FromParam = "from"
+	// ToParam is the end of the time range, the last day of the trends or
+	// the last timestamp of the list of requests
+	ToParam = "to"
+
+	// defaultTrendsPeriod is used when the first day of trends is not
+	// specified
+	defaultTrendsPeriod = 30 * 24 * time.Hour
+)
+
+// SetTrendsStore method sets the store used to record snapshots of
+// organization overviews
+func (server *HTTPServer) SetTrendsStore(store trends.Store) {
+	server.trendsStore = store
+}
+
+// recordTrendsSnapshot method stores the snapshot of the organization
+// overview for today. The overview has been computed already, so failures are
+// only logged.
+func (server *HTTPServer) recordTrendsSnapshot(
+	logger *zerolog.Logger,
+	orgID types.OrgID,
+	overview *types.OrgOverviewResponse,
+	hitsByRule map[types.RuleID]int,
+) {
+	if server.trendsStore == nil {
+		return
+	}
+
+	snapshot := trends.Snapshot{
+		Date:            trends.Date(time.Now()),
+		ClustersHit:     overview.ClustersHit,
+		HitsByTotalRisk: overview.ClustersHitByTotalRisk,
+		HitsByRule:      hitsByRule,
+	}
+	if err := server.trendsStore.Record(orgID, &snapshot); err != nil {
+		logger.Error().Err(err).Msg("Unable to record trends snapshot")
+	}
+}
+
+// readDateParam function reads date in YYYY-MM-DD format from query
+func readDateParam(name string, defaultValue time.Time, request *http.Request) (time.Time, error) {
+	value := request.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	date, err := time.Parse(trends.DateFormat, value)
+	if err != nil {
+		return time.Time{}, &RouterParsingError{
+			ParamName:  name,
+			ParamValue: value,
+			ErrString:  "date in YYYY-MM-DD format expected",
+		}
+	}
+	return date, nil
+}
+
+// readTrendsRange function reads first and last day of trends from query.
+// Last 30 days are returned by default.
+func readTrendsRange(request *http.Request) (from, to time.Time, err error) {
+	to, err = readDateParam(ToParam, time.Now().UTC(), request)
+	if err != nil {
+		return
+	}
+	from, err = readDateParam(FromParam, to.Add(-defaultTrendsPeriod), request)
+	if err != nil {
+		return
+	}
+
+	if from.After(to) {
+		err = &RouterParsingError{
+			ParamName:  FromParam,
+			ParamValue: request.URL.Query().Get(FromParam),
+			ErrString:  "first day must not be after the last day",
+		}
+	}
+	return
+}
+
+// getTrends method returns daily snapshots of the organization overview
+func (server *HTTPServer) getTrends(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	if server.trendsStore == nil {
+		if err := responses.SendNotFound(writer, "Recording of trends is disabled"); err != nil {
+			logger.Error().Err(err).Msg(responseDataError)
+		}
+		return
+	}
+
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg(orgIDTokenError)
+		handleServerError(writer, err)
+		return
+	}
+
+	from, to, err := readTrendsRange(request)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	snapshots, err := server.trendsStore.List(orgID, from, to)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read trends snapshots")
+		handleServerError(writer, err)
+		return
+	}
+
+	// snapshots are recorded only when the overview is requested, so days
+	// without snapshot are marked explicitly
+	response := responses.BuildOkResponseWithData("trends", snapshots)
+	response["missing_dates"] = trends.MissingDates(snapshots)
+
+	if err = responses.SendOK(writer, response); err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
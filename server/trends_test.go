@@ -0,0 +1,184 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// trendsResponse represents body returned by trends endpoint
+type trendsResponse struct {
+	Status       string            `json:"status"`
+	Trends       []trends.Snapshot `json:"trends"`
+	MissingDates []string          `json:"missing_dates"`
+}
+
+// trendsChecker returns body checker that compares returned snapshots
+func trendsChecker(expected []trends.Snapshot) iou_helpers.BodyChecker {
+	return func(t testing.TB, _, got []byte) {
+		var resp trendsResponse
+		helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+		assert.Equal(t, "ok", resp.Status)
+		assert.Equal(t, expected, resp.Trends)
+		assert.Equal(t, trends.MissingDates(expected), resp.MissingDates)
+	}
+}
+
+// TestTrendsRecordedByOverview checks that snapshot is recorded when the
+// overview of the organization is computed and returned by trends endpoint
+func TestTrendsRecordedByOverview(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{
+				testdata.RuleContent1,
+				testdata.RuleContent2,
+				testdata.RuleContent3,
+			},
+		),
+	)
+	assert.Nil(t, err)
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		clusterInfoList := []types.ClusterInfo{data.GetRandomClusterInfo(), data.GetRandomClusterInfo()}
+		reqBody, _ := json.Marshal(types.GetClusterNames(clusterInfoList))
+		respBody := fmt.Sprintf(`{"clusters":{"%v":{"created_at":"%v","recommendations":["%v","%v","%v"]}}}`,
+			clusterInfoList[0].ID, testTimeStr, testdata.Rule1CompositeID,
+			testdata.Rule2CompositeID, testdata.Rule3CompositeID,
+		)
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+			&helpers.APIRequest{
+				Method:       http.MethodPost,
+				Endpoint:     ira_server.ClustersRecommendationsListEndpoint,
+				EndpointArgs: []interface{}{testdata.OrgID, userIDInGoodAuthToken},
+				Body:         reqBody,
+			},
+			&helpers.APIResponse{
+				StatusCode: http.StatusOK,
+				Body:       respBody,
+			},
+		)
+		expectNoRulesDisabledSystemWide(&t, testdata.OrgID)
+		expectNoRulesDisabledPerCluster(&t, testdata.OrgID)
+
+		amsClientMock := helpers.AMSClientWithOrgResults(testdata.OrgID, clusterInfoList)
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
+		testServer.SetTrendsStore(trends.NewMemoryStore(time.Hour))
+
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv1Prefix, &helpers.APIRequest{
+			Method:      http.MethodGet,
+			Endpoint:    server.OverviewEndpoint,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       helpers.ToJSONString(OverviewResponseRules123Enabled),
+		})
+
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodGet,
+			Endpoint:    server.TrendsEndpoint,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       "",
+			BodyChecker: trendsChecker([]trends.Snapshot{{
+				Date:            trends.Date(time.Now()),
+				ClustersHit:     1,
+				HitsByTotalRisk: map[int]int{1: 1, 2: 2},
+				HitsByRule: map[types.RuleID]int{
+					testdata.Rule1CompositeID: 1,
+					testdata.Rule2CompositeID: 1,
+					testdata.Rule3CompositeID: 1,
+				},
+			}}),
+		})
+	}, testTimeout)
+}
+
+// TestTrendsEndpointRange checks that snapshots are filtered by the range
+// given in query
+func TestTrendsEndpointRange(t *testing.T) {
+	store := trends.NewMemoryStore(24 * time.Hour * 365 * 10)
+	snapshots := []trends.Snapshot{
+		{Date: "2026-01-01", ClustersHit: 1},
+		{Date: "2026-01-15", ClustersHit: 2},
+		{Date: "2026-02-01", ClustersHit: 3},
+	}
+	for i := range snapshots {
+		helpers.FailOnError(t, store.Record(testdata.OrgID, &snapshots[i]))
+	}
+
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetTrendsStore(store)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.TrendsEndpoint + "?from=2026-01-02&to=2026-02-01",
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode:  http.StatusOK,
+		Body:        "",
+		BodyChecker: trendsChecker(snapshots[1:]),
+	})
+}
+
+// TestTrendsEndpointBadParams checks handling of improper dates
+func TestTrendsEndpointBadParams(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetTrendsStore(trends.NewMemoryStore(time.Hour))
+
+	for _, query := range []string{"?from=yesterday", "?to=2026-13-01", "?from=2026-02-01&to=2026-01-01"} {
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodGet,
+			Endpoint:    server.TrendsEndpoint + query,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusBadRequest,
+		})
+	}
+}
+
+// TestTrendsEndpointDisabled checks that trends endpoint is not available
+// when recording of snapshots is disabled
+func TestTrendsEndpointDisabled(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.TrendsEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusNotFound,
+	})
+}
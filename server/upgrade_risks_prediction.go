@@ -16,16 +16,17 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
-	"time"
 
 	ctypes "github.com/RedHatInsights/insights-results-types"
 
 	httputils "github.com/RedHatInsights/insights-operator-utils/http"
 	"github.com/RedHatInsights/insights-operator-utils/responses"
 	utypes "github.com/RedHatInsights/insights-operator-utils/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
 
@@ -65,8 +66,9 @@ const (
 //			}
 //		}
 func (server *HTTPServer) upgradeRisksPrediction(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	if server.amsClient == nil {
-		log.Error().Msg(AMSApiNotInitializedErrorMessage)
+		logger.Error().Msg(AMSApiNotInitializedErrorMessage)
 		handleServerError(writer, &AMSAPIUnavailableError{})
 		return
 	}
@@ -86,17 +88,17 @@ func (server *HTTPServer) upgradeRisksPrediction(writer http.ResponseWriter, req
 	clusterInfo, err := server.amsClient.GetSingleClusterInfoForOrganization(orgID, clusterID)
 
 	if err != nil {
-		log.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("failure retrieving the cluster's organization")
+		logger.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("failure retrieving the cluster's organization")
 		handleServerError(writer, err)
 		return
 	} else if clusterInfo.ID != clusterID {
-		log.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("cluster doesn't belong to the expected org")
+		logger.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("cluster doesn't belong to the expected org")
 		handleServerError(writer, &utypes.ItemNotFoundError{ItemID: clusterID})
 		return
 	}
 
 	if clusterInfo.Managed {
-		log.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("cluster is managed")
+		logger.Warn().Err(err).Str(clusterIDTag, string(clusterID)).Msg("cluster is managed")
 		handleServerError(writer, &utypes.NoContentError{
 			ErrString: "the upgrade failure prediction service is not available for managed clusters",
 		})
@@ -104,7 +106,7 @@ func (server *HTTPServer) upgradeRisksPrediction(writer http.ResponseWriter, req
 	}
 
 	// Request to Data Engineering Service to retrieve the result
-	predictionResponse, err := server.fetchUpgradePrediction(clusterID, writer)
+	predictionResponse, err := server.fetchUpgradePrediction(request.Context(), clusterID, writer)
 	if err != nil || predictionResponse == nil {
 		// Error already handled or not OK status, already returned
 		return
@@ -126,7 +128,7 @@ func (server *HTTPServer) upgradeRisksPrediction(writer http.ResponseWriter, req
 		response,
 	)
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
@@ -134,6 +136,7 @@ func (server *HTTPServer) upgradeRisksPrediction(writer http.ResponseWriter, req
 // Each prediction will have a result (true or false) and a list of the alerts and operator conditions
 // that were taken into account for non-recommended upgrades.
 func (server *HTTPServer) upgradeRisksPredictionMultiCluster(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
 	if request.ContentLength <= 0 {
 		handleServerError(writer, &NoBodyError{})
 		return
@@ -154,7 +157,7 @@ func (server *HTTPServer) upgradeRisksPredictionMultiCluster(writer http.Respons
 	}
 
 	// Request to Data Engineering Service to retrieve the result
-	predictionResponse, err := server.fetchMulticlusterUpgradePrediction(clusterList, writer)
+	predictionResponse, err := server.fetchMulticlusterUpgradePrediction(request.Context(), clusterList, writer)
 	if err != nil || predictionResponse == nil {
 		// Error already handled or not OK status, already returned
 		return
@@ -172,11 +175,28 @@ func (server *HTTPServer) upgradeRisksPredictionMultiCluster(writer http.Respons
 		response,
 	)
 	if err != nil {
-		log.Error().Err(err).Msg(responseDataError)
+		logger.Error().Err(err).Msg(responseDataError)
 	}
 }
 
+// upgradeRisksPredictionRequest function sends the request to the upgrade
+// risks prediction service (data-eng) within the context of the incoming
+// request, so the request ID and the trace context are propagated
+func upgradeRisksPredictionRequest(
+	ctx context.Context, method, dataEngURL string, body io.Reader,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, dataEngURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != http.NoBody {
+		req.Header.Set(contentTypeHeader, JSONContentType)
+	}
+	return httpclient.Upstream(httpclient.UpgradeRisksPredictionUpstream).Do(req)
+}
+
 func (server *HTTPServer) fetchUpgradePrediction(
+	ctx context.Context,
 	cluster types.ClusterName,
 	writer http.ResponseWriter,
 ) (*types.DataEngResponse, error) {
@@ -186,13 +206,9 @@ func (server *HTTPServer) fetchUpgradePrediction(
 		cluster,
 	)
 
-	httpClient := http.Client{
-		Timeout: 5 * time.Second,
-	}
-
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	response, err := httpClient.Get(dataEngURL)
+	response, err := upgradeRisksPredictionRequest(ctx, http.MethodGet, dataEngURL, http.NoBody)
 	if err != nil {
 		log.Error().
 			Str(clusterIDTag, string(cluster)).
@@ -233,6 +249,7 @@ func (server *HTTPServer) fetchUpgradePrediction(
 }
 
 func (server *HTTPServer) fetchMulticlusterUpgradePrediction(
+	ctx context.Context,
 	clusterList ctypes.ClusterListInRequest,
 	writer http.ResponseWriter,
 ) (*types.UpgradeRisksRecommendations, error) {
@@ -241,10 +258,6 @@ func (server *HTTPServer) fetchMulticlusterUpgradePrediction(
 		UpgradeRisksPredictionMultiClusterEndpoint,
 	)
 
-	httpClient := http.Client{
-		Timeout: 5 * time.Second,
-	}
-
 	var asJSON bytes.Buffer
 	encoder := json.NewEncoder(&asJSON)
 	// Encode the map into JSON and check for errors
@@ -256,7 +269,7 @@ func (server *HTTPServer) fetchMulticlusterUpgradePrediction(
 	}
 	// #nosec G107
 	// nolint:bodyclose // TODO: remove once the bodyclose library fixes this bug
-	response, err := httpClient.Post(dataEngURL, JSONContentType, &asJSON)
+	response, err := upgradeRisksPredictionRequest(ctx, http.MethodPost, dataEngURL, &asJSON)
 	defer services.CloseResponseBody(response)
 	if err != nil {
 		log.Error().
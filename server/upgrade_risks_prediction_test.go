@@ -583,15 +583,16 @@ func TestHTTPServer_GetUpgradeRisksPrediction__timesout(t *testing.T) {
 func TestHTTPServer_GetMulticlusterURPNoBody(t *testing.T) {
 	helpers.RunTestWithTimeout(t, func(t testing.TB) {
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
-		expectedResponse := `{"status":"client didn't provide request body"}`
+		expectedResponse := `{"status":"client didn't provide request body","request_id":"test-request-id"}`
 		iou_helpers.AssertAPIRequest(
 			t,
 			testServer,
 			serverConfigXRH.APIv2Prefix,
 			&helpers.APIRequest{
-				Method:      http.MethodPost,
-				Endpoint:    server.UpgradeRisksPredictionMultiClusterEndpoint,
-				XRHIdentity: goodXRHAuthToken,
+				Method:       http.MethodPost,
+				Endpoint:     server.UpgradeRisksPredictionMultiClusterEndpoint,
+				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode:  http.StatusBadRequest,
 				Body:        expectedResponse,
@@ -604,16 +605,17 @@ func TestHTTPServer_GetMulticlusterURPNoBody(t *testing.T) {
 func TestHTTPServer_GetMulticlusterUpgradeRisksServiceUnvailable(t *testing.T) {
 	helpers.RunTestWithTimeout(t, func(t testing.TB) {
 		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
-		expectedResponse := `{"status":"Upgrade Failure Prediction service is unreachable"}`
+		expectedResponse := `{"status":"Upgrade Failure Prediction service is unreachable","request_id":"test-request-id"}`
 		iou_helpers.AssertAPIRequest(
 			t,
 			testServer,
 			serverConfigXRH.APIv2Prefix,
 			&helpers.APIRequest{
-				Method:      http.MethodPost,
-				Endpoint:    server.UpgradeRisksPredictionMultiClusterEndpoint,
-				XRHIdentity: goodXRHAuthToken,
-				Body:        helpers.ToJSONString(testdata.ClusterIDListInReq),
+				Method:       http.MethodPost,
+				Endpoint:     server.UpgradeRisksPredictionMultiClusterEndpoint,
+				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
+				Body:         helpers.ToJSONString(testdata.ClusterIDListInReq),
 			}, &helpers.APIResponse{
 				StatusCode:  http.StatusServiceUnavailable,
 				Body:        expectedResponse,
@@ -822,16 +824,17 @@ func TestHTTPServer_GetMulticlusterURPOverMaxAllowed(t *testing.T) {
 		clusters := generateUUIDs(server.MaxAllowedClusters + 1)
 		reqBody := fmt.Sprintf(`{"clusters": ["%s"]}`, strings.Join(clusters, `","`))
 
-		expectedResponse := `{"status":"the maximum amount of clusters allowed are 100"}`
+		expectedResponse := `{"status":"the maximum amount of clusters allowed are 100","request_id":"test-request-id"}`
 		iou_helpers.AssertAPIRequest(
 			t,
 			testServer,
 			serverConfigXRH.APIv2Prefix,
 			&helpers.APIRequest{
-				Method:      http.MethodPost,
-				Endpoint:    server.UpgradeRisksPredictionMultiClusterEndpoint,
-				Body:        reqBody,
-				XRHIdentity: goodXRHAuthToken,
+				Method:       http.MethodPost,
+				Endpoint:     server.UpgradeRisksPredictionMultiClusterEndpoint,
+				Body:         reqBody,
+				XRHIdentity:  goodXRHAuthToken,
+				ExtraHeaders: requestIDHeader,
 			}, &helpers.APIResponse{
 				StatusCode:  http.StatusBadRequest,
 				Body:        expectedResponse,
@@ -840,3 +843,39 @@ func TestHTTPServer_GetMulticlusterURPOverMaxAllowed(t *testing.T) {
 		)
 	}, testTimeout)
 }
+
+// TestRequestIDForwardedToDataEng checks that request ID sent by the client
+// is forwarded to the upgrade risks prediction service
+func TestRequestIDForwardedToDataEng(t *testing.T) {
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		clusterInfoList := testdata.GetRandomClusterInfoListAllUnManaged(1)
+		cluster := clusterInfoList[0].ID
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.UpgradeRisksPredictionEndpoint, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     "cluster/{clusterId}/upgrade-risks-prediction",
+			EndpointArgs: []interface{}{cluster},
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Body:       testdata.UpgradeRecommended,
+		})
+
+		testServer := helpers.CreateHTTPServer(
+			&helpers.DefaultServerConfig, nil, helpers.AMSClientWithOrgResults(testdata.OrgID, clusterInfoList),
+			nil, nil, nil, nil, nil,
+		)
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodGet,
+			Endpoint:     server.UpgradeRisksPredictionEndpoint,
+			EndpointArgs: []interface{}{cluster},
+			XRHIdentity:  goodXRHAuthToken,
+			ExtraHeaders: requestIDHeader,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"X-Request-ID": testRequestID},
+		})
+	}, testTimeout)
+}
@@ -0,0 +1,321 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	httputils "github.com/RedHatInsights/insights-operator-utils/http"
+	"github.com/RedHatInsights/insights-operator-utils/responses"
+	utypes "github.com/RedHatInsights/insights-operator-utils/types"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/content"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
+)
+
+const (
+	// WebhookIDParam parameter name in the URL for webhook IDs
+	WebhookIDParam = "webhook_id"
+
+	// webhooksUserID is used in requests to aggregator made by the
+	// webhooks evaluator, as there is no user behind them
+	webhooksUserID = types.UserID("webhooks")
+
+	webhooksDisabledMessage = "Webhook notifications are disabled"
+)
+
+// webhookRegistration represents payload sent by client to register new
+// webhook
+type webhookRegistration struct {
+	URL    string          `json:"url"`
+	Secret string          `json:"secret"`
+	Filter webhooks.Filter `json:"filter"`
+}
+
+// discardResponseWriter is used when helpers shared with request handlers
+// are called by background jobs. The helpers return errors, so responses
+// prepared for clients can be thrown away.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (writer *discardResponseWriter) Header() http.Header {
+	if writer.header == nil {
+		writer.header = make(http.Header)
+	}
+	return writer.header
+}
+
+func (*discardResponseWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (*discardResponseWriter) WriteHeader(int) {}
+
+// SetWebhookStore method sets the store with webhooks registered by
+// organizations and the configuration used to validate new webhooks
+func (server *HTTPServer) SetWebhookStore(store webhooks.Store, conf webhooks.Configuration) {
+	server.webhookStore = store
+	server.webhooksConf = conf
+}
+
+// checkWebhooksEnabled method responds with HTTP code 404 when webhook
+// notifications are disabled
+func (server *HTTPServer) checkWebhooksEnabled(writer http.ResponseWriter) bool {
+	if server.webhookStore != nil {
+		return true
+	}
+
+	if err := responses.SendNotFound(writer, webhooksDisabledMessage); err != nil {
+		log.Error().Err(err).Msg(responseDataError)
+	}
+	return false
+}
+
+// readWebhookRegistration function reads and validates webhook sent by
+// client. Only https endpoints on public addresses are accepted, unless
+// insecure endpoints are allowed in configuration.
+func readWebhookRegistration(request *http.Request, conf webhooks.Configuration) (*webhooks.Webhook, error) {
+	var registration webhookRegistration
+	if err := json.NewDecoder(request.Body).Decode(&registration); err != nil {
+		return nil, &BadBodyContent{}
+	}
+
+	if err := webhooks.CheckEndpoint(request.Context(), conf, registration.URL); err != nil {
+		return nil, &RouterParsingError{
+			ParamName:  "url",
+			ParamValue: registration.URL,
+			ErrString:  err.Error(),
+		}
+	}
+
+	if registration.Secret == "" {
+		return nil, &RouterMissingParamError{ParamName: "secret"}
+	}
+
+	if registration.Filter.TotalRiskMin == 0 {
+		registration.Filter.TotalRiskMin = webhooks.DefaultTotalRiskMin
+	}
+	if registration.Filter.TotalRiskMin < minTotalRisk || registration.Filter.TotalRiskMin > maxTotalRisk {
+		return nil, &RouterParsingError{
+			ParamName:  "total_risk_min",
+			ParamValue: registration.Filter.TotalRiskMin,
+			ErrString:  fmt.Sprintf("total risk between %d and %d expected", minTotalRisk, maxTotalRisk),
+		}
+	}
+
+	return &webhooks.Webhook{
+		ID:        uuid.NewString(),
+		URL:       registration.URL,
+		Secret:    registration.Secret,
+		Filter:    registration.Filter,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// withoutSecret function returns copy of the webhook that can be sent to
+// client
+func withoutSecret(webhook webhooks.Webhook) webhooks.Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+// getWebhooks method returns webhooks registered by the organization
+func (server *HTTPServer) getWebhooks(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	if !server.checkWebhooksEnabled(writer) {
+		return
+	}
+
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg(orgIDTokenError)
+		handleServerError(writer, err)
+		return
+	}
+
+	registered, err := server.webhookStore.List(orgID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read webhooks")
+		handleServerError(writer, err)
+		return
+	}
+
+	slices.SortFunc(registered, func(a, b webhooks.Webhook) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	for i := range registered {
+		registered[i] = withoutSecret(registered[i])
+	}
+
+	if err = responses.SendOK(writer, responses.BuildOkResponseWithData("webhooks", registered)); err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// registerWebhook method registers new webhook for the organization
+func (server *HTTPServer) registerWebhook(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	if !server.checkWebhooksEnabled(writer) {
+		return
+	}
+
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg(orgIDTokenError)
+		handleServerError(writer, err)
+		return
+	}
+
+	webhook, err := readWebhookRegistration(request, server.webhooksConf)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	registered, err := server.webhookStore.List(orgID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to read webhooks")
+		handleServerError(writer, err)
+		return
+	}
+	if len(registered) >= webhooks.MaxWebhooksPerOrganization {
+		handleServerError(writer, &TooManyWebhooksError{})
+		return
+	}
+
+	if err = server.webhookStore.Add(orgID, webhook); err != nil {
+		logger.Error().Err(err).Msg("Unable to register webhook")
+		handleServerError(writer, err)
+		return
+	}
+	logger.Info().Str("webhookID", webhook.ID).Msg("Webhook registered")
+
+	if err = responses.SendCreated(writer, responses.BuildOkResponseWithData("webhook", withoutSecret(*webhook))); err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// deleteWebhook method removes webhook registered by the organization
+func (server *HTTPServer) deleteWebhook(writer http.ResponseWriter, request *http.Request) {
+	logger := requestLogger(request)
+	if !server.checkWebhooksEnabled(writer) {
+		return
+	}
+
+	orgID, err := server.GetCurrentOrgID(request)
+	if err != nil {
+		logger.Warn().Err(err).Msg(orgIDTokenError)
+		handleServerError(writer, err)
+		return
+	}
+
+	webhookID, err := httputils.GetRouterParam(request, WebhookIDParam)
+	if err != nil {
+		handleServerError(writer, err)
+		return
+	}
+
+	err = server.webhookStore.Delete(orgID, webhookID)
+	if errors.Is(err, webhooks.ErrWebhookNotFound) {
+		handleServerError(writer, &utypes.ItemNotFoundError{ItemID: webhookID})
+		return
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to delete webhook")
+		handleServerError(writer, err)
+		return
+	}
+	logger.Info().Str("webhookID", webhookID).Msg("Webhook deleted")
+
+	if err = responses.SendNoContent(writer); err != nil {
+		logger.Error().Err(err).Msg(responseDataError)
+	}
+}
+
+// WebhookHits method returns recommendations currently hitting clusters of
+// the organization. It is used as the source of hits by the webhooks
+// evaluator. Acked recommendations, recommendations disabled for the cluster
+// and recommendations not applicable to managed clusters are skipped.
+func (server *HTTPServer) WebhookHits(ctx context.Context, orgID types.OrgID) ([]webhooks.Hit, error) {
+	writer := &discardResponseWriter{}
+
+	clusterInfoList, err := server.readClusterInfoForOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	clusterList := types.GetClusterNames(clusterInfoList)
+
+	clusterRecommendationMap, err := server.getClustersAndRecommendations(ctx, writer, orgID, webhooksUserID, clusterList)
+	if err != nil {
+		return nil, err
+	}
+
+	ackedRules, err := server.readListOfAckedRules(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ackedRulesMap := generateRuleAckMap(ackedRules, server.readAckExpirations(orgID))
+	ruleDisabledClusters := server.getRuleDisabledClusters(ctx, writer, orgID, clusterList)
+
+	hits := make([]webhooks.Hit, 0)
+	for i := range clusterInfoList {
+		clusterInfo := &clusterInfoList[i]
+
+		recommendations, found := clusterRecommendationMap[clusterInfo.ID]
+		if !found {
+			continue
+		}
+
+		for _, ruleID := range recommendations.Recommendations {
+			if ackedRulesMap[ruleID] || slices.Contains(ruleDisabledClusters[ruleID], clusterInfo.ID) {
+				continue
+			}
+
+			ruleContent, err := content.GetContentForRecommendation(ruleID)
+			if err != nil {
+				var timeoutErr *content.RuleContentDirectoryTimeoutError
+				if errors.As(err, &timeoutErr) {
+					return nil, err
+				}
+				log.Warn().Err(err).Interface(ruleIDStr, ruleID).Msg(ruleContentError)
+				continue
+			}
+
+			if clusterInfo.Managed && !ruleContent.OSDCustomer {
+				continue
+			}
+
+			hits = append(hits, webhooks.Hit{
+				ClusterID:   clusterInfo.ID,
+				RuleID:      ruleID,
+				TotalRisk:   ruleContent.TotalRisk,
+				Tags:        ruleContent.Tags,
+				Description: ruleContent.Description,
+			})
+		}
+	}
+
+	return hits, nil
+}
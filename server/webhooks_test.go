@@ -0,0 +1,266 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	iou_helpers "github.com/RedHatInsights/insights-operator-utils/tests/helpers"
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	ira_server "github.com/RedHatInsights/insights-results-aggregator/server"
+	ctypes "github.com/RedHatInsights/insights-results-types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/server"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	data "github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
+)
+
+const testWebhookRegistration = `{
+	"url": "https://example.com/hook",
+	"secret": "top secret",
+	"filter": {"tags": ["security"]}
+}`
+
+// webhooksResponse represents body returned by webhooks endpoints
+type webhooksResponse struct {
+	Status   string             `json:"status"`
+	Webhook  webhooks.Webhook   `json:"webhook"`
+	Webhooks []webhooks.Webhook `json:"webhooks"`
+}
+
+// webhooksTestServer constructs server with in-memory webhook store
+func webhooksTestServer() (*server.HTTPServer, webhooks.Store) {
+	store := webhooks.NewMemoryStore()
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+	testServer.SetWebhookStore(store, webhooks.Configuration{})
+	return testServer, store
+}
+
+// TestWebhooksEndpointsDisabled checks that webhook endpoints are not
+// available when webhooks are disabled
+func TestWebhooksEndpointsDisabled(t *testing.T) {
+	testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, nil, nil, nil, nil, nil, nil)
+
+	for _, request := range []helpers.APIRequest{
+		{Method: http.MethodGet, Endpoint: server.WebhooksEndpoint},
+		{Method: http.MethodPost, Endpoint: server.WebhooksEndpoint, Body: testWebhookRegistration},
+		{Method: http.MethodDelete, Endpoint: server.WebhookEndpoint, EndpointArgs: []interface{}{"webhook"}},
+	} {
+		request.XRHIdentity = goodXRHAuthToken
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &request, &helpers.APIResponse{
+			StatusCode: http.StatusNotFound,
+		})
+	}
+}
+
+// TestWebhooksRegisterListDelete checks the whole life cycle of the webhook
+func TestWebhooksRegisterListDelete(t *testing.T) {
+	testServer, store := webhooksTestServer()
+
+	var webhookID string
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodPost,
+		Endpoint:    server.WebhooksEndpoint,
+		Body:        testWebhookRegistration,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusCreated,
+		Body:       "",
+		BodyChecker: func(t testing.TB, _, got []byte) {
+			var resp webhooksResponse
+			helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+			assert.NotEmpty(t, resp.Webhook.ID)
+			assert.Equal(t, "https://example.com/hook", resp.Webhook.URL)
+			assert.Empty(t, resp.Webhook.Secret)
+			assert.Equal(t, webhooks.Filter{TotalRiskMin: 4, Tags: []string{"security"}}, resp.Webhook.Filter)
+			webhookID = resp.Webhook.ID
+		},
+	})
+
+	registered, err := store.List(testdata.OrgID)
+	helpers.FailOnError(t, err)
+	assert.Len(t, registered, 1)
+	assert.Equal(t, "top secret", registered[0].Secret)
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:      http.MethodGet,
+		Endpoint:    server.WebhooksEndpoint,
+		XRHIdentity: goodXRHAuthToken,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusOK,
+		Body:       "",
+		BodyChecker: func(t testing.TB, _, got []byte) {
+			var resp webhooksResponse
+			helpers.FailOnError(t, json.Unmarshal(got, &resp))
+
+			assert.Len(t, resp.Webhooks, 1)
+			assert.Equal(t, webhookID, resp.Webhooks[0].ID)
+			assert.Empty(t, resp.Webhooks[0].Secret)
+		},
+	})
+
+	for _, expectedStatus := range []int{http.StatusNoContent, http.StatusNotFound} {
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:       http.MethodDelete,
+			Endpoint:     server.WebhookEndpoint,
+			EndpointArgs: []interface{}{webhookID},
+			XRHIdentity:  goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: expectedStatus,
+		})
+	}
+}
+
+// TestWebhooksRegisterBadRequest checks validation of registered webhooks
+func TestWebhooksRegisterBadRequest(t *testing.T) {
+	testServer, _ := webhooksTestServer()
+
+	for _, body := range []string{
+		`not a JSON`,
+		`{"url": "example.com/hook", "secret": "secret"}`,
+		`{"url": "ftp://example.com/hook", "secret": "secret"}`,
+		`{"url": "http://example.com/hook", "secret": "secret"}`,
+		`{"url": "https://127.0.0.1/hook", "secret": "secret"}`,
+		`{"url": "https://169.254.169.254/latest/meta-data", "secret": "secret"}`,
+		`{"url": "https://[::1]:8080/hook", "secret": "secret"}`,
+		`{"url": "https://10.0.0.1/hook", "secret": "secret"}`,
+		`{"url": "https://localhost/hook", "secret": "secret"}`,
+		`{"url": "https://example.com/hook"}`,
+		`{"url": "https://example.com/hook", "secret": "secret", "filter": {"total_risk_min": 5}}`,
+	} {
+		iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+			Method:      http.MethodPost,
+			Endpoint:    server.WebhooksEndpoint,
+			Body:        body,
+			XRHIdentity: goodXRHAuthToken,
+		}, &helpers.APIResponse{
+			StatusCode: http.StatusBadRequest,
+		})
+	}
+}
+
+// TestWebhooksRegisterTooMany checks that number of webhooks registered by
+// one organization is limited
+func TestWebhooksRegisterTooMany(t *testing.T) {
+	testServer, store := webhooksTestServer()
+	for i := 0; i < webhooks.MaxWebhooksPerOrganization; i++ {
+		helpers.FailOnError(t, store.Add(testdata.OrgID, &webhooks.Webhook{ID: fmt.Sprint(i)}))
+	}
+
+	iou_helpers.AssertAPIRequest(t, testServer, helpers.DefaultServerConfig.APIv2Prefix, &helpers.APIRequest{
+		Method:       http.MethodPost,
+		Endpoint:     server.WebhooksEndpoint,
+		Body:         testWebhookRegistration,
+		XRHIdentity:  goodXRHAuthToken,
+		ExtraHeaders: requestIDHeader,
+	}, &helpers.APIResponse{
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"status": "the maximum amount of webhooks allowed is 10", "request_id": "test-request-id"}`,
+	})
+}
+
+// TestWebhookHits checks that acked recommendations and recommendations
+// disabled for the cluster are not returned as hits for webhooks
+func TestWebhookHits(t *testing.T) {
+	err := loadMockRuleContentDir(
+		createRuleContentDirectoryFromRuleContent(
+			[]ctypes.RuleContent{
+				testdata.RuleContent1,
+				testdata.RuleContent2,
+				testdata.RuleContent3,
+			},
+		),
+	)
+	assert.Nil(t, err)
+
+	helpers.RunTestWithTimeout(t, func(t testing.TB) {
+		defer helpers.CleanAfterGock(t)
+
+		clusterInfoList := []types.ClusterInfo{data.GetRandomClusterInfo(), data.GetRandomClusterInfo()}
+		clusterList := types.GetClusterNames(clusterInfoList)
+		reqBody, _ := json.Marshal(clusterList)
+
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+			&helpers.APIRequest{
+				Method:       http.MethodPost,
+				Endpoint:     ira_server.ClustersRecommendationsListEndpoint,
+				EndpointArgs: []interface{}{testdata.OrgID, "webhooks"},
+				Body:         reqBody,
+			},
+			&helpers.APIResponse{
+				StatusCode: http.StatusOK,
+				Body: fmt.Sprintf(`{"clusters":{
+					"%v":{"created_at":"%v","recommendations":["%v","%v","%v"]},
+					"%v":{"created_at":"%v","recommendations":["%v"]}
+				}}`,
+					clusterList[0], testTimeStr, testdata.Rule1CompositeID, testdata.Rule2CompositeID, testdata.Rule3CompositeID,
+					clusterList[1], testTimeStr, testdata.Rule2CompositeID,
+				),
+			},
+		)
+
+		// rule 1 is acked
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+			&helpers.APIRequest{
+				Method:       http.MethodGet,
+				Endpoint:     ira_server.ListOfDisabledRulesSystemWide,
+				EndpointArgs: []interface{}{testdata.OrgID},
+			},
+			&helpers.APIResponse{
+				StatusCode: http.StatusOK,
+				Body:       helpers.ToJSONString(ResponseRule1DisabledSystemWide),
+			},
+		)
+
+		// rule 2 is disabled for the second cluster
+		helpers.GockExpectAPIRequest(t, helpers.DefaultServicesConfig.AggregatorBaseEndpoint,
+			&helpers.APIRequest{
+				Method:       http.MethodPost,
+				Endpoint:     ira_server.ListOfDisabledRulesForClusters,
+				EndpointArgs: []interface{}{testdata.OrgID},
+				Body:         reqBody,
+			},
+			&helpers.APIResponse{
+				StatusCode: http.StatusOK,
+				Body: fmt.Sprintf(`{"rules":[{"ClusterID":"%v","RuleID":"%v.report","ErrorKey":"%v"}],"status":"ok"}`,
+					clusterList[1], testdata.Rule2ID, testdata.ErrorKey2,
+				),
+			},
+		)
+
+		amsClientMock := helpers.AMSClientWithOrgResults(testdata.OrgID, clusterInfoList)
+		testServer := helpers.CreateHTTPServer(&helpers.DefaultServerConfig, nil, amsClientMock, nil, nil, nil, nil, nil)
+
+		hits, err := testServer.WebhookHits(context.Background(), testdata.OrgID)
+		helpers.FailOnError(t, err)
+
+		found := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			found = append(found, fmt.Sprintf("%v %v", hit.ClusterID, hit.RuleID))
+		}
+		assert.ElementsMatch(t, []string{
+			fmt.Sprintf("%v %v", clusterList[0], testdata.Rule2CompositeID),
+			fmt.Sprintf("%v %v", clusterList[0], testdata.Rule3CompositeID),
+		}, found)
+	}, testTimeout)
+}
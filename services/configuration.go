@@ -20,13 +20,26 @@ import (
 	"time"
 )
 
-// RedisConfiguration represents configuration of Redis client
+// RedisConfiguration represents configuration of Redis client. Single Redis
+// server is used by default, Sentinel is used when sentinel addresses are
+// configured and Redis Cluster is used in cluster mode.
 type RedisConfiguration struct {
 	RedisEndpoint       string `mapstructure:"endpoint" toml:"endpoint"`
 	RedisDatabase       int    `mapstructure:"database" toml:"database"`
 	RedisTimeoutSeconds int    `mapstructure:"timeout_seconds" toml:"timeout_seconds"`
 	RedisPassword       string `mapstructure:"password" toml:"password"`
 	RedisUsername       string `mapstructure:"username" toml:"username"`
+
+	RedisSentinelAddresses []string `mapstructure:"sentinel_addresses" toml:"sentinel_addresses"`
+	RedisSentinelPassword  string   `mapstructure:"sentinel_password" toml:"sentinel_password"`
+	RedisMasterName        string   `mapstructure:"master_name" toml:"master_name"`
+
+	RedisClusterMode      bool     `mapstructure:"cluster_mode" toml:"cluster_mode"`
+	RedisClusterAddresses []string `mapstructure:"cluster_addresses" toml:"cluster_addresses"`
+
+	RedisUseTLS        bool   `mapstructure:"use_tls" toml:"use_tls"`
+	RedisTLSCAFile     string `mapstructure:"tls_ca_file" toml:"tls_ca_file"`
+	RedisTLSServerName string `mapstructure:"tls_server_name" toml:"tls_server_name"`
 }
 
 // Configuration represents configuration of services on which smart-proxy depends.
@@ -25,5 +25,8 @@ package services
 // to see why this trick is needed for using package internal
 // symbols (externally invisible) in unit tests.
 var (
-	GetFromURL = getFromURL
+	GetFromURL             = getFromURL
+	RequestWatcherRegister = (*KeyspaceRequestWatcher).register
+	RequestWatcherDispatch = (*KeyspaceRequestWatcher).dispatch
+	ReleaseLeaseScript     = releaseLeaseScript
 )
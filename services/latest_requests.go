@@ -0,0 +1,256 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// latestRequestReport structure represents fields of simplified report
+// read for the latest request of a cluster
+type latestRequestReport struct {
+	RequestID   string `redis:"request_id"`
+	Received    string `redis:"received_timestamp"`
+	Processed   string `redis:"processed_timestamp"`
+	RuleHitsCSV string `redis:"rule_hits"`
+}
+
+// GetLatestRequestsForClusters retrieves the most recently received request
+// of each given cluster together with its rule hits. Commands for all
+// clusters are sent in pipelines to avoid client-server round trip per
+// cluster. Requests of the organization are scanned once for all clusters
+// whose index of requests does not exist. Clusters without any stored
+// request are not part of the result.
+func (redisClient *RedisClient) GetLatestRequestsForClusters(
+	orgID types.OrgID,
+	clusterIDs []types.ClusterName,
+) (map[types.ClusterName]types.ClusterLatestRequest, error) {
+	ctx := context.Background()
+
+	if len(clusterIDs) == 0 {
+		return map[types.ClusterName]types.ClusterLatestRequest{}, nil
+	}
+
+	indexedRequestIDs, err := redisClient.getLatestIndexedRequestIDs(ctx, orgID, clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	latestRequests, err := redisClient.readLatestRequests(ctx, orgID, clusterIDs, indexedRequestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// fallback for clusters whose requests have not been indexed yet,
+	// clusters with index of expired requests have no request
+	var unindexedClusters []types.ClusterName
+	for _, clusterID := range clusterIDs {
+		if _, indexed := indexedRequestIDs[clusterID]; !indexed {
+			unindexedClusters = append(unindexedClusters, clusterID)
+		}
+	}
+
+	if len(unindexedClusters) > 0 {
+		scannedRequestIDs, err := redisClient.scanLatestRequestIDsOfClusters(ctx, orgID, unindexedClusters)
+		if err != nil {
+			return nil, err
+		}
+
+		scannedRequests, err := redisClient.readLatestRequests(ctx, orgID, unindexedClusters, scannedRequestIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for clusterID, latestRequest := range scannedRequests {
+			latestRequests[clusterID] = latestRequest
+		}
+	}
+
+	log.Debug().Msgf("retrieved latest requests of %d out of %d clusters", len(latestRequests), len(clusterIDs))
+	return latestRequests, nil
+}
+
+// getLatestIndexedRequestIDs method reads IDs of the most recently received
+// requests of each cluster from indexes of requests. More than one ID is
+// read, because the latest indexed request might have expired already.
+// Clusters without index are not part of the result. Redis deletes sorted
+// sets without members, so the index exists exactly when a member is read.
+func (redisClient *RedisClient) getLatestIndexedRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterIDs []types.ClusterName,
+) (map[types.ClusterName][]types.RequestID, error) {
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, clusterID := range clusterIDs {
+			pipe.ZRevRange(ctx, fmt.Sprintf(RequestIDsIndexKey, orgID, clusterID), 0, indexOverFetchFactor-1)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	requestIDs := make(map[types.ClusterName][]types.RequestID, len(clusterIDs))
+	for i, cmd := range commands {
+		for _, member := range cmd.(*redisV9.StringSliceCmd).Val() {
+			requestIDs[clusterIDs[i]] = append(requestIDs[clusterIDs[i]], types.RequestID(member))
+		}
+	}
+
+	return requestIDs, nil
+}
+
+// scanLatestRequestIDsOfClusters method scans keys of all requests of the
+// organization once and returns ID of the most recently received request of
+// each given cluster as its only candidate. Clusters without any stored
+// request are not part of the result.
+func (redisClient *RedisClient) scanLatestRequestIDsOfClusters(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterIDs []types.ClusterName,
+) (map[types.ClusterName][]types.RequestID, error) {
+	requestKeys, err := redisClient.scanRequestKeys(ctx, fmt.Sprintf(OrgRequestIDsScanPattern, orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[types.ClusterName]bool, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		wanted[clusterID] = true
+	}
+
+	var scannedKeys []requestKey
+	for _, key := range requestKeys {
+		if wanted[key.clusterID] {
+			scannedKeys = append(scannedKeys, key)
+		}
+	}
+
+	if len(scannedKeys) == 0 {
+		return map[types.ClusterName][]types.RequestID{}, nil
+	}
+
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, key := range scannedKeys {
+			pipe.HMGet(ctx, key.reportsKey(), ReceivedTimestampFieldName)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	requestIDs := make(map[types.ClusterName][]types.RequestID, len(clusterIDs))
+	latestReceived := make(map[types.ClusterName]time.Time, len(clusterIDs))
+	for i, cmd := range commands {
+		key := scannedKeys[i]
+		var received time.Time
+		if timestamp, ok := cmd.(*redisV9.SliceCmd).Val()[0].(string); ok {
+			received, _ = time.Parse(time.RFC3339, timestamp)
+		}
+
+		latest, found := latestReceived[key.clusterID]
+		if !found || received.After(latest) {
+			latestReceived[key.clusterID] = received
+			requestIDs[key.clusterID] = []types.RequestID{key.requestID}
+		}
+	}
+
+	return requestIDs, nil
+}
+
+// readLatestRequests method reads simplified reports of candidate requests
+// of the given clusters in one pipeline. Candidates of each cluster are
+// ordered from the most recently received one and the first candidate whose
+// report has not expired yet is returned.
+func (redisClient *RedisClient) readLatestRequests(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterIDs []types.ClusterName,
+	requestIDs map[types.ClusterName][]types.RequestID,
+) (map[types.ClusterName]types.ClusterLatestRequest, error) {
+	latestRequests := make(map[types.ClusterName]types.ClusterLatestRequest, len(clusterIDs))
+
+	// the order of requests in pipeline has to be remembered as commands
+	// results are returned in the same order
+	var keys []requestKey
+	for _, clusterID := range clusterIDs {
+		for _, requestID := range requestIDs[clusterID] {
+			keys = append(keys, requestKey{
+				orgID:     fmt.Sprint(orgID),
+				clusterID: clusterID,
+				requestID: requestID,
+			})
+		}
+	}
+
+	if len(keys) == 0 {
+		return latestRequests, nil
+	}
+
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, key := range keys {
+			pipe.HMGet(ctx, key.reportsKey(), RequestIDFieldName, ReceivedTimestampFieldName,
+				ProcessedTimestampFieldName, RuleHitsFieldName)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	for i, cmd := range commands {
+		clusterID := keys[i].clusterID
+		if _, found := latestRequests[clusterID]; found {
+			continue
+		}
+
+		var report latestRequestReport
+
+		err = cmd.(*redisV9.SliceCmd).Scan(&report)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to scan result map into a struct")
+			return nil, err
+		}
+
+		// simplified report might have expired already
+		if report.RequestID == "" {
+			log.Warn().Msgf("request data for request_id %v not found in Redis", keys[i].requestID)
+			continue
+		}
+
+		latestRequests[clusterID] = types.ClusterLatestRequest{
+			RequestStatus: types.RequestStatus{
+				RequestID: report.RequestID,
+				Valid:     true,
+				Received:  report.Received,
+				Processed: report.Processed,
+			},
+			RuleHits: parseRuleHits(report.RequestID, report.RuleHitsCSV),
+		}
+	}
+
+	return latestRequests, nil
+}
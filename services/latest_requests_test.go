@@ -0,0 +1,236 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	data "github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+var testClusters = []types.ClusterName{testdata.ClusterName1, testdata.ClusterName2}
+
+func expectLatestIndexedRequest(server redismock.ClientMock, clusterID types.ClusterName, requestIDs ...string) {
+	key := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, clusterID)
+	server.ExpectZRevRange(key, 0, 1).SetVal(requestIDs)
+}
+
+func expectLatestReport(server redismock.ClientMock, clusterID types.ClusterName, requestID string) *redismock.ExpectedSlice {
+	key := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, clusterID, requestID)
+	return server.ExpectHMGet(key, services.RequestIDFieldName, services.ReceivedTimestampFieldName,
+		services.ProcessedTimestampFieldName, services.RuleHitsFieldName)
+}
+
+func TestGetLatestRequestsForClusters(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	expectLatestIndexedRequest(server, testdata.ClusterName1, "requestID2")
+	expectLatestIndexedRequest(server, testdata.ClusterName2, "requestID3")
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").
+		SetVal([]interface{}{"requestID2", "2026-10-01T10:00:00Z", "2026-10-01T10:01:00Z", testRuleHits})
+	expectLatestReport(server, testdata.ClusterName2, "requestID3").
+		SetVal([]interface{}{"requestID3", "2026-10-01T11:00:00Z", nil, nil})
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters)
+	assert.NoError(t, err)
+	assert.Equal(t, map[types.ClusterName]types.ClusterLatestRequest{
+		testdata.ClusterName1: {
+			RequestStatus: types.RequestStatus{
+				RequestID: "requestID2",
+				Valid:     true,
+				Received:  "2026-10-01T10:00:00Z",
+				Processed: "2026-10-01T10:01:00Z",
+			},
+			RuleHits: []types.RuleID{data.Rule1CompositeID, data.Rule2CompositeID},
+		},
+		testdata.ClusterName2: {
+			RequestStatus: types.RequestStatus{
+				RequestID: "requestID3",
+				Valid:     true,
+				Received:  "2026-10-01T11:00:00Z",
+			},
+		},
+	}, latestRequests)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_NoClusters(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, latestRequests)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func expectScannedRequests(server redismock.ClientMock, keys ...string) {
+	server.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal(keys, 0)
+}
+
+func expectScannedReceived(server redismock.ClientMock, clusterID types.ClusterName, requestID, received string) {
+	key := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, clusterID, requestID)
+	server.ExpectHMGet(key, services.ReceivedTimestampFieldName).SetVal([]interface{}{received})
+}
+
+func TestGetLatestRequestsForClusters_ScanFallback(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	expectLatestIndexedRequest(server, testdata.ClusterName1)
+	expectLatestIndexedRequest(server, testdata.ClusterName2, "requestID3")
+	expectLatestReport(server, testdata.ClusterName2, "requestID3").
+		SetVal([]interface{}{"requestID3", "2026-10-01T11:00:00Z", "2026-10-01T11:01:00Z", nil})
+
+	// only requests of the cluster without index are read
+	expectScannedRequests(server,
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName1, "requestID1"),
+		fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName1, "requestID1"),
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName2, "requestID3"),
+	)
+	expectScannedReceived(server, testdata.ClusterName1, "requestID1", "2026-10-01T10:00:00Z")
+	expectLatestReport(server, testdata.ClusterName1, "requestID1").
+		SetVal([]interface{}{"requestID1", "2026-10-01T10:00:00Z", "2026-10-01T10:01:00Z", nil})
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters)
+	assert.NoError(t, err)
+	assert.Len(t, latestRequests, 2)
+	assert.Equal(t, "requestID1", latestRequests[testdata.ClusterName1].RequestID)
+	assert.Equal(t, "requestID3", latestRequests[testdata.ClusterName2].RequestID)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_ScanOncePerOrganization(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+	clusters := []types.ClusterName{testdata.ClusterName1, testdata.ClusterName2, testdata.ClusterName3}
+
+	for _, clusterID := range clusters {
+		expectLatestIndexedRequest(server, clusterID)
+	}
+
+	// requests of all clusters without index are found by single scan
+	expectScannedRequests(server,
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName1, "requestID1"),
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName1, "requestID2"),
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName2, "requestID3"),
+		fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName3, "requestID4"),
+	)
+	expectScannedReceived(server, testdata.ClusterName1, "requestID1", "2026-10-01T10:00:00Z")
+	expectScannedReceived(server, testdata.ClusterName1, "requestID2", "2026-10-01T12:00:00Z")
+	expectScannedReceived(server, testdata.ClusterName2, "requestID3", "2026-10-01T11:00:00Z")
+	expectScannedReceived(server, testdata.ClusterName3, "requestID4", "2026-10-01T09:00:00Z")
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").
+		SetVal([]interface{}{"requestID2", "2026-10-01T12:00:00Z", nil, nil})
+	expectLatestReport(server, testdata.ClusterName2, "requestID3").
+		SetVal([]interface{}{"requestID3", "2026-10-01T11:00:00Z", nil, nil})
+	expectLatestReport(server, testdata.ClusterName3, "requestID4").
+		SetVal([]interface{}{"requestID4", "2026-10-01T09:00:00Z", nil, nil})
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, clusters)
+	assert.NoError(t, err)
+	assert.Len(t, latestRequests, 3)
+	assert.Equal(t, "requestID2", latestRequests[testdata.ClusterName1].RequestID)
+	assert.Equal(t, "requestID3", latestRequests[testdata.ClusterName2].RequestID)
+	assert.Equal(t, "requestID4", latestRequests[testdata.ClusterName3].RequestID)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_ExpiredIndexedRequest(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	expectLatestIndexedRequest(server, testdata.ClusterName1, "requestID3", "requestID2")
+
+	// the latest indexed request has expired, but it has not been removed
+	// from the index yet
+	expectLatestReport(server, testdata.ClusterName1, "requestID3").
+		SetVal([]interface{}{nil, nil, nil, nil})
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").
+		SetVal([]interface{}{"requestID2", "2026-10-01T10:00:00Z", nil, nil})
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters[:1])
+	assert.NoError(t, err)
+	assert.Len(t, latestRequests, 1)
+	assert.Equal(t, "requestID2", latestRequests[testdata.ClusterName1].RequestID)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_IndexOfExpiredRequests(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	// requests are not scanned for cluster whose index exists
+	expectLatestIndexedRequest(server, testdata.ClusterName1, "requestID2")
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").
+		SetVal([]interface{}{nil, nil, nil, nil})
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters[:1])
+	assert.NoError(t, err)
+	assert.Empty(t, latestRequests)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_NotFound(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	expectLatestIndexedRequest(server, testdata.ClusterName1, "requestID2")
+	expectLatestIndexedRequest(server, testdata.ClusterName2)
+
+	// simplified report of indexed request has expired already
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").
+		SetVal([]interface{}{nil, nil, nil, nil})
+	expectScannedRequests(server)
+
+	latestRequests, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters)
+	assert.NoError(t, err)
+	assert.Empty(t, latestRequests)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_IndexError(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	key := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)
+	server.ExpectZRevRange(key, 0, 1).SetErr(errTest)
+
+	_, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters)
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetLatestRequestsForClusters_ReportError(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	expectLatestIndexedRequest(server, testdata.ClusterName1, "requestID2")
+	expectLatestReport(server, testdata.ClusterName1, "requestID2").SetErr(errTest)
+
+	_, err := client.GetLatestRequestsForClusters(testdata.OrgID, testClusters[:1])
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -21,8 +21,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
-	"github.com/RedHatInsights/insights-operator-utils/redis"
 	utypes "github.com/RedHatInsights/insights-operator-utils/types"
 	"github.com/RedHatInsights/insights-results-smart-proxy/types"
 
@@ -56,6 +56,9 @@ var (
 
 	// SimplifiedReportKey is a key under which the information about specific requests is stored
 	SimplifiedReportKey = "organization:%v:cluster:%v:request:%v:reports"
+
+	// ruleIDRegex is used to validate rule IDs stored in simplified reports
+	ruleIDRegex = regexp.MustCompile(`^([a-zA-Z_0-9.]+)[|]([a-zA-Z_0-9.]+)$`)
 )
 
 // RedisInterface represents interface for functions executed against a Redis server
@@ -65,6 +68,7 @@ type RedisInterface interface {
 	GetRequestIDsForClusterID(
 		types.OrgID,
 		types.ClusterName,
+		int,
 	) ([]types.RequestID, error)
 	GetTimestampsForRequestIDs(
 		types.OrgID,
@@ -82,55 +86,101 @@ type RedisInterface interface {
 		types.ClusterName,
 		types.RequestID,
 	) error
+	GetRequestsForOrganization(
+		types.OrgID,
+		time.Time,
+		time.Time,
+		int,
+		int,
+	) ([]types.ClusterRequestStatus, int, error)
+	DeleteRequestsForCluster(
+		types.OrgID,
+		types.ClusterName,
+	) (int, error)
+	EnforceRequestRetention(
+		time.Duration,
+		int,
+	) (int, error)
+	GetLatestRequestsForClusters(
+		types.OrgID,
+		[]types.ClusterName,
+	) (map[types.ClusterName]types.ClusterLatestRequest, error)
+	Close() error
 }
 
-// RedisClient is a local type which embeds the imported redis.Client to include its own functionality
+// RedisClient is an implementation of RedisInterface. The connection is
+// either a client of single Redis server, Sentinel failover client or Redis
+// Cluster client.
 type RedisClient struct {
-	redis.Client
+	Connection redisV9.UniversalClient
 }
 
 // NewRedisClient creates a new Redis client based on configuration and returns RedisInterface
 func NewRedisClient(conf RedisConfiguration) (RedisInterface, error) {
-	client, err := redis.CreateRedisClient(
-		conf.RedisEndpoint,
-		conf.RedisDatabase,
-		conf.RedisUsername,
-		conf.RedisPassword,
-		conf.RedisTimeoutSeconds,
-	)
+	connection, err := newRedisConnection(conf)
 	if err != nil {
+		log.Error().Err(err).Msg("unable to create Redis client")
 		return nil, err
 	}
 
 	return &RedisClient{
-		redis.Client{Connection: client},
+		Connection: connection,
 	}, nil
 }
 
-// GetRequestIDsForClusterID retrieves a list of request IDs from Redis.
-// "List" of request IDs is in the form of keys with empty values in the following structure:
-// organization:{org_id}:cluster:{cluster_id}:request:{request_id1}.
+// GetRequestIDsForClusterID retrieves a list of request IDs from Redis. The
+// IDs are read from the index of requests of the cluster, which is a sorted
+// set scored by received timestamps, so the most recently received requests
+// are returned first. At most limit request IDs are returned, zero limit
+// means that all of them are returned. Request keys are scanned only when
+// the index of the cluster does not exist, because its requests have not
+// been indexed yet.
 func (redisClient *RedisClient) GetRequestIDsForClusterID(
 	orgID types.OrgID,
 	clusterID types.ClusterName,
+	limit int,
 ) (requestIDs []types.RequestID, err error) {
 	ctx := context.Background()
 
+	requestIDs, indexed, err := redisClient.getIndexedRequestIDs(ctx, orgID, clusterID, limit)
+	if err != nil || indexed {
+		return
+	}
+
+	return redisClient.scanLatestRequestIDs(ctx, orgID, clusterID, limit)
+}
+
+// scanLatestRequestIDs retrieves IDs of at most limit most recently received
+// requests of the cluster by scanning request keys. Zero limit means that all
+// request IDs are returned in no particular order.
+func (redisClient *RedisClient) scanLatestRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+	limit int,
+) (requestIDs []types.RequestID, err error) {
+	requestIDs, err = redisClient.scanRequestIDs(ctx, orgID, clusterID)
+	if err != nil || limit <= 0 || len(requestIDs) <= limit {
+		return
+	}
+
+	// scanned keys are not ordered, so the most recent requests have to be
+	// found using their timestamps
+	return redisClient.getLatestRequestIDs(ctx, orgID, clusterID, requestIDs, limit)
+}
+
+// scanRequestIDs retrieves a list of request IDs from Redis using SCAN command.
+// "List" of request IDs is in the form of keys with empty values in the following structure:
+// organization:{org_id}:cluster:{cluster_id}:request:{request_id1}.
+func (redisClient *RedisClient) scanRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+) (requestIDs []types.RequestID, err error) {
 	scanKey := fmt.Sprintf(RequestIDsScanPattern, orgID, clusterID)
 	log.Debug().Str("Scan key", scanKey).Msg("Key to retrieve request IDs from Redis")
 
-	var cursor uint64
-	for {
-		var keys []string
-		var err error
-		keys, cursor, err = redisClient.Client.Connection.Scan(ctx, cursor, scanKey, ScanBatchCount).Result()
-		if err != nil {
-			log.Error().Err(err).
-				Str("scanKey", scanKey).Uint64("cursor", cursor).
-				Msg("failed to execute SCAN command for key and cursor")
-			return nil, err
-		}
-
+	err = redisClient.scanKeys(ctx, scanKey, func(keys []string) {
 		// get last part of key == request_id
 		for _, key := range keys {
 			// exclude simplified report keys that are ending with ":reports" suffix
@@ -141,10 +191,9 @@ func (redisClient *RedisClient) GetRequestIDsForClusterID(
 			requestID := keySliced[len(keySliced)-1]
 			requestIDs = append(requestIDs, types.RequestID(requestID))
 		}
-
-		if cursor == 0 {
-			break
-		}
+	})
+	if err != nil {
+		return nil, err
 	}
 	log.Debug().Msgf("retrieved %d request IDs for cluster_id %v: %v", len(requestIDs), clusterID, requestIDs)
 
@@ -245,14 +294,18 @@ func (redisClient *RedisClient) GetRuleHitsForRequest(
 
 	log.Debug().Msgf("rule hits CSV retrieved from Redis: %v", simplifiedReport.RuleHitsCSV)
 
-	// validate rule IDs coming from Redis
-	ruleHitsSplit := strings.Split(simplifiedReport.RuleHitsCSV, ",")
-	for _, ruleHit := range ruleHitsSplit {
-		ruleIDRegex := regexp.MustCompile(`^([a-zA-Z_0-9.]+)[|]([a-zA-Z_0-9.]+)$`)
+	ruleHits = parseRuleHits(simplifiedReport.RequestID, simplifiedReport.RuleHitsCSV)
+	return
+}
 
+// parseRuleHits function parses and validates rule IDs stored in Redis as
+// comma separated values. Invalid rule IDs are skipped.
+func parseRuleHits(requestID, ruleHitsCSV string) (ruleHits []types.RuleID) {
+	ruleHitsSplit := strings.Split(ruleHitsCSV, ",")
+	for _, ruleHit := range ruleHitsSplit {
 		isRuleIDValid := ruleIDRegex.MatchString(ruleHit)
 		if ruleHit == "" {
-			log.Debug().Str("RequestID", simplifiedReport.RequestID).Msg("There are no rule hits for given request id")
+			log.Debug().Str("RequestID", requestID).Msg("There are no rule hits for given request id")
 			continue
 		}
 		if !isRuleIDValid {
@@ -293,3 +346,12 @@ func (redisClient *RedisClient) GetRequestStatus(
 
 	return
 }
+
+// Close closes the connection to Redis server. It is called during service
+// shutdown once no more requests can be processed.
+func (redisClient *RedisClient) Close() error {
+	if redisClient.Connection == nil {
+		return nil
+	}
+	return redisClient.Connection.Close()
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxRedisDatabase is the highest index of database that can be
+	// selected on Redis server
+	maxRedisDatabase = 15
+
+	redisHealthCheckFailedMsg = "unexpected response from Redis server"
+)
+
+// ErrNoRedisConnection is returned when Redis storage is selected, but the
+// connection to Redis server is not available
+var ErrNoRedisConnection = errors.New("connection to Redis server is not available")
+
+// newRedisConnection function creates client of Redis Cluster when cluster
+// mode is turned on, Sentinel failover client when sentinel addresses are
+// configured or client of single Redis server otherwise
+func newRedisConnection(conf RedisConfiguration) (redisV9.UniversalClient, error) {
+	tlsConfig, err := redisTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(conf.RedisTimeoutSeconds) * time.Second
+
+	switch {
+	case conf.RedisClusterMode:
+		addresses := conf.RedisClusterAddresses
+		if len(addresses) == 0 && conf.RedisEndpoint != "" {
+			addresses = []string{conf.RedisEndpoint}
+		}
+		if len(addresses) == 0 {
+			return nil, errors.New("Redis cluster addresses must not be empty")
+		}
+		// SELECT command is not supported in Redis Cluster
+		if conf.RedisDatabase != 0 {
+			return nil, errors.New("only database 0 can be selected in Redis cluster mode")
+		}
+
+		log.Info().Strs("addresses", addresses).Int("timeoutSeconds", conf.RedisTimeoutSeconds).
+			Msg("creating Redis cluster client")
+		return redisV9.NewClusterClient(&redisV9.ClusterOptions{
+			Addrs:       addresses,
+			Username:    conf.RedisUsername,
+			Password:    conf.RedisPassword,
+			ReadTimeout: timeout,
+			TLSConfig:   tlsConfig,
+		}), nil
+
+	case len(conf.RedisSentinelAddresses) > 0:
+		if conf.RedisMasterName == "" {
+			return nil, errors.New("Redis master name must not be empty when Sentinel is used")
+		}
+		if err := checkRedisDatabase(conf.RedisDatabase); err != nil {
+			return nil, err
+		}
+
+		log.Info().Strs("sentinels", conf.RedisSentinelAddresses).Str("masterName", conf.RedisMasterName).
+			Int("database", conf.RedisDatabase).Int("timeoutSeconds", conf.RedisTimeoutSeconds).
+			Msg("creating Redis Sentinel failover client")
+		return redisV9.NewFailoverClient(&redisV9.FailoverOptions{
+			MasterName:       conf.RedisMasterName,
+			SentinelAddrs:    conf.RedisSentinelAddresses,
+			SentinelPassword: conf.RedisSentinelPassword,
+			DB:               conf.RedisDatabase,
+			Username:         conf.RedisUsername,
+			Password:         conf.RedisPassword,
+			ReadTimeout:      timeout,
+			TLSConfig:        tlsConfig,
+		}), nil
+
+	default:
+		if conf.RedisEndpoint == "" {
+			return nil, errors.New("Redis server address must not be empty")
+		}
+		if err := checkRedisDatabase(conf.RedisDatabase); err != nil {
+			return nil, err
+		}
+
+		log.Info().Str("endpoint", conf.RedisEndpoint).
+			Int("database", conf.RedisDatabase).Int("timeoutSeconds", conf.RedisTimeoutSeconds).
+			Msg("creating Redis client")
+		return redisV9.NewClient(&redisV9.Options{
+			Addr:        conf.RedisEndpoint,
+			DB:          conf.RedisDatabase,
+			Username:    conf.RedisUsername,
+			Password:    conf.RedisPassword,
+			ReadTimeout: timeout,
+			TLSConfig:   tlsConfig,
+		}), nil
+	}
+}
+
+// checkRedisDatabase function checks if the database index can be selected
+func checkRedisDatabase(database int) error {
+	if database < 0 || database > maxRedisDatabase {
+		return fmt.Errorf("Redis selected database must be a value in the range 0-%d", maxRedisDatabase)
+	}
+	return nil
+}
+
+// redisTLSConfig function constructs TLS configuration for connections to
+// Redis. Nil is returned when TLS is not turned on.
+func redisTLSConfig(conf RedisConfiguration) (*tls.Config, error) {
+	if !conf.RedisUseTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: conf.RedisTLSServerName,
+	}
+
+	if conf.RedisTLSCAFile != "" {
+		pemData, err := os.ReadFile(filepath.Clean(conf.RedisTLSCAFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Redis CA file: %w", err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pemData) {
+			return nil, errors.New("no certificate found in Redis CA file")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	return tlsConfig, nil
+}
+
+// HealthCheck method checks if Redis is responding. All shards are checked
+// in Redis Cluster.
+func (redisClient *RedisClient) HealthCheck() error {
+	ctx := context.Background()
+
+	ping := func(ctx context.Context, client redisV9.Cmdable) error {
+		res, err := client.Ping(ctx).Result()
+		if err != nil || res != "PONG" {
+			log.Error().Err(err).Msg("Redis PING command failed")
+			return errors.New(redisHealthCheckFailedMsg)
+		}
+		return nil
+	}
+
+	if cluster, isCluster := redisClient.Connection.(*redisV9.ClusterClient); isCluster {
+		return cluster.ForEachShard(ctx, func(ctx context.Context, shard *redisV9.Client) error {
+			return ping(ctx, shard)
+		})
+	}
+	return ping(ctx, redisClient.Connection)
+}
+
+// database method returns index of the selected database. Only database 0
+// is available in Redis Cluster.
+func (redisClient *RedisClient) database() int {
+	if client, ok := redisClient.Connection.(*redisV9.Client); ok {
+		return client.Options().DB
+	}
+	return 0
+}
+
+// scanKeys method calls the handler for each batch of keys matching the
+// pattern. Keys are distributed across masters in Redis Cluster, so SCAN is
+// executed on all of them concurrently.
+func (redisClient *RedisClient) scanKeys(ctx context.Context, pattern string, handler func(keys []string)) error {
+	cluster, isCluster := redisClient.Connection.(*redisV9.ClusterClient)
+	if !isCluster {
+		return scanNode(ctx, redisClient.Connection, pattern, handler)
+	}
+
+	var mutex sync.Mutex
+	return cluster.ForEachMaster(ctx, func(ctx context.Context, master *redisV9.Client) error {
+		return scanNode(ctx, master, pattern, func(keys []string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			handler(keys)
+		})
+	})
+}
+
+// scanNode function iterates over all keys matching the pattern on one Redis
+// node
+func scanNode(ctx context.Context, node redisV9.Cmdable, pattern string, handler func(keys []string)) error {
+	var cursor uint64
+	for {
+		keys, nextCursor, err := node.Scan(ctx, cursor, pattern, ScanBatchCount).Result()
+		if err != nil {
+			log.Error().Err(err).
+				Str("scanKey", pattern).Uint64("cursor", cursor).
+				Msg("failed to execute SCAN command for key and cursor")
+			return err
+		}
+
+		handler(keys)
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// subscribe method subscribes to channels matching the pattern. Keyspace
+// notifications are not propagated across Redis Cluster, so each master is
+// subscribed separately.
+func (redisClient *RedisClient) subscribe(ctx context.Context, pattern string) ([]*redisV9.PubSub, error) {
+	cluster, isCluster := redisClient.Connection.(*redisV9.ClusterClient)
+	if !isCluster {
+		pubSub, err := pSubscribe(ctx, redisClient.Connection, pattern)
+		if err != nil {
+			return nil, err
+		}
+		return []*redisV9.PubSub{pubSub}, nil
+	}
+
+	var mutex sync.Mutex
+	var subscriptions []*redisV9.PubSub
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, master *redisV9.Client) error {
+		pubSub, err := pSubscribe(ctx, master, pattern)
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		subscriptions = append(subscriptions, pubSub)
+		return nil
+	})
+	if err != nil {
+		for _, pubSub := range subscriptions {
+			_ = pubSub.Close()
+		}
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// pSubscribe function subscribes to channels matching the pattern on one
+// Redis node and waits for the confirmation, so errors are reported to the
+// caller
+func pSubscribe(ctx context.Context, node redisV9.UniversalClient, pattern string) (*redisV9.PubSub, error) {
+	pubSub := node.PSubscribe(ctx, pattern)
+	if _, err := pubSub.Receive(ctx); err != nil {
+		log.Error().Err(err).Str("pattern", pattern).Msg("unable to subscribe to keyspace notifications")
+		_ = pubSub.Close()
+		return nil, err
+	}
+	return pubSub, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+)
+
+// connectionOf returns Redis connection used by the client
+func connectionOf(t *testing.T, client services.RedisInterface) redisV9.UniversalClient {
+	redisClient, ok := client.(*services.RedisClient)
+	if !ok {
+		t.Fatalf("unexpected type of Redis client %T", client)
+	}
+	return redisClient.Connection
+}
+
+func TestNewRedisClientSentinel(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisSentinelAddresses = []string{"localhost:26379", "localhost:26380"}
+	conf.RedisMasterName = "mymaster"
+	conf.RedisDatabase = 2
+
+	client, err := services.NewRedisClient(conf)
+	helpers.FailOnError(t, err)
+	defer func() { assert.NoError(t, client.Close()) }()
+
+	connection, ok := connectionOf(t, client).(*redisV9.Client)
+	assert.True(t, ok)
+	assert.Equal(t, "FailoverClient", connection.Options().Addr)
+	assert.Equal(t, 2, connection.Options().DB)
+}
+
+func TestNewRedisClientSentinelWithoutMasterName(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisSentinelAddresses = []string{"localhost:26379"}
+
+	client, err := services.NewRedisClient(conf)
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "Redis master name must not be empty when Sentinel is used")
+}
+
+func TestNewRedisClientClusterMode(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisClusterMode = true
+	conf.RedisClusterAddresses = []string{"localhost:7000", "localhost:7001"}
+
+	client, err := services.NewRedisClient(conf)
+	helpers.FailOnError(t, err)
+	defer func() { assert.NoError(t, client.Close()) }()
+
+	connection, ok := connectionOf(t, client).(*redisV9.ClusterClient)
+	assert.True(t, ok)
+	assert.Equal(t, conf.RedisClusterAddresses, connection.Options().Addrs)
+}
+
+func TestNewRedisClientClusterModeEndpoint(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisClusterMode = true
+
+	client, err := services.NewRedisClient(conf)
+	helpers.FailOnError(t, err)
+	defer func() { assert.NoError(t, client.Close()) }()
+
+	connection, ok := connectionOf(t, client).(*redisV9.ClusterClient)
+	assert.True(t, ok)
+	assert.Equal(t, []string{conf.RedisEndpoint}, connection.Options().Addrs)
+}
+
+func TestNewRedisClientClusterModeDatabase(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisClusterMode = true
+	conf.RedisDatabase = 1
+
+	client, err := services.NewRedisClient(conf)
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "only database 0 can be selected in Redis cluster mode")
+}
+
+func TestNewRedisClientTLS(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisUseTLS = true
+	conf.RedisTLSServerName = "redis.example.com"
+
+	client, err := services.NewRedisClient(conf)
+	helpers.FailOnError(t, err)
+	defer func() { assert.NoError(t, client.Close()) }()
+
+	connection, ok := connectionOf(t, client).(*redisV9.Client)
+	assert.True(t, ok)
+	assert.NotNil(t, connection.Options().TLSConfig)
+	assert.Equal(t, "redis.example.com", connection.Options().TLSConfig.ServerName)
+	assert.Nil(t, connection.Options().TLSConfig.RootCAs)
+}
+
+func TestNewRedisClientTLSImproperCAFile(t *testing.T) {
+	conf := helpers.DefaultRedisConf
+	conf.RedisUseTLS = true
+
+	t.Run("missing file", func(t *testing.T) {
+		conf.RedisTLSCAFile = filepath.Join(t.TempDir(), "missing.crt")
+		client, err := services.NewRedisClient(conf)
+		assert.Nil(t, client)
+		assert.Error(t, err)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		conf.RedisTLSCAFile = filepath.Join(t.TempDir(), "ca.crt")
+		helpers.FailOnError(t, os.WriteFile(conf.RedisTLSCAFile, []byte("not a certificate"), 0600))
+		client, err := services.NewRedisClient(conf)
+		assert.Nil(t, client)
+		assert.EqualError(t, err, "no certificate found in Redis CA file")
+	})
+}
+
+func TestRedisHealthCheck(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectPing().SetVal("PONG")
+	assert.NoError(t, client.HealthCheck())
+
+	server.ExpectPing().SetErr(errTest)
+	assert.EqualError(t, client.HealthCheck(), "unexpected response from Redis server")
+
+	helpers.RedisExpectationsMet(t, server)
+}
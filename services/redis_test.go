@@ -62,13 +62,20 @@ func TestNewRedisClientDBIndexOutOfRange(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRedisClientClose(t *testing.T) {
+	client, err := services.NewRedisClient(helpers.DefaultRedisConf)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Close())
+}
+
 func TestRedisGetRequestIDsForClusterID_Empty(t *testing.T) {
 	client, server := helpers.GetMockRedis()
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 	server.ExpectScan(0, expectedKey, services.ScanBatchCount).SetVal([]string{}, 0)
 
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.NoError(t, err)
 	assert.Len(t, requestIDs, 0)
 
@@ -78,6 +85,7 @@ func TestRedisGetRequestIDsForClusterID_Empty(t *testing.T) {
 func TestRedisGetRequestIDsForClusterID_ResultsSinglePage(t *testing.T) {
 	client, server := helpers.GetMockRedis()
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 
 	expectedResponseKeys := make([]string, 2)
@@ -87,7 +95,7 @@ func TestRedisGetRequestIDsForClusterID_ResultsSinglePage(t *testing.T) {
 	// all results are in a single page -- cursor == 0, so no more calls are expected
 	server.ExpectScan(0, expectedKey, services.ScanBatchCount).SetVal(expectedResponseKeys, 0)
 
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.NoError(t, err)
 	assert.Len(t, requestIDs, 2)
 	assert.ElementsMatch(t, requestIDs, []types.RequestID{"requestID0", "requestID1"})
@@ -98,6 +106,7 @@ func TestRedisGetRequestIDsForClusterID_ResultsSinglePage(t *testing.T) {
 func TestRedisGetRequestIDsForClusterID_FilterKeys(t *testing.T) {
 	client, server := helpers.GetMockRedis()
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 
 	expectedResponseKeys := make([]string, 3)
@@ -109,7 +118,7 @@ func TestRedisGetRequestIDsForClusterID_FilterKeys(t *testing.T) {
 	// all results are in a single page -- cursor == 0, so no more calls are expected
 	server.ExpectScan(0, expectedKey, services.ScanBatchCount).SetVal(expectedResponseKeys, 0)
 
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.NoError(t, err)
 	assert.Len(t, requestIDs, 2)
 	assert.ElementsMatch(t, requestIDs, []types.RequestID{"requestID0", "requestIDe"})
@@ -125,6 +134,7 @@ func TestRedisGetRequestIDsForClusterID_ResultsMultiplePages(t *testing.T) {
 		expectedResponseKeys[i] = fmt.Sprintf("organization:%v:cluster:%v:request:requestID%v", testdata.OrgID, testdata.ClusterName1, i)
 	}
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 	server.ExpectScan(0, expectedKey, services.ScanBatchCount).SetVal([]string{expectedResponseKeys[0], expectedResponseKeys[1]}, 42)
 	// returned cursor is expected to be used in the next call
@@ -133,7 +143,7 @@ func TestRedisGetRequestIDsForClusterID_ResultsMultiplePages(t *testing.T) {
 	server.ExpectScan(8, expectedKey, services.ScanBatchCount).SetVal([]string{expectedResponseKeys[3]}, 0)
 	// returned cursor == 0, so no more calls are expected
 
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.NoError(t, err)
 	assert.Len(t, requestIDs, len(expectedResponseKeys))
 	assert.ElementsMatch(t, requestIDs, []types.RequestID{"requestID0", "requestID1", "requestID2", "requestID3"})
@@ -144,10 +154,11 @@ func TestRedisGetRequestIDsForClusterID_ResultsMultiplePages(t *testing.T) {
 func TestRedisGetRequestIDsForClusterID_Error(t *testing.T) {
 	client, server := helpers.GetMockRedis()
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 	server.ExpectScan(0, expectedKey, services.ScanBatchCount).SetErr(errTest)
 
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.Error(t, err)
 	assert.Len(t, requestIDs, 0)
 
@@ -157,6 +168,7 @@ func TestRedisGetRequestIDsForClusterID_Error(t *testing.T) {
 func TestRedisGetRequestIDsForClusterID_ErrorInFollowingCalls(t *testing.T) {
 	client, server := helpers.GetMockRedis()
 
+	helpers.ExpectMissingRequestIDsIndex(server, testdata.OrgID, testdata.ClusterName1)
 	expectedKey := fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1)
 
 	expectedResponseKeys := make([]string, 2)
@@ -168,7 +180,7 @@ func TestRedisGetRequestIDsForClusterID_ErrorInFollowingCalls(t *testing.T) {
 	server.ExpectScan(42, expectedKey, services.ScanBatchCount).SetErr(errTest)
 
 	// function should return empty list + error if we can't retrieve the whole data set
-	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1)
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
 	assert.Error(t, err)
 	assert.Len(t, requestIDs, 0)
 
@@ -0,0 +1,705 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+var (
+	// OrgRequestIDsScanPattern is a glob-style pattern to find keys of all
+	// requests of the organization
+	OrgRequestIDsScanPattern = "organization:%v:cluster:*:request:?*"
+
+	// AllRequestIndexesScanPattern is a glob-style pattern to find indexes
+	// of requests of all clusters of all organizations
+	AllRequestIndexesScanPattern = "organization:*:cluster:*:requests"
+
+	// AllRequestIDsScanPattern is a glob-style pattern to find keys of all
+	// requests of all clusters of all organizations
+	AllRequestIDsScanPattern = "organization:*:cluster:*:request:?*"
+
+	// RequestRetentionLeaseKey is a key of lease that prevents concurrent
+	// enforcing of the retention policy by multiple replicas
+	RequestRetentionLeaseKey = "smart-proxy:request-retention:lease"
+)
+
+// releaseLeaseScript deletes the retention lease only when it still holds
+// the token of the instance releasing it, so the lease acquired by another
+// instance after this one expired is kept
+var releaseLeaseScript = redisV9.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+const (
+	// retentionLeaseTimeout is the maximum time the retention lease is
+	// held when it is not released
+	retentionLeaseTimeout = 10 * time.Minute
+	// retentionBatchSize is the number of clusters whose requests are
+	// processed together in one pipeline
+	retentionBatchSize = 100
+	// keyNotFoundTTL is returned by TTL command for keys that don't exist
+	keyNotFoundTTL = time.Duration(-2)
+)
+
+// requestKey represents key of one request parsed from its name
+type requestKey struct {
+	orgID     string
+	clusterID types.ClusterName
+	requestID types.RequestID
+}
+
+// parseRequestKey function parses key in the format
+// "organization:{org_id}:cluster:{cluster}:request:{request_id}" or the key
+// of simplified report of the request. Other keys are not accepted.
+func parseRequestKey(key string) (requestKey, bool) {
+	parts := strings.Split(strings.TrimSuffix(key, reportsKeySuffix), ":")
+	if len(parts) != 6 || parts[0] != "organization" || parts[2] != "cluster" || parts[4] != "request" {
+		return requestKey{}, false
+	}
+
+	return requestKey{
+		orgID:     parts[1],
+		clusterID: types.ClusterName(parts[3]),
+		requestID: types.RequestID(parts[5]),
+	}, true
+}
+
+// reportsKey method returns the key of simplified report of the request
+func (key requestKey) reportsKey() string {
+	return fmt.Sprintf(SimplifiedReportKey, key.orgID, key.clusterID, key.requestID)
+}
+
+// requestIDKey method returns the key of the request
+func (key requestKey) requestIDKey() string {
+	return fmt.Sprintf(RequestIDCheck, key.orgID, key.clusterID, key.requestID)
+}
+
+// scanRequestKeys method returns keys of all requests matching the pattern.
+// Each request is returned once even if both its keys match the pattern.
+func (redisClient *RedisClient) scanRequestKeys(ctx context.Context, pattern string) ([]requestKey, error) {
+	var requestKeys []requestKey
+	found := map[requestKey]bool{}
+
+	err := redisClient.scanKeys(ctx, pattern, func(keys []string) {
+		for _, key := range keys {
+			parsed, ok := parseRequestKey(key)
+			if ok && !found[parsed] {
+				found[parsed] = true
+				requestKeys = append(requestKeys, parsed)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return requestKeys, nil
+}
+
+// deleteKeys function deletes given keys. Keys are deleted one by one in a
+// pipeline, because keys stored in different hash slots can't be deleted by
+// one command in Redis Cluster.
+func deleteKeys(ctx context.Context, pipe redisV9.Pipeliner, keys []string) {
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+}
+
+// receivedRequest represents status of request together with its received
+// timestamp used for sorting
+type receivedRequest struct {
+	request    types.ClusterRequestStatus
+	receivedAt time.Time
+}
+
+// receivedRequestsHeap is a heap of requests with the least recently
+// received request on the top
+type receivedRequestsHeap []receivedRequest
+
+func (h receivedRequestsHeap) Len() int           { return len(h) }
+func (h receivedRequestsHeap) Less(i, j int) bool { return h[i].receivedAt.Before(h[j].receivedAt) }
+func (h receivedRequestsHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *receivedRequestsHeap) Push(x any) {
+	*h = append(*h, x.(receivedRequest))
+}
+
+func (h *receivedRequestsHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// GetRequestsForOrganization retrieves one page of requests of all clusters
+// of the organization together with their timestamps and the total number
+// of requests. Only requests received in the given time range are returned,
+// zero time means the range is not limited from that side. Requests are
+// sorted from the most recently received ones. Keys are scanned and
+// statuses are read in batches and at most offset+limit most recently
+// received requests are kept in memory. Zero limit means that all requests
+// from the offset are returned.
+func (redisClient *RedisClient) GetRequestsForOrganization(
+	orgID types.OrgID,
+	from, to time.Time,
+	offset, limit int,
+) ([]types.ClusterRequestStatus, int, error) {
+	ctx := context.Background()
+
+	total := 0
+	latest := &receivedRequestsHeap{}
+	var batchErr error
+
+	err := redisClient.scanKeys(ctx, fmt.Sprintf(OrgRequestIDsScanPattern, orgID), func(keys []string) {
+		if batchErr != nil {
+			return
+		}
+
+		// statuses are read from simplified reports, so keys of requests
+		// are skipped to read each request once
+		var requestKeys []requestKey
+		for _, key := range keys {
+			if parsed, ok := parseRequestKey(key); ok && strings.HasSuffix(key, reportsKeySuffix) {
+				requestKeys = append(requestKeys, parsed)
+			}
+		}
+
+		var requests []receivedRequest
+		requests, batchErr = redisClient.readReceivedRequests(ctx, requestKeys, from, to)
+		for _, request := range requests {
+			total++
+			heap.Push(latest, request)
+			if limit > 0 && latest.Len() > offset+limit {
+				heap.Pop(latest)
+			}
+		}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if batchErr != nil {
+		return nil, 0, batchErr
+	}
+
+	receivedRequests := *latest
+	sort.SliceStable(receivedRequests, func(i, j int) bool {
+		return receivedRequests[i].receivedAt.After(receivedRequests[j].receivedAt)
+	})
+
+	page := []types.ClusterRequestStatus{}
+	for i := offset; i < len(receivedRequests); i++ {
+		page = append(page, receivedRequests[i].request)
+	}
+
+	return page, total, nil
+}
+
+// readReceivedRequests method reads statuses of given requests and returns
+// those received in the given time range. Requests whose data expired in
+// the meantime are skipped.
+func (redisClient *RedisClient) readReceivedRequests(
+	ctx context.Context,
+	requestKeys []requestKey,
+	from, to time.Time,
+) ([]receivedRequest, error) {
+	if len(requestKeys) == 0 {
+		return nil, nil
+	}
+
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, key := range requestKeys {
+			pipe.HMGet(ctx, key.reportsKey(), RequestIDFieldName, ReceivedTimestampFieldName, ProcessedTimestampFieldName)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	var receivedRequests []receivedRequest
+	for i, cmd := range commands {
+		var request types.ClusterRequestStatus
+		if err := cmd.(*redisV9.SliceCmd).Scan(&request.RequestStatus); err != nil {
+			log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+			return nil, err
+		}
+
+		// data expired in the meantime
+		if request.RequestID == "" {
+			continue
+		}
+
+		receivedAt, err := time.Parse(time.RFC3339, request.Received)
+		if err != nil && (!from.IsZero() || !to.IsZero()) {
+			log.Warn().Err(err).Str("requestID", request.RequestID).Msg("unable to parse received timestamp")
+			continue
+		}
+		if (!from.IsZero() && receivedAt.Before(from)) || (!to.IsZero() && receivedAt.After(to)) {
+			continue
+		}
+
+		request.ClusterID = requestKeys[i].clusterID
+		request.Valid = true
+		receivedRequests = append(receivedRequests, receivedRequest{request, receivedAt})
+	}
+
+	return receivedRequests, nil
+}
+
+// DeleteRequestsForCluster deletes all requests of the cluster including
+// their simplified reports and the index of requests. Number of deleted
+// requests is returned.
+func (redisClient *RedisClient) DeleteRequestsForCluster(
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+) (int, error) {
+	ctx := context.Background()
+
+	requestKeys, err := redisClient.scanRequestKeys(ctx, fmt.Sprintf(RequestIDsScanPattern, orgID, clusterID))
+	if err != nil {
+		return 0, err
+	}
+
+	// index of requests is deleted even when there are no requests, as it
+	// can contain IDs of expired requests only
+	keys := make([]string, 0, 2*len(requestKeys)+1)
+	for _, key := range requestKeys {
+		keys = append(keys, key.requestIDKey(), key.reportsKey())
+	}
+	keys = append(keys, fmt.Sprintf(RequestIDsIndexKey, orgID, clusterID))
+
+	_, err = redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		deleteKeys(ctx, pipe, keys)
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+
+	log.Info().
+		Uint32("orgID", uint32(orgID)).
+		Str("clusterID", string(clusterID)).
+		Int("requests", len(requestKeys)).
+		Msg("Requests for cluster deleted")
+
+	return len(requestKeys), nil
+}
+
+// parseIndexKey function parses key of index of requests in the format
+// "organization:{org_id}:cluster:{cluster}:requests". Request ID of the
+// returned key is empty.
+func parseIndexKey(key string) (requestKey, bool) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 5 || parts[0] != "organization" || parts[2] != "cluster" || parts[4] != "requests" {
+		return requestKey{}, false
+	}
+
+	return requestKey{
+		orgID:     parts[1],
+		clusterID: types.ClusterName(parts[3]),
+	}, true
+}
+
+// EnforceRequestRetention applies retention policy to requests of all
+// clusters. Indexes of requests of clusters are walked in batches, so
+// neither all keys are held in memory nor sent in one pipeline. Requests of
+// clusters without index are found by scanning their keys afterwards.
+// Requests older than maxAge are deleted and younger requests get TTL so
+// they expire at the age limit. Only maxRequestsPerCluster most recently
+// received requests are kept for each cluster. Zero value disables the
+// given limit. The policy is enforced by one instance at a time, others
+// return immediately. Number of deleted requests is returned.
+func (redisClient *RedisClient) EnforceRequestRetention(
+	maxAge time.Duration,
+	maxRequestsPerCluster int,
+) (int, error) {
+	ctx := context.Background()
+
+	token := uuid.NewString()
+	leased, err := redisClient.Connection.SetNX(ctx, RequestRetentionLeaseKey, token, retentionLeaseTimeout).Result()
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+	if !leased {
+		log.Debug().Msg("Retention of requests is enforced by another instance")
+		return 0, nil
+	}
+	defer func() {
+		err := releaseLeaseScript.Run(ctx, redisClient.Connection, []string{RequestRetentionLeaseKey}, token).Err()
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to release lease for retention of requests")
+		}
+	}()
+
+	deleted := 0
+	var batchErr error
+	batch := make([]requestKey, 0, retentionBatchSize)
+	flush := func() {
+		if batchErr == nil && len(batch) > 0 {
+			var batchDeleted int
+			batchDeleted, batchErr = redisClient.enforceRetentionForClusters(ctx, batch, maxAge, maxRequestsPerCluster)
+			deleted += batchDeleted
+		}
+		batch = batch[:0]
+	}
+
+	err = redisClient.scanKeys(ctx, AllRequestIndexesScanPattern, func(keys []string) {
+		for _, key := range keys {
+			if index, ok := parseIndexKey(key); ok {
+				batch = append(batch, index)
+			}
+			if len(batch) >= retentionBatchSize {
+				flush()
+			}
+		}
+	})
+	if err != nil {
+		return deleted, err
+	}
+	flush()
+	if batchErr != nil {
+		return deleted, batchErr
+	}
+
+	unindexed := newUnindexedRetention(maxAge, maxRequestsPerCluster)
+	err = redisClient.scanKeys(ctx, AllRequestIDsScanPattern, func(keys []string) {
+		if batchErr == nil {
+			var batchDeleted int
+			batchDeleted, batchErr = redisClient.enforceRetentionForUnindexedRequests(ctx, keys, unindexed)
+			deleted += batchDeleted
+		}
+	})
+	if err != nil {
+		return deleted, err
+	}
+
+	return deleted, batchErr
+}
+
+// enforceRetentionForClusters method applies retention policy to requests
+// of given clusters. Requests whose keys have expired already are removed
+// from the index. Number of deleted requests is returned.
+func (redisClient *RedisClient) enforceRetentionForClusters(
+	ctx context.Context,
+	clusters []requestKey,
+	maxAge time.Duration,
+	maxRequestsPerCluster int,
+) (int, error) {
+	// requests are read from the most recently received ones
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, cluster := range clusters {
+			pipe.ZRevRangeWithScores(ctx, cluster.indexKey(), 0, -1)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+
+	type requestRetention struct {
+		key       requestKey
+		remaining time.Duration
+	}
+
+	now := time.Now()
+	var toDelete []requestKey
+	var toExpire []requestRetention
+
+	for i, cmd := range commands {
+		for rank, member := range cmd.(*redisV9.ZSliceCmd).Val() {
+			requestID, ok := member.Member.(string)
+			if !ok {
+				continue
+			}
+			key := clusters[i]
+			key.requestID = types.RequestID(requestID)
+
+			if maxRequestsPerCluster > 0 && rank >= maxRequestsPerCluster {
+				toDelete = append(toDelete, key)
+				continue
+			}
+			if maxAge <= 0 {
+				continue
+			}
+
+			remaining := time.Unix(int64(member.Score), 0).Add(maxAge).Sub(now)
+			if remaining <= 0 {
+				toDelete = append(toDelete, key)
+				continue
+			}
+			toExpire = append(toExpire, requestRetention{key: key, remaining: remaining})
+		}
+	}
+
+	// TTL is read so keys already expiring sooner are not prolonged
+	var ttls []redisV9.Cmder
+	if len(toExpire) > 0 {
+		ttls, err = redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+			for _, request := range toExpire {
+				pipe.TTL(ctx, request.key.reportsKey())
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+			return 0, err
+		}
+	}
+
+	var toUnindex []requestKey
+	var expiring []requestRetention
+	for i, request := range toExpire {
+		ttl := ttls[i].(*redisV9.DurationCmd).Val()
+		switch {
+		case ttl == keyNotFoundTTL:
+			toUnindex = append(toUnindex, request.key)
+		// negative TTL means that the key does not expire
+		case ttl < 0 || ttl > request.remaining:
+			expiring = append(expiring, request)
+		}
+	}
+
+	if len(toDelete) == 0 && len(toUnindex) == 0 && len(expiring) == 0 {
+		return 0, nil
+	}
+
+	_, err = redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, key := range toDelete {
+			deleteKeys(ctx, pipe, []string{key.requestIDKey(), key.reportsKey()})
+			pipe.ZRem(ctx, key.indexKey(), string(key.requestID))
+		}
+		for _, key := range toUnindex {
+			pipe.ZRem(ctx, key.indexKey(), string(key.requestID))
+		}
+		for _, request := range expiring {
+			pipe.Expire(ctx, request.key.requestIDKey(), request.remaining)
+			pipe.Expire(ctx, request.key.reportsKey(), request.remaining)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+
+	return len(toDelete), nil
+}
+
+// unindexedRetention holds the state of enforcing retention policy on
+// requests of clusters without index while their keys are scanned
+type unindexedRetention struct {
+	maxAge                time.Duration
+	maxRequestsPerCluster int
+	// indexed caches whether index of the cluster exists by its key
+	indexed map[string]bool
+	// latest contains the most recently received requests of each cluster
+	// found so far, at most maxRequestsPerCluster of them
+	latest map[string]*receivedRequestsHeap
+}
+
+// newUnindexedRetention function creates the state of enforcing retention
+// policy on requests of clusters without index
+func newUnindexedRetention(maxAge time.Duration, maxRequestsPerCluster int) *unindexedRetention {
+	return &unindexedRetention{
+		maxAge:                maxAge,
+		maxRequestsPerCluster: maxRequestsPerCluster,
+		indexed:               map[string]bool{},
+		latest:                map[string]*receivedRequestsHeap{},
+	}
+}
+
+// keep method remembers the request as one of the most recently received
+// requests of its cluster. The least recently received request that no
+// longer fits into the limit is returned, so it can be deleted.
+func (retention *unindexedRetention) keep(key requestKey, receivedAt time.Time) (requestKey, bool) {
+	latest, found := retention.latest[key.indexKey()]
+	if !found {
+		latest = &receivedRequestsHeap{}
+		retention.latest[key.indexKey()] = latest
+	}
+
+	// keys can be returned by SCAN more than once
+	for _, request := range *latest {
+		if request.request.RequestID == string(key.requestID) {
+			return requestKey{}, false
+		}
+	}
+
+	heap.Push(latest, receivedRequest{
+		request: types.ClusterRequestStatus{
+			ClusterID:     key.clusterID,
+			RequestStatus: types.RequestStatus{RequestID: string(key.requestID)},
+		},
+		receivedAt: receivedAt,
+	})
+	if latest.Len() <= retention.maxRequestsPerCluster {
+		return requestKey{}, false
+	}
+
+	evicted := heap.Pop(latest).(receivedRequest)
+	key.requestID = types.RequestID(evicted.request.RequestID)
+	return key, true
+}
+
+// enforceRetentionForUnindexedRequests method applies retention policy to
+// requests with given keys whose clusters have no index. Keys are one batch
+// returned by SCAN, so the limit of requests per cluster is enforced using
+// requests remembered from the previous batches. Number of deleted requests
+// is returned.
+func (redisClient *RedisClient) enforceRetentionForUnindexedRequests(
+	ctx context.Context,
+	keys []string,
+	retention *unindexedRetention,
+) (int, error) {
+	// timestamps are stored in simplified reports, so keys of requests are
+	// skipped to process each request once
+	var requests []requestKey
+	var unknownIndexes []string
+	for _, key := range keys {
+		parsed, ok := parseRequestKey(key)
+		if !ok || !strings.HasSuffix(key, reportsKeySuffix) {
+			continue
+		}
+		requests = append(requests, parsed)
+
+		if _, found := retention.indexed[parsed.indexKey()]; !found {
+			retention.indexed[parsed.indexKey()] = false
+			unknownIndexes = append(unknownIndexes, parsed.indexKey())
+		}
+	}
+
+	if len(unknownIndexes) > 0 {
+		commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+			for _, index := range unknownIndexes {
+				pipe.Exists(ctx, index)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+			return 0, err
+		}
+		for i, cmd := range commands {
+			retention.indexed[unknownIndexes[i]] = cmd.(*redisV9.IntCmd).Val() > 0
+		}
+	}
+
+	// requests of indexed clusters have been processed already
+	var unindexed []requestKey
+	for _, request := range requests {
+		if !retention.indexed[request.indexKey()] {
+			unindexed = append(unindexed, request)
+		}
+	}
+	if len(unindexed) == 0 {
+		return 0, nil
+	}
+
+	// TTL is read so keys already expiring sooner are not prolonged
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, request := range unindexed {
+			pipe.HMGet(ctx, request.reportsKey(), ReceivedTimestampFieldName)
+			pipe.TTL(ctx, request.reportsKey())
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+
+	type requestRetention struct {
+		key       requestKey
+		remaining time.Duration
+	}
+
+	now := time.Now()
+	var toDelete []requestKey
+	var expiring []requestRetention
+
+	for i, request := range unindexed {
+		timestamp, ok := commands[2*i].(*redisV9.SliceCmd).Val()[0].(string)
+		if !ok {
+			// the request has expired in the meantime
+			continue
+		}
+		receivedAt, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			log.Warn().Err(err).Str("requestID", string(request.requestID)).Msg("unable to parse received timestamp")
+			continue
+		}
+
+		remaining := receivedAt.Add(retention.maxAge).Sub(now)
+		if retention.maxAge > 0 && remaining <= 0 {
+			toDelete = append(toDelete, request)
+			continue
+		}
+
+		if retention.maxRequestsPerCluster > 0 {
+			if evicted, ok := retention.keep(request, receivedAt); ok {
+				toDelete = append(toDelete, evicted)
+				if evicted == request {
+					continue
+				}
+			}
+		}
+
+		// negative TTL means that the key does not expire
+		ttl := commands[2*i+1].(*redisV9.DurationCmd).Val()
+		if retention.maxAge > 0 && ttl != keyNotFoundTTL && (ttl < 0 || ttl > remaining) {
+			expiring = append(expiring, requestRetention{key: request, remaining: remaining})
+		}
+	}
+
+	if len(toDelete) == 0 && len(expiring) == 0 {
+		return 0, nil
+	}
+
+	_, err = redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, request := range expiring {
+			pipe.Expire(ctx, request.key.requestIDKey(), request.remaining)
+			pipe.Expire(ctx, request.key.reportsKey(), request.remaining)
+		}
+		for _, key := range toDelete {
+			deleteKeys(ctx, pipe, []string{key.requestIDKey(), key.reportsKey()})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return 0, err
+	}
+
+	return len(toDelete), nil
+}
@@ -0,0 +1,457 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// requestKeys returns key of the request and key of its simplified report
+func requestKeys(orgID types.OrgID, clusterID types.ClusterName, requestID string) (string, string) {
+	return fmt.Sprintf(services.RequestIDCheck, orgID, clusterID, requestID),
+		fmt.Sprintf(services.SimplifiedReportKey, orgID, clusterID, requestID)
+}
+
+// receivedAgo returns received timestamp of request received before given
+// time
+func receivedAgo(ago time.Duration) string {
+	return time.Now().Add(-ago).UTC().Format(time.RFC3339)
+}
+
+// expectExpire expects EXPIRE command for given key. TTL depends on the
+// current time, so it is compared with one second tolerance.
+func expectExpire(server redismock.ClientMock, key string, ttl time.Duration) {
+	server.CustomMatch(func(expected, actual []interface{}) error {
+		if actual[1] != key {
+			return fmt.Errorf("unexpected key %v", actual[1])
+		}
+		seconds, ok := actual[2].(int64)
+		if !ok || seconds < int64(ttl.Seconds())-1 || seconds > int64(ttl.Seconds())+1 {
+			return fmt.Errorf("unexpected TTL %v", actual[2])
+		}
+		return nil
+	}).ExpectExpire(key, ttl).SetVal(true)
+}
+
+// expectRetentionLease expects the retention lease to be acquired with
+// random token. The returned function expects the lease to be released with
+// the same token.
+func expectRetentionLease(server redismock.ClientMock, acquired bool) func() {
+	var token interface{}
+	server.CustomMatch(func(expected, actual []interface{}) error {
+		if len(actual) != len(expected) || actual[1] != expected[1] || actual[3] != expected[3] ||
+			actual[4] != expected[4] || actual[5] != expected[5] {
+			return fmt.Errorf("unexpected command %v", actual)
+		}
+		token = actual[2]
+		return nil
+	}).ExpectSetNX(services.RequestRetentionLeaseKey, "", 10*time.Minute).SetVal(acquired)
+
+	return func() {
+		server.CustomMatch(func(expected, actual []interface{}) error {
+			if len(actual) != len(expected) || actual[1] != expected[1] || actual[3] != expected[3] {
+				return fmt.Errorf("unexpected command %v", actual)
+			}
+			if actual[4] != token {
+				return fmt.Errorf("lease released with token %v instead of %v", actual[4], token)
+			}
+			return nil
+		}).ExpectEvalSha(services.ReleaseLeaseScript.Hash(), []string{services.RequestRetentionLeaseKey}, "").
+			SetVal(int64(1))
+	}
+}
+
+func TestGetRequestsForOrganization(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	request1, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+	_, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID2")
+	_, reports3 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID3")
+
+	received1 := receivedAgo(3 * time.Hour)
+	received2 := receivedAgo(time.Hour)
+
+	server.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{request1, reports1, reports2, reports3}, 0)
+	server.ExpectHMGet(
+		reports1, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID1", received1, received1})
+	server.ExpectHMGet(
+		reports2, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID2", received2, received2})
+	// the request has expired in the meantime
+	server.ExpectHMGet(
+		reports3, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{nil, nil, nil})
+
+	requests, total, err := client.GetRequestsForOrganization(testdata.OrgID, time.Time{}, time.Time{}, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []types.ClusterRequestStatus{
+		{
+			ClusterID: testdata.ClusterName2,
+			RequestStatus: types.RequestStatus{
+				RequestID: "requestID2", Valid: true, Received: received2, Processed: received2,
+			},
+		},
+		{
+			ClusterID: testdata.ClusterName1,
+			RequestStatus: types.RequestStatus{
+				RequestID: "requestID1", Valid: true, Received: received1, Processed: received1,
+			},
+		},
+	}, requests)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestsForOrganization_TimeRange(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	_, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+	_, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID2")
+
+	server.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{reports1, reports2}, 0)
+	server.ExpectHMGet(
+		reports1, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID1", receivedAgo(3 * time.Hour), nil})
+	server.ExpectHMGet(
+		reports2, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetVal([]interface{}{"requestID2", receivedAgo(time.Hour), nil})
+
+	requests, total, err := client.GetRequestsForOrganization(testdata.OrgID, time.Now().Add(-2*time.Hour), time.Now(), 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "requestID2", requests[0].RequestID)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestGetRequestsForOrganization_Page checks that only the requested page
+// of requests scanned in more batches is returned together with the total
+// number of requests
+func TestGetRequestsForOrganization_Page(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	pattern := fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID)
+	_, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+	_, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID2")
+	_, reports3 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID3")
+	_, reports4 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID4")
+
+	expectHMGet := func(key, requestID string, ago time.Duration) {
+		server.ExpectHMGet(
+			key, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+		).SetVal([]interface{}{requestID, receivedAgo(ago), nil})
+	}
+
+	server.ExpectScan(0, pattern, services.ScanBatchCount).SetVal([]string{reports1, reports2}, 2)
+	expectHMGet(reports1, "requestID1", 4*time.Hour)
+	expectHMGet(reports2, "requestID2", time.Hour)
+	server.ExpectScan(2, pattern, services.ScanBatchCount).SetVal([]string{reports3, reports4}, 0)
+	expectHMGet(reports3, "requestID3", 3*time.Hour)
+	expectHMGet(reports4, "requestID4", 2*time.Hour)
+
+	requests, total, err := client.GetRequestsForOrganization(testdata.OrgID, time.Time{}, time.Time{}, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, total)
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "requestID4", requests[0].RequestID)
+		assert.Equal(t, "requestID3", requests[1].RequestID)
+	}
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestsForOrganization_Empty(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{}, 0)
+
+	requests, total, err := client.GetRequestsForOrganization(testdata.OrgID, time.Time{}, time.Time{}, 0, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, requests)
+	assert.Zero(t, total)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestsForOrganization_Error(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	_, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+
+	server.ExpectScan(0, fmt.Sprintf(services.OrgRequestIDsScanPattern, testdata.OrgID), services.ScanBatchCount).
+		SetVal([]string{reports1}, 0)
+	server.ExpectHMGet(
+		reports1, services.RequestIDFieldName, services.ReceivedTimestampFieldName, services.ProcessedTimestampFieldName,
+	).SetErr(errTest)
+
+	_, _, err := client.GetRequestsForOrganization(testdata.OrgID, time.Time{}, time.Time{}, 0, 0)
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestDeleteRequestsForCluster(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	request1, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+	request2, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID2")
+
+	server.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1), services.ScanBatchCount).
+		SetVal([]string{request1, reports1, reports2}, 0)
+	server.ExpectDel(request1).SetVal(1)
+	server.ExpectDel(reports1).SetVal(1)
+	server.ExpectDel(request2).SetVal(0)
+	server.ExpectDel(reports2).SetVal(1)
+	server.ExpectDel(fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)).SetVal(1)
+
+	deleted, err := client.DeleteRequestsForCluster(testdata.OrgID, testdata.ClusterName1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestDeleteRequestsForCluster_NoRequests(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1), services.ScanBatchCount).
+		SetVal([]string{}, 0)
+	server.ExpectDel(fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)).SetVal(0)
+
+	deleted, err := client.DeleteRequestsForCluster(testdata.OrgID, testdata.ClusterName1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestDeleteRequestsForCluster_Error(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	request1, _ := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+
+	server.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1), services.ScanBatchCount).
+		SetVal([]string{request1}, 0)
+	server.ExpectDel(request1).SetErr(errTest)
+
+	_, err := client.DeleteRequestsForCluster(testdata.OrgID, testdata.ClusterName1)
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// indexedAgo returns index member of request received before given time
+func indexedAgo(requestID string, ago time.Duration) redisV9.Z {
+	return redisV9.Z{Score: float64(time.Now().Add(-ago).Unix()), Member: requestID}
+}
+
+func TestEnforceRequestRetention(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	index1 := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)
+	index2 := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID+1, testdata.ClusterName1)
+
+	// four requests of the first cluster, the oldest one exceeds the limit
+	request1, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+	_, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID2")
+	request3, reports3 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID3")
+	request4, reports4 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID4")
+	// requests of another organization, the first one has expired already
+	// and the second one is too old
+	_, reports5 := requestKeys(testdata.OrgID+1, testdata.ClusterName1, "requestID5")
+	request6, reports6 := requestKeys(testdata.OrgID+1, testdata.ClusterName1, "requestID6")
+
+	releaseLease := expectRetentionLease(server, true)
+	server.ExpectScan(0, services.AllRequestIndexesScanPattern, services.ScanBatchCount).
+		SetVal([]string{index1, index2, "organization:1:cluster:2:unrelated"}, 0)
+
+	server.ExpectZRevRangeWithScores(index1, 0, -1).SetVal([]redisV9.Z{
+		indexedAgo("requestID1", time.Hour),
+		indexedAgo("requestID2", 2*time.Hour),
+		indexedAgo("requestID4", 3*time.Hour),
+		indexedAgo("requestID3", 4*time.Hour),
+	})
+	server.ExpectZRevRangeWithScores(index2, 0, -1).SetVal([]redisV9.Z{
+		indexedAgo("requestID5", time.Hour),
+		indexedAgo("requestID6", 48*time.Hour),
+	})
+
+	server.ExpectTTL(reports1).SetVal(-1)
+	server.ExpectTTL(reports2).SetVal(time.Hour)
+	server.ExpectTTL(reports4).SetVal(100 * time.Hour)
+	server.ExpectTTL(reports5).SetVal(-2)
+
+	server.ExpectDel(request3).SetVal(1)
+	server.ExpectDel(reports3).SetVal(1)
+	server.ExpectZRem(index1, "requestID3").SetVal(1)
+	server.ExpectDel(request6).SetVal(1)
+	server.ExpectDel(reports6).SetVal(1)
+	server.ExpectZRem(index2, "requestID6").SetVal(1)
+	server.ExpectZRem(index2, "requestID5").SetVal(1)
+	expectExpire(server, request1, 23*time.Hour)
+	expectExpire(server, reports1, 23*time.Hour)
+	expectExpire(server, request4, 21*time.Hour)
+	expectExpire(server, reports4, 21*time.Hour)
+
+	// requests of indexed clusters are skipped when keys are scanned
+	server.ExpectScan(0, services.AllRequestIDsScanPattern, services.ScanBatchCount).
+		SetVal([]string{request1, reports1}, 0)
+	server.ExpectExists(index1).SetVal(1)
+
+	releaseLease()
+
+	deleted, err := client.EnforceRequestRetention(24*time.Hour, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestEnforceRequestRetention_NothingToChange(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	index1 := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)
+	_, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID1")
+
+	releaseLease := expectRetentionLease(server, true)
+	server.ExpectScan(0, services.AllRequestIndexesScanPattern, services.ScanBatchCount).
+		SetVal([]string{index1}, 0)
+	server.ExpectZRevRangeWithScores(index1, 0, -1).SetVal([]redisV9.Z{indexedAgo("requestID1", time.Hour)})
+	server.ExpectTTL(reports1).SetVal(time.Hour)
+	server.ExpectScan(0, services.AllRequestIDsScanPattern, services.ScanBatchCount).SetVal([]string{}, 0)
+	releaseLease()
+
+	deleted, err := client.EnforceRequestRetention(24*time.Hour, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestEnforceRequestRetention_UnindexedClusters checks that the policy is
+// applied to requests of clusters without index found in more scan batches
+func TestEnforceRequestRetention_UnindexedClusters(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	indexed := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)
+	unindexed := fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName2)
+
+	_, reportsIndexed := requestKeys(testdata.OrgID, testdata.ClusterName1, "requestID0")
+	request1, reports1 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID1")
+	request2, reports2 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID2")
+	request3, reports3 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID3")
+	_, reports4 := requestKeys(testdata.OrgID, testdata.ClusterName2, "requestID4")
+
+	expectReceived := func(key string, ago, ttl time.Duration) {
+		server.ExpectHMGet(key, services.ReceivedTimestampFieldName).SetVal([]interface{}{receivedAgo(ago)})
+		server.ExpectTTL(key).SetVal(ttl)
+	}
+
+	releaseLease := expectRetentionLease(server, true)
+	server.ExpectScan(0, services.AllRequestIndexesScanPattern, services.ScanBatchCount).
+		SetVal([]string{indexed}, 0)
+	server.ExpectZRevRangeWithScores(indexed, 0, -1).SetVal([]redisV9.Z{})
+
+	// the first batch: the first request expires at the age limit, the
+	// second one is too old and the third one is kept for now
+	server.ExpectScan(0, services.AllRequestIDsScanPattern, services.ScanBatchCount).
+		SetVal([]string{reportsIndexed, request1, reports1, reports2, reports3}, 5)
+	server.ExpectExists(indexed).SetVal(1)
+	server.ExpectExists(unindexed).SetVal(0)
+	expectReceived(reports1, time.Hour, -1)
+	expectReceived(reports2, 48*time.Hour, -1)
+	expectReceived(reports3, 3*time.Hour, 100*time.Hour)
+	expectExpire(server, request1, 23*time.Hour)
+	expectExpire(server, reports1, 23*time.Hour)
+	expectExpire(server, request3, 21*time.Hour)
+	expectExpire(server, reports3, 21*time.Hour)
+	server.ExpectDel(request2).SetVal(1)
+	server.ExpectDel(reports2).SetVal(1)
+
+	// the second batch: the newer request exceeds the limit of requests
+	// of the cluster, so the oldest kept request is deleted
+	server.ExpectScan(5, services.AllRequestIDsScanPattern, services.ScanBatchCount).
+		SetVal([]string{reports4}, 0)
+	expectReceived(reports4, 2*time.Hour, time.Hour)
+	server.ExpectDel(request3).SetVal(1)
+	server.ExpectDel(reports3).SetVal(1)
+
+	releaseLease()
+
+	deleted, err := client.EnforceRequestRetention(24*time.Hour, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestEnforceRequestRetention_LeaseHeld(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	// another instance enforces the policy, so nothing is scanned
+	expectRetentionLease(server, false)
+
+	deleted, err := client.EnforceRequestRetention(24*time.Hour, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestEnforceRequestRetention_ScanError(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	releaseLease := expectRetentionLease(server, true)
+	server.ExpectScan(0, services.AllRequestIndexesScanPattern, services.ScanBatchCount).SetErr(errTest)
+	releaseLease()
+
+	_, err := client.EnforceRequestRetention(24*time.Hour, 2)
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestNewRequestRetentionDisabled(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	assert.Nil(t, services.NewRequestRetention(services.RequestRetentionConfiguration{
+		Enabled: false,
+		MaxAge:  time.Hour,
+	}, &client))
+	assert.Nil(t, services.NewRequestRetention(services.RequestRetentionConfiguration{
+		Enabled: true,
+	}, &client))
+	assert.NotNil(t, services.NewRequestRetention(services.RequestRetentionConfiguration{
+		Enabled: true,
+		MaxAge:  time.Hour,
+	}, &client))
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// RequestIDsIndexKey is a key of sorted set with IDs of all requests of the
+// cluster. Requests are scored by their received timestamps (Unix time in
+// seconds).
+var RequestIDsIndexKey = "organization:%v:cluster:%v:requests"
+
+// indexOverFetchFactor is the number of request IDs read from the index for
+// each requested one. IDs of expired requests stay in the index until the
+// retention policy removes them, so more IDs are read and filtered.
+const indexOverFetchFactor = 2
+
+// indexKey method returns the key of index of requests of the cluster
+func (key requestKey) indexKey() string {
+	return fmt.Sprintf(RequestIDsIndexKey, key.orgID, key.clusterID)
+}
+
+// getIndexedRequestIDs method reads IDs of at most limit most recently
+// received requests from the index of requests of the cluster. Zero limit
+// means that all request IDs are read. IDs of requests whose keys have
+// expired already are left out. The second return value is false when the
+// index does not exist. Redis deletes sorted sets without members, so the
+// index exists exactly when at least one member is read.
+func (redisClient *RedisClient) getIndexedRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+	limit int,
+) ([]types.RequestID, bool, error) {
+	key := fmt.Sprintf(RequestIDsIndexKey, orgID, clusterID)
+
+	// stop index -1 means the last member of the set
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(limit*indexOverFetchFactor) - 1
+	}
+	members, err := redisClient.Connection.ZRevRange(ctx, key, 0, stop).Result()
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg(redisCmdExecutionFailedMsg)
+		return nil, false, err
+	}
+	if len(members) == 0 {
+		return nil, false, nil
+	}
+
+	requestIDs, err := redisClient.filterLiveRequestIDs(ctx, orgID, clusterID, members)
+	if err != nil {
+		return nil, false, err
+	}
+	if limit > 0 && len(requestIDs) > limit {
+		requestIDs = requestIDs[:limit]
+	}
+
+	log.Debug().Msgf("retrieved %d request IDs for cluster_id %v from index", len(requestIDs), clusterID)
+	return requestIDs, true, nil
+}
+
+// filterLiveRequestIDs method returns IDs of requests whose keys still
+// exist. The order of IDs is kept.
+func (redisClient *RedisClient) filterLiveRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+	members []string,
+) ([]types.RequestID, error) {
+	requestIDs := make([]types.RequestID, 0, len(members))
+	if len(members) == 0 {
+		return requestIDs, nil
+	}
+
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, member := range members {
+			pipe.Exists(ctx, fmt.Sprintf(RequestIDCheck, orgID, clusterID, member))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	for i, cmd := range commands {
+		if cmd.(*redisV9.IntCmd).Val() > 0 {
+			requestIDs = append(requestIDs, types.RequestID(members[i]))
+		}
+	}
+	return requestIDs, nil
+}
+
+// getLatestRequestIDs method returns IDs of at most limit most recently
+// received requests from the given list. Requests with unknown received
+// timestamp are considered to be the oldest ones.
+func (redisClient *RedisClient) getLatestRequestIDs(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+	requestIDs []types.RequestID,
+	limit int,
+) ([]types.RequestID, error) {
+	commands, err := redisClient.Connection.Pipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		for _, requestID := range requestIDs {
+			pipe.HMGet(ctx, fmt.Sprintf(SimplifiedReportKey, orgID, clusterID, requestID), ReceivedTimestampFieldName)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(redisCmdExecutionFailedMsg)
+		return nil, err
+	}
+
+	received := make(map[types.RequestID]time.Time, len(requestIDs))
+	for i, cmd := range commands {
+		values := cmd.(*redisV9.SliceCmd).Val()
+		if timestamp, ok := values[0].(string); ok {
+			received[requestIDs[i]], _ = time.Parse(time.RFC3339, timestamp)
+		}
+	}
+
+	sort.SliceStable(requestIDs, func(i, j int) bool {
+		return received[requestIDs[i]].After(received[requestIDs[j]])
+	})
+
+	return requestIDs[:limit], nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/testdata"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+var requestIDsIndexKey = fmt.Sprintf(services.RequestIDsIndexKey, testdata.OrgID, testdata.ClusterName1)
+
+// expectRequestExists expects check that keys of the request still exist
+func expectRequestExists(server redismock.ClientMock, requestID string, exists bool) {
+	var count int64
+	if exists {
+		count = 1
+	}
+	server.ExpectExists(fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName1, requestID)).SetVal(count)
+}
+
+func TestGetRequestIDsForClusterID_Index(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	// SCAN is not expected when the index exists
+	server.ExpectZRevRange(requestIDsIndexKey, 0, -1).SetVal([]string{"requestID2", "requestID1"})
+	expectRequestExists(server, "requestID2", true)
+	expectRequestExists(server, "requestID1", true)
+
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RequestID{"requestID2", "requestID1"}, requestIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestIDsForClusterID_IndexLimit(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	// more IDs are read, so expired requests can be left out
+	server.ExpectZRevRange(requestIDsIndexKey, 0, 3).SetVal([]string{"requestID4", "requestID3", "requestID2", "requestID1"})
+	expectRequestExists(server, "requestID4", false)
+	expectRequestExists(server, "requestID3", true)
+	expectRequestExists(server, "requestID2", true)
+	expectRequestExists(server, "requestID1", true)
+
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RequestID{"requestID3", "requestID2"}, requestIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestIDsForClusterID_IndexShorterThanLimit(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	// the index exists, so request keys are not scanned even when it lists
+	// fewer live requests than requested
+	server.ExpectZRevRange(requestIDsIndexKey, 0, 3).SetVal([]string{"requestID2", "requestID1"})
+	expectRequestExists(server, "requestID2", true)
+	expectRequestExists(server, "requestID1", false)
+
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RequestID{"requestID2"}, requestIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestIDsForClusterID_IndexOfExpiredRequests(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectZRevRange(requestIDsIndexKey, 0, -1).SetVal([]string{"requestID1"})
+	expectRequestExists(server, "requestID1", false)
+
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, requestIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestIDsForClusterID_IndexError(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectZRevRange(requestIDsIndexKey, 0, -1).SetErr(errTest)
+
+	_, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 0)
+	assert.Equal(t, errTest, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestGetRequestIDsForClusterID_ScanLimit(t *testing.T) {
+	client, server := helpers.GetMockRedis()
+
+	server.ExpectZRevRange(requestIDsIndexKey, 0, 3).SetVal([]string{})
+
+	keys := make([]string, 3)
+	for i := range keys {
+		keys[i] = fmt.Sprintf(services.RequestIDCheck, testdata.OrgID, testdata.ClusterName1, fmt.Sprintf("requestID%d", i))
+	}
+	server.ExpectScan(0, fmt.Sprintf(services.RequestIDsScanPattern, testdata.OrgID, testdata.ClusterName1), services.ScanBatchCount).
+		SetVal(keys, 0)
+
+	// received timestamps are read to find the most recent requests
+	for i, ago := range []time.Duration{2 * time.Hour, time.Hour, 3 * time.Hour} {
+		key := fmt.Sprintf(services.SimplifiedReportKey, testdata.OrgID, testdata.ClusterName1, fmt.Sprintf("requestID%d", i))
+		server.ExpectHMGet(key, services.ReceivedTimestampFieldName).SetVal([]interface{}{receivedAgo(ago)})
+	}
+
+	requestIDs, err := client.GetRequestIDsForClusterID(testdata.OrgID, testdata.ClusterName1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RequestID{"requestID1", "requestID0"}, requestIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultRetentionInterval is used when the interval of enforcing the
+// retention policy is not configured
+const defaultRetentionInterval = 10 * time.Minute
+
+// RequestRetentionConfiguration represents retention policy of requests
+// stored in Redis
+type RequestRetentionConfiguration struct {
+	Enabled               bool          `mapstructure:"enabled" toml:"enabled"`
+	MaxAge                time.Duration `mapstructure:"max_age" toml:"max_age"`
+	MaxRequestsPerCluster int           `mapstructure:"max_requests_per_cluster" toml:"max_requests_per_cluster"`
+	Interval              time.Duration `mapstructure:"interval" toml:"interval"`
+}
+
+// RequestRetention periodically enforces the retention policy of requests
+// stored in Redis
+type RequestRetention struct {
+	redis                 RedisInterface
+	maxAge                time.Duration
+	maxRequestsPerCluster int
+	interval              time.Duration
+}
+
+// NewRequestRetention function constructs the retention policy enforcer.
+// Nil is returned when the retention policy is disabled or it does not
+// limit anything.
+func NewRequestRetention(conf RequestRetentionConfiguration, redis RedisInterface) *RequestRetention {
+	if !conf.Enabled || redis == nil || (conf.MaxAge <= 0 && conf.MaxRequestsPerCluster <= 0) {
+		return nil
+	}
+
+	interval := conf.Interval
+	if interval <= 0 {
+		interval = defaultRetentionInterval
+	}
+
+	return &RequestRetention{
+		redis:                 redis,
+		maxAge:                conf.MaxAge,
+		maxRequestsPerCluster: conf.MaxRequestsPerCluster,
+		interval:              interval,
+	}
+}
+
+// Run method enforces the retention policy periodically until the stop
+// channel is closed
+func (retention *RequestRetention) Run(stopChannel <-chan struct{}) {
+	ticker := time.NewTicker(retention.interval)
+	defer ticker.Stop()
+	log.Info().
+		Dur("maxAge", retention.maxAge).
+		Int("maxRequestsPerCluster", retention.maxRequestsPerCluster).
+		Msgf("Enforcing retention of requests each %v", retention.interval)
+
+	for {
+		select {
+		case <-stopChannel:
+			log.Info().Msg("Requests retention loop stopped")
+			return
+		case <-ticker.C:
+			retention.Enforce()
+		}
+	}
+}
+
+// Enforce method applies the retention policy to requests of all clusters
+func (retention *RequestRetention) Enforce() {
+	deleted, err := retention.redis.EnforceRequestRetention(retention.maxAge, retention.maxRequestsPerCluster)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to enforce retention of requests")
+		return
+	}
+
+	if deleted > 0 {
+		log.Info().Int("deleted", deleted).Msg("Requests exceeding retention policy deleted")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+var (
+	// RequestKeyspacePattern is a pattern of Redis channels with keyspace
+	// notifications about changes of all request keys
+	RequestKeyspacePattern = "__keyspace@%v__:organization:*:cluster:*:request:*"
+
+	// keyspaceChannelPrefix is the prefix of Redis channels with keyspace
+	// notifications about keys of given database
+	keyspaceChannelPrefix = "__keyspace@%v__:"
+
+	// reportsKeySuffix is the suffix of keys with simplified reports
+	reportsKeySuffix = ":reports"
+)
+
+// requestWatcherBufferSize is the number of notifications buffered for each
+// watcher. Notifications are dropped when the watcher is not able to keep
+// up with them, such changes are detected by periodic checks.
+const requestWatcherBufferSize = 16
+
+// RequestWatcher represents interface for watching changes of requests
+// stored in Redis
+type RequestWatcher interface {
+	// WatchRequests returns channel with IDs of requests that have been
+	// changed. The channel is closed when the context is done.
+	WatchRequests(
+		ctx context.Context,
+		orgID types.OrgID,
+		clusterID types.ClusterName,
+	) (<-chan types.RequestID, error)
+}
+
+// watchedCluster identifies the cluster whose requests are watched
+type watchedCluster struct {
+	orgID     string
+	clusterID types.ClusterName
+}
+
+// KeyspaceRequestWatcher watches changes of requests using Redis keyspace
+// notifications. Notifications have to be enabled on the Redis server by
+// "notify-keyspace-events" option (at least "Kh$" flags are needed to be
+// notified about hash and string commands). One subscription to
+// notifications about all requests is shared by all watchers of the process
+// and notifications are distributed to them in memory. The subscription is
+// opened for the first watcher and closed when the last one is done.
+type KeyspaceRequestWatcher struct {
+	redisClient *RedisClient
+	mutex       sync.RWMutex
+	watchers    map[watchedCluster]map[chan types.RequestID]struct{}
+	// unsubscribe closes the shared subscription, nil when not subscribed
+	unsubscribe context.CancelFunc
+}
+
+// NewRequestWatcher constructs new watcher of requests stored in Redis
+func NewRequestWatcher(redisClient *RedisClient) *KeyspaceRequestWatcher {
+	return &KeyspaceRequestWatcher{
+		redisClient: redisClient,
+		watchers:    make(map[watchedCluster]map[chan types.RequestID]struct{}),
+	}
+}
+
+// WatchRequests method returns channel with IDs of requests of given
+// cluster that have been changed. The shared subscription is opened when
+// there is no other watcher.
+func (watcher *KeyspaceRequestWatcher) WatchRequests(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+) (<-chan types.RequestID, error) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	if watcher.unsubscribe == nil {
+		unsubscribe, err := watcher.subscribe()
+		if err != nil {
+			return nil, err
+		}
+		watcher.unsubscribe = unsubscribe
+	}
+
+	return watcher.register(ctx, orgID, clusterID), nil
+}
+
+// register method adds new watcher of requests of given cluster. The
+// watcher is removed when the context is done. The mutex has to be locked
+// by the caller.
+func (watcher *KeyspaceRequestWatcher) register(
+	ctx context.Context,
+	orgID types.OrgID,
+	clusterID types.ClusterName,
+) <-chan types.RequestID {
+	cluster := watchedCluster{orgID: fmt.Sprint(orgID), clusterID: clusterID}
+	requestIDs := make(chan types.RequestID, requestWatcherBufferSize)
+
+	if watcher.watchers[cluster] == nil {
+		watcher.watchers[cluster] = make(map[chan types.RequestID]struct{})
+	}
+	watcher.watchers[cluster][requestIDs] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		watcher.unregister(cluster, requestIDs)
+	}()
+
+	return requestIDs
+}
+
+// unregister method removes the watcher and closes its channel. The shared
+// subscription is closed together with the last watcher.
+func (watcher *KeyspaceRequestWatcher) unregister(cluster watchedCluster, requestIDs chan types.RequestID) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	delete(watcher.watchers[cluster], requestIDs)
+	if len(watcher.watchers[cluster]) == 0 {
+		delete(watcher.watchers, cluster)
+	}
+	close(requestIDs)
+
+	if len(watcher.watchers) == 0 && watcher.unsubscribe != nil {
+		watcher.unsubscribe()
+		watcher.unsubscribe = nil
+		log.Debug().Msg("no requests are watched, keyspace notifications unsubscribed")
+	}
+}
+
+// subscribe method subscribes to keyspace notifications about all requests.
+// In Redis Cluster all masters are subscribed, because notifications are
+// not propagated across the cluster. The returned function closes the
+// subscription.
+func (watcher *KeyspaceRequestWatcher) subscribe() (context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	database := watcher.redisClient.database()
+	subscriptions, err := watcher.redisClient.subscribe(ctx, fmt.Sprintf(RequestKeyspacePattern, database))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf(keyspaceChannelPrefix, database)
+	for _, pubSub := range subscriptions {
+		go watcher.forward(ctx, pubSub, prefix)
+	}
+
+	log.Debug().Msg("subscribed to keyspace notifications about requests")
+	return cancel, nil
+}
+
+// forward method distributes keyspace notifications to watchers until the
+// context is done. The subscription is closed afterwards.
+func (watcher *KeyspaceRequestWatcher) forward(ctx context.Context, pubSub *redisV9.PubSub, prefix string) {
+	defer func() {
+		if err := pubSub.Close(); err != nil {
+			log.Error().Err(err).Msg("unable to close subscription to keyspace notifications")
+		}
+	}()
+
+	messages := pubSub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			watcher.dispatch(message.Channel, prefix)
+		}
+	}
+}
+
+// dispatch method sends ID of the changed request to all watchers of its
+// cluster. Watchers are never blocked, the notification is dropped for
+// those with full buffer.
+func (watcher *KeyspaceRequestWatcher) dispatch(channel, prefix string) {
+	key, found := requestKeyFromKeyspaceChannel(channel, prefix)
+	if !found {
+		return
+	}
+
+	watcher.mutex.RLock()
+	defer watcher.mutex.RUnlock()
+
+	for requestIDs := range watcher.watchers[watchedCluster{orgID: key.orgID, clusterID: key.clusterID}] {
+		select {
+		case requestIDs <- key.requestID:
+		default:
+			log.Debug().Str("requestID", string(key.requestID)).Msg("watcher is busy, notification dropped")
+		}
+	}
+}
+
+// requestKeyFromKeyspaceChannel function returns the request from the name
+// of channel with keyspace notification. Notifications for both the request
+// key and the key with its simplified report are accepted.
+func requestKeyFromKeyspaceChannel(channel, prefix string) (requestKey, bool) {
+	if !strings.HasPrefix(channel, prefix) {
+		return requestKey{}, false
+	}
+
+	key, ok := parseRequestKey(strings.TrimPrefix(channel, prefix))
+	if !ok || key.requestID == "" {
+		return requestKey{}, false
+	}
+
+	return key, true
+}
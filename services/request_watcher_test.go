@@ -0,0 +1,112 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const keyspacePrefix = "__keyspace@0__:"
+
+// receivedRequestIDs returns IDs of requests waiting in the channel
+func receivedRequestIDs(requestIDs <-chan types.RequestID) []types.RequestID {
+	received := []types.RequestID{}
+	for {
+		select {
+		case requestID := <-requestIDs:
+			received = append(received, requestID)
+		default:
+			return received
+		}
+	}
+}
+
+func TestRequestWatcherDispatch(t *testing.T) {
+	watcher := services.NewRequestWatcher(&services.RedisClient{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := services.RequestWatcherRegister(watcher, ctx, testdata.OrgID, testdata.ClusterName)
+	second := services.RequestWatcherRegister(watcher, ctx, testdata.OrgID, testdata.ClusterName)
+	other := services.RequestWatcherRegister(watcher, ctx, testdata.OrgID, testdata.GetRandomClusterID())
+
+	requestChannel := fmt.Sprintf("%vorganization:%v:cluster:%v:request:", keyspacePrefix, testdata.OrgID, testdata.ClusterName)
+	for _, channel := range []string{
+		requestChannel + "requestID1",
+		requestChannel + "requestID2:reports",
+		requestChannel,
+		requestChannel + "requestID3:other:key",
+		"__keyspace@1__:" + requestChannel[len(keyspacePrefix):] + "requestID4",
+		fmt.Sprintf("%vorganization:2:cluster:%v:request:requestID5", keyspacePrefix, testdata.ClusterName),
+	} {
+		services.RequestWatcherDispatch(watcher, channel, keyspacePrefix)
+	}
+
+	// all watchers of the cluster are notified
+	expected := []types.RequestID{"requestID1", "requestID2"}
+	assert.Equal(t, expected, receivedRequestIDs(first))
+	assert.Equal(t, expected, receivedRequestIDs(second))
+	assert.Empty(t, receivedRequestIDs(other))
+}
+
+func TestRequestWatcherDispatchToBusyWatcher(t *testing.T) {
+	watcher := services.NewRequestWatcher(&services.RedisClient{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requestIDs := services.RequestWatcherRegister(watcher, ctx, testdata.OrgID, testdata.ClusterName)
+
+	// notifications are dropped instead of blocking other watchers
+	channel := fmt.Sprintf("%vorganization:%v:cluster:%v:request:requestID1", keyspacePrefix, testdata.OrgID, testdata.ClusterName)
+	for i := 0; i < 100; i++ {
+		services.RequestWatcherDispatch(watcher, channel, keyspacePrefix)
+	}
+
+	assert.NotEmpty(t, receivedRequestIDs(requestIDs))
+}
+
+func TestRequestWatcherChannelClosed(t *testing.T) {
+	watcher := services.NewRequestWatcher(&services.RedisClient{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	requestIDs := services.RequestWatcherRegister(watcher, ctx, testdata.OrgID, testdata.ClusterName)
+	cancel()
+
+	select {
+	case _, ok := <-requestIDs:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel has not been closed when the context is done")
+	}
+}
+
+func TestRequestWatcherSubscriptionError(t *testing.T) {
+	connection := redisV9.NewClient(&redisV9.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer connection.Close()
+	watcher := services.NewRequestWatcher(&services.RedisClient{Connection: connection})
+
+	_, err := watcher.WatchRequests(context.Background(), testdata.OrgID, testdata.ClusterName)
+	assert.Error(t, err)
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/RedHatInsights/content-service/groups"
 	types "github.com/RedHatInsights/insights-results-types"
 	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
 )
 
 const (
@@ -48,7 +50,7 @@ func getFromURL(endpoint string) (*http.Response, error) {
 
 	log.Debug().Msgf("Connecting to %s", parsedURL.String())
 
-	resp, err := http.Get(parsedURL.String())
+	resp, err := httpclient.Upstream(httpclient.ContentServiceUpstream).Get(parsedURL.String())
 	if err != nil {
 		log.Error().Err(err).Str(urlStr, parsedURL.String()).Msg("Error during retrieve of URL")
 		return nil, err
@@ -19,25 +19,38 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/RedHatInsights/content-service/groups"
 	"github.com/RedHatInsights/insights-operator-utils/logger"
+	redisV9 "github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 
+	"github.com/RedHatInsights/insights-results-smart-proxy/ackexpiry"
 	"github.com/RedHatInsights/insights-results-smart-proxy/amsclient"
+	"github.com/RedHatInsights/insights-results-smart-proxy/audit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/auth"
+	"github.com/RedHatInsights/insights-results-smart-proxy/cache"
 	"github.com/RedHatInsights/insights-results-smart-proxy/conf"
+	"github.com/RedHatInsights/insights-results-smart-proxy/httpclient"
 	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/ratelimit"
 	"github.com/RedHatInsights/insights-results-smart-proxy/server"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
 
 	proxy_content "github.com/RedHatInsights/insights-results-smart-proxy/content"
 )
@@ -58,6 +71,9 @@ const (
 	commandPrintHelp    = "print-help"
 	commandPrintConfig  = "print-config"
 	commandPrintEnv     = "print-env"
+
+	// defaultShutdownTimeout is used when no shutdown timeout is configured
+	defaultShutdownTimeout = 30 * time.Second
 )
 
 const helpMessageTemplate = `
@@ -121,20 +137,30 @@ func startServer() ExitCode {
 	amsConfig := conf.GetAMSClientConfiguration()
 	redisConf := conf.GetRedisConfiguration()
 	rbacCfg := conf.GetRBACConfiguration()
+	responseCacheCfg := conf.GetResponseCacheConfiguration()
+	upstreamsCfg := conf.GetUpstreamsConfiguration()
+	tracingCfg := conf.GetTracingConfiguration()
+	rateLimitCfg := conf.GetRateLimitConfiguration()
+	trendsCfg := conf.GetTrendsConfiguration()
+	webhooksCfg := conf.GetWebhooksConfiguration()
+	ackExpiryCfg := conf.GetAckExpiryConfiguration()
+	auditCfg := conf.GetAuditConfiguration()
+	requestRetentionCfg := conf.GetRequestRetentionConfiguration()
 	groupsChannel := make(chan []groups.Group)
 	errorFoundChannel := make(chan bool)
 	errorChannel := make(chan error)
+	stopLoopsChannel := make(chan struct{})
 
 	if metricsCfg.Namespace != "" {
 		metrics.AddAPIMetricsWithNamespace(metricsCfg.Namespace)
 	}
 
-	amsClient, err := amsclient.NewAMSClient(amsConfig)
+	httpclient.Configure(upstreamsCfg)
+
+	shutdownTracing, err := tracing.Init(tracingCfg)
 	if err != nil {
-		log.Error().Err(err).Msg("Cannot init the AMSClient, using old approach")
-		amsClient = nil
-	} else {
-		log.Info().Msg("AMSClient successfully created")
+		log.Error().Err(err).Msg("failed to initialize tracing")
+		return ExitStatusServerError
 	}
 
 	redisClient, err := services.NewRedisClient(redisConf)
@@ -148,27 +174,205 @@ func startServer() ExitCode {
 	}
 	log.Info().Msg("Redis client created, Redis server is responding")
 
+	// the connection (single server, Sentinel or Cluster client) is shared
+	// by all stores using Redis
+	var redisConnection redisV9.UniversalClient
+	if client, ok := redisClient.(*services.RedisClient); ok {
+		redisConnection = client.Connection
+	}
+
+	// loops and goroutines using the shared Redis connection are tracked,
+	// so the connection is closed only after they stop
+	var redisLoops sync.WaitGroup
+
+	amsClient, err := amsclient.NewAMSClient(amsConfig)
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot init the AMSClient, using old approach")
+		amsClient = nil
+	} else {
+		log.Info().Msg("AMSClient successfully created")
+		if redisConnection != nil && amsConfig.ClusterListCaching {
+			amsClient = amsclient.NewCachingAMSClient(amsClient, redisConnection, amsConfig, &redisLoops)
+		}
+	}
+
 	rbac, err := auth.NewRBACClient(&rbacCfg)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to initialize RBAC client")
 		return ExitStatusServerError
 	}
+	responseCache, err := cache.New(responseCacheCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize response cache")
+		return ExitStatusServerError
+	}
+	rateLimiter, err := ratelimit.New(rateLimitCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize rate limiter")
+		return ExitStatusServerError
+	}
+	trendsStore, err := trends.New(trendsCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize trends store")
+		return ExitStatusServerError
+	}
+	webhookStore, err := webhooks.New(webhooksCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize webhooks store")
+		return ExitStatusServerError
+	}
+	ackExpiryStore, err := ackexpiry.New(ackExpiryCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize acknowledgements expiration store")
+		return ExitStatusServerError
+	}
+	auditStore, err := audit.New(auditCfg, redisConnection)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize audit log")
+		return ExitStatusServerError
+	}
+
 	serverInstance = server.New(serverCfg, servicesCfg, amsClient, redisClient, groupsChannel, errorFoundChannel, errorChannel, rbac)
+	serverInstance.SetResponseCache(responseCache)
+	serverInstance.SetRateLimiter(rateLimiter)
+	serverInstance.SetTrendsStore(trendsStore)
+	serverInstance.SetWebhookStore(webhookStore, webhooksCfg)
+	serverInstance.SetAckExpiryStore(ackExpiryStore)
+	serverInstance.SetAuditStore(auditStore)
+	if client, ok := redisClient.(*services.RedisClient); ok {
+		serverInstance.SetRequestWatcher(services.NewRequestWatcher(client))
+	}
 
 	// fill-in additional info used by /info endpoint handler
 	fillInInfoParams(serverInstance.InfoParams)
 
 	proxy_content.SetContentDirectoryTimeout(servicesCfg.ContentDirectoryTimeout)
-	go updateGroupInfo(servicesCfg, groupsChannel, errorFoundChannel, errorChannel)
+	go updateGroupInfo(servicesCfg, groupsChannel, errorFoundChannel, errorChannel, stopLoopsChannel)
 	go proxy_content.RunUpdateContentLoop(servicesCfg)
+	if webhookStore != nil {
+		evaluator := webhooks.NewEvaluator(webhooksCfg, webhookStore, serverInstance.WebhookHits)
+		runLoop(&redisLoops, func() { evaluator.Run(stopLoopsChannel) })
+	}
+	if ackExpiryStore != nil {
+		sweeper := ackexpiry.NewSweeper(ackExpiryCfg, ackExpiryStore, serverInstance.DeleteExpiredAck)
+		runLoop(&redisLoops, func() { sweeper.Run(stopLoopsChannel) })
+	}
+	if retention := services.NewRequestRetention(requestRetentionCfg, redisClient); retention != nil {
+		runLoop(&redisLoops, func() { retention.Run(stopLoopsChannel) })
+	}
 
-	err = serverInstance.Start()
-	if err != nil {
-		log.Error().Err(err).Msg("HTTP(s) start error")
-		return ExitStatusServerError
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- serverInstance.Start()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err = <-serverErrors:
+		if err != nil {
+			log.Error().Err(err).Msg("HTTP(s) start error")
+			return ExitStatusServerError
+		}
+		return ExitStatusOK
+	case sig := <-signals:
+		log.Info().Str("signal", sig.String()).Msg("Shutdown signal received")
 	}
 
-	return ExitStatusOK
+	return shutdownServer(serverCfg, redisClient, stopLoopsChannel, &redisLoops, shutdownTracing)
+}
+
+// runLoop function starts the background loop in new goroutine tracked by
+// the wait group
+func runLoop(loops *sync.WaitGroup, loop func()) {
+	loops.Add(1)
+	go func() {
+		defer loops.Done()
+		loop()
+	}()
+}
+
+// waitForLoops function waits until all loops tracked by the wait group
+// stop or the context is done. It returns false when the loops were not
+// stopped in time.
+func waitForLoops(ctx context.Context, loops *sync.WaitGroup) bool {
+	stopped := make(chan struct{})
+	go func() {
+		loops.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// shutdownServer function drains in-flight requests, stops all background
+// loops and releases connections to other services. The readiness check
+// starts to fail first, so the load balancer has time (ShutdownDelay) to stop
+// sending new requests before the listener is closed.
+func shutdownServer(serverCfg server.Configuration,
+	redisClient services.RedisInterface,
+	stopLoopsChannel chan struct{},
+	redisLoops *sync.WaitGroup,
+	shutdownTracing func(context.Context) error) ExitCode {
+	exitCode := ExitCode(ExitStatusOK)
+
+	serverInstance.MarkShuttingDown()
+	if serverCfg.ShutdownDelay > 0 {
+		log.Info().Msgf("Waiting %v before the HTTP server is stopped", serverCfg.ShutdownDelay)
+		time.Sleep(serverCfg.ShutdownDelay)
+	}
+
+	timeout := serverCfg.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := serverInstance.Stop(ctx); err != nil {
+		log.Error().Err(err).Msg("HTTP(s) server was not stopped gracefully")
+		exitCode = ExitStatusServerError
+	}
+
+	close(stopLoopsChannel)
+
+	// the content loop might be in the middle of an update, so don't wait
+	// for it longer than the shutdown deadline allows
+	contentLoopStopped := make(chan struct{})
+	go func() {
+		proxy_content.StopUpdateContentLoop()
+		close(contentLoopStopped)
+	}()
+	select {
+	case <-contentLoopStopped:
+	case <-ctx.Done():
+		log.Warn().Msg("Content update loop was not stopped before shutdown deadline")
+	}
+
+	// the connection to Redis is shared by all stores, so it must stay
+	// open until all loops using it are stopped
+	if !waitForLoops(ctx, redisLoops) {
+		log.Warn().Msg("Background loops using Redis were not stopped before shutdown deadline")
+	}
+
+	if err := redisClient.Close(); err != nil {
+		log.Error().Err(err).Msg("Unable to close connection to Redis server")
+		exitCode = ExitStatusServerError
+	}
+
+	// spans that were not exported yet are flushed to the collector
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error().Err(err).Msg("Unable to flush spans to the tracing collector")
+	}
+
+	log.Info().Msg("Smart proxy has been stopped")
+	return exitCode
 }
 
 // fillInInfoParams function fills-in additional info used by /info endpoint
@@ -181,13 +385,14 @@ func fillInInfoParams(params map[string]string) {
 	params["UtilsVersion"] = UtilsVersion
 }
 
-// updateGroupInfo function is run in a goroutine. It runs forever, waiting for 1 of 2 events: a Ticker or a channel
+// updateGroupInfo function is run in a goroutine. It runs until the stop channel is closed, waiting for 1 of 2 events: a Ticker or a channel
 // * If ticker comes first, the groups configuration is updated, doing a request to the content-service
 // * If the channel comes first, the latest valid groups configuration is send through the channel
 func updateGroupInfo(servicesConf services.Configuration,
 	groupsChannel chan []groups.Group,
 	errorFoundChannel chan bool,
-	errorChannel chan error) {
+	errorChannel chan error,
+	stopChannel chan struct{}) {
 	var currentGroups []groups.Group
 	var currentError error
 	var currentErrorFound bool
@@ -212,6 +417,10 @@ func updateGroupInfo(servicesConf services.Configuration,
 
 	for {
 		select {
+		case <-stopChannel:
+			uptimeTicker.Stop()
+			log.Info().Msg("Groups update loop stopped")
+			return
 		case <-uptimeTicker.C:
 			retrievedGroups, err = services.GetGroups(servicesConf)
 			currentGroups, currentErrorFound, currentError = handleGroupError(err, currentGroups, retrievedGroups)
@@ -19,8 +19,11 @@ limitations under the License.
 package main_test
 
 import (
+	"context"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/RedHatInsights/insights-operator-utils/tests/helpers"
 	"github.com/stretchr/testify/assert"
@@ -115,3 +118,18 @@ func TestHandleCommand(t *testing.T) {
 	assert.Equal(t, int(main.HandleCommand("print-config")), main.ExitStatusOK)
 	assert.Equal(t, int(main.HandleCommand("print-env")), main.ExitStatusOK)
 }
+
+// TestWaitForLoops checks that the shutdown waits for background loops, but
+// not longer than the deadline allows
+func TestWaitForLoops(t *testing.T) {
+	var loops sync.WaitGroup
+	stop := make(chan struct{})
+	main.RunLoop(&loops, func() { <-stop })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, main.WaitForLoops(ctx, &loops))
+
+	close(stop)
+	assert.True(t, main.WaitForLoops(context.Background(), &loops))
+}
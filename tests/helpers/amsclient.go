@@ -15,6 +15,7 @@
 package helpers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/RedHatInsights/insights-results-aggregator-data/testdata"
@@ -26,8 +27,9 @@ import (
 )
 
 type mockAMSClient struct {
-	clustersPerOrg map[types.OrgID][]types.ClusterInfo
-	errorToReturn  error
+	clustersPerOrg   map[types.OrgID][]types.ClusterInfo
+	errorToReturn    error
+	healthCheckError error
 }
 
 func (m *mockAMSClient) GetClustersForOrganization(
@@ -81,6 +83,11 @@ func (m *mockAMSClient) GetSingleClusterInfoForOrganization(
 	return
 }
 
+// HealthCheck method returns error set up by AMSClientWithHealthCheckError
+func (m *mockAMSClient) HealthCheck(context.Context) error {
+	return m.healthCheckError
+}
+
 // AMSClientWithOrgResults creates a mock of AMSClient interface that returns the results
 // defined by orgID and clusters parameters
 func AMSClientWithOrgResults(orgID types.OrgID, clusters []types.ClusterInfo) amsclient.AMSClient {
@@ -103,3 +110,11 @@ func AMSClientWithError(errorMessage string) amsclient.AMSClient {
 		errorToReturn: fmt.Errorf("%s", errorMessage),
 	}
 }
+
+// AMSClientWithHealthCheckError creates a mock of AMSClient interface that
+// reports unavailable AMS API when HealthCheck is called
+func AMSClientWithHealthCheckError(errorMessage string) amsclient.AMSClient {
+	return &mockAMSClient{
+		healthCheckError: fmt.Errorf("%s", errorMessage),
+	}
+}
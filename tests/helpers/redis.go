@@ -15,9 +15,9 @@
 package helpers
 
 import (
+	"fmt"
 	"testing"
 
-	redisutils "github.com/RedHatInsights/insights-operator-utils/redis"
 	"github.com/RedHatInsights/insights-results-smart-proxy/services"
 
 	"github.com/go-redis/redismock/v9"
@@ -44,7 +44,7 @@ func GetMockRedis() (
 ) {
 	client, mockServer := redismock.NewClientMock()
 	mockClient = services.RedisClient{
-		Client: redisutils.Client{Connection: client},
+		Connection: client,
 	}
 	return
 }
@@ -55,3 +55,10 @@ func RedisExpectationsMet(t *testing.T, mock redismock.ClientMock) {
 		t.Error(err)
 	}
 }
+
+// ExpectMissingRequestIDsIndex helper function expects reading of all IDs
+// from index of requests of the cluster that has not been populated yet, so
+// the request IDs are scanned afterwards
+func ExpectMissingRequestIDsIndex(mock redismock.ClientMock, orgID, clusterID interface{}) {
+	mock.ExpectZRevRange(fmt.Sprintf(services.RequestIDsIndexKey, orgID, clusterID), 0, -1).SetVal([]string{})
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+// Configuration represents configuration of OpenTelemetry tracing
+type Configuration struct {
+	// Enabled turns on export of spans
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Endpoint is host and port of the OTLP/HTTP collector
+	Endpoint string `mapstructure:"endpoint" toml:"endpoint"`
+	// Insecure disables TLS for connection to the collector
+	Insecure bool `mapstructure:"insecure" toml:"insecure"`
+	// SampleRatio is the ratio of traces started by this service that are
+	// sampled, traces started by callers follow their sampling decision
+	SampleRatio float64 `mapstructure:"sample_ratio" toml:"sample_ratio"`
+	// ServiceName is reported as service.name resource attribute
+	ServiceName string `mapstructure:"service_name" toml:"service_name"`
+}
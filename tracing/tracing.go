@@ -0,0 +1,144 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing contains OpenTelemetry setup and helpers used to trace
+// requests handled by the smart proxy and calls to other services.
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// TracerName is the name of tracer used by the smart proxy
+	TracerName = "github.com/RedHatInsights/insights-results-smart-proxy"
+
+	defaultServiceName = "insights-results-smart-proxy"
+)
+
+// attribute keys used in spans
+const (
+	OrgIDKey        = attribute.Key("org_id")
+	ClusterCountKey = attribute.Key("cluster.count")
+	RuleCountKey    = attribute.Key("rule.count")
+)
+
+// Init function sets up global tracer provider exporting spans to the OTLP
+// collector and W3C trace context propagation. Returned function flushes
+// spans that were not exported yet and stops the exporter.
+func Init(conf Configuration) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	if !conf.Enabled {
+		log.Info().Msg("Tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if conf.Endpoint == "" {
+		return nil, errors.New("tracing endpoint is not configured")
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Endpoint)}
+	if conf.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewTracerProvider(conf, sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+
+	log.Info().Str("endpoint", conf.Endpoint).Float64("sampleRatio", conf.SampleRatio).Msg("Tracing is enabled")
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider function constructs tracer provider with the sampler and
+// resource taken from configuration. Span processors (exporters) are passed
+// as options.
+func NewTracerProvider(conf Configuration, options ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	serviceName := conf.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	sampleRatio := conf.SampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+
+	options = append(options,
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	return sdktrace.NewTracerProvider(options...)
+}
+
+// Tracer function returns tracer used by the smart proxy
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan function starts new span as a child of the span stored in the
+// context
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan function records error (if any) and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SetAttributes function sets attributes of the span stored in the context
+func SetAttributes(ctx context.Context, attributes ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attributes...)
+}
+
+// OrgID function returns attribute with organization ID
+func OrgID(orgID types.OrgID) attribute.KeyValue {
+	return OrgIDKey.Int64(int64(orgID))
+}
+
+// ClusterCount function returns attribute with number of clusters
+func ClusterCount(count int) attribute.KeyValue {
+	return ClusterCountKey.Int(count)
+}
+
+// RuleCount function returns attribute with number of rules
+func RuleCount(count int) attribute.KeyValue {
+	return RuleCountKey.Int(count)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/tracing"
+)
+
+// useInMemoryExporter sets global tracer provider that stores spans in
+// memory. Original provider is restored when the test finishes.
+func useInMemoryExporter(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewTracerProvider(tracing.Configuration{}, sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(original) })
+	return exporter
+}
+
+// TestStartSpan checks that spans are created as children of the span
+// stored in context and contain the given attributes
+func TestStartSpan(t *testing.T) {
+	exporter := useInMemoryExporter(t)
+
+	ctx, parent := tracing.StartSpan(context.Background(), "parent", tracing.OrgID(42))
+	_, child := tracing.StartSpan(ctx, "child")
+	tracing.SetAttributes(ctx, tracing.ClusterCount(3), tracing.RuleCount(5))
+	tracing.EndSpan(child, nil)
+	tracing.EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "child", childSpan.Name)
+	assert.Equal(t, parentSpan.SpanContext.SpanID(), childSpan.Parent.SpanID())
+	assert.Equal(t, parentSpan.SpanContext.TraceID(), childSpan.SpanContext.TraceID())
+
+	assert.ElementsMatch(t, parentSpan.Attributes, []attribute.KeyValue{
+		tracing.OrgID(42), tracing.ClusterCount(3), tracing.RuleCount(5),
+	})
+}
+
+// TestEndSpanWithError checks that error is recorded in the span
+func TestEndSpanWithError(t *testing.T) {
+	exporter := useInMemoryExporter(t)
+
+	_, span := tracing.StartSpan(context.Background(), "failing")
+	tracing.EndSpan(span, errors.New("test error"))
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "test error", spans[0].Status.Description)
+	assert.Len(t, spans[0].Events, 1)
+}
+
+// TestInitDisabled checks that the trace context is propagated even when
+// export of spans is disabled
+func TestInitDisabled(t *testing.T) {
+	shutdown, err := tracing.Init(tracing.Configuration{Enabled: false})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+}
+
+// TestInitWithoutEndpoint checks that the collector endpoint is required
+// when tracing is enabled
+func TestInitWithoutEndpoint(t *testing.T) {
+	_, err := tracing.Init(tracing.Configuration{Enabled: true})
+	assert.EqualError(t, err, "tracing endpoint is not configured")
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trends
+
+import (
+	"time"
+)
+
+// Configuration represents the configuration of recording of organization
+// overview snapshots used by trends endpoint
+type Configuration struct {
+	// Enabled turns on recording of the snapshots
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Storage selects where the snapshots are stored: "memory" (each
+	// instance has its own history, useful for development) or "redis"
+	Storage string `mapstructure:"storage" toml:"storage"`
+	// Retention is the time snapshots are kept for
+	Retention time.Duration `mapstructure:"retention" toml:"retention"`
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trends
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// MemoryStore is a Store implementation that keeps snapshots in memory of
+// the service instance
+type MemoryStore struct {
+	mutex     sync.RWMutex
+	retention time.Duration
+	snapshots map[types.OrgID]map[string]Snapshot
+}
+
+// NewMemoryStore function constructs new in-memory store
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		retention: retention,
+		snapshots: make(map[types.OrgID]map[string]Snapshot),
+	}
+}
+
+// Record method stores the snapshot and removes snapshots of the
+// organization older than the retention
+func (store *MemoryStore) Record(orgID types.OrgID, snapshot *Snapshot) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	orgSnapshots, found := store.snapshots[orgID]
+	if !found {
+		orgSnapshots = make(map[string]Snapshot)
+		store.snapshots[orgID] = orgSnapshots
+	}
+	orgSnapshots[snapshot.Date] = *snapshot
+
+	oldest := Date(time.Now().Add(-store.retention))
+	for date := range orgSnapshots {
+		if date < oldest {
+			delete(orgSnapshots, date)
+		}
+	}
+
+	return nil
+}
+
+// List method returns snapshots of the organization in given range
+func (store *MemoryStore) List(orgID types.OrgID, from, to time.Time) ([]Snapshot, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	snapshots := make([]Snapshot, 0)
+	for date, snapshot := range store.snapshots[orgID] {
+		if inRange(date, from, to) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sortSnapshots(snapshots)
+
+	return snapshots, nil
+}
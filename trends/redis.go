@@ -0,0 +1,111 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// RedisSnapshotsKey is a key pattern for hash with the snapshots of one
+// organization. Organization ID is used as a parameter. Dates are used as
+// fields of the hash.
+const RedisSnapshotsKey = "smart-proxy:trends:%v"
+
+// RedisStore is a Store implementation that keeps snapshots in Redis
+type RedisStore struct {
+	connection redisV9.Cmdable
+	retention  time.Duration
+}
+
+// NewRedisStore function constructs new Redis store
+func NewRedisStore(connection redisV9.Cmdable, retention time.Duration) *RedisStore {
+	return &RedisStore{
+		connection: connection,
+		retention:  retention,
+	}
+}
+
+// Record method stores the snapshot into the hash of the organization and
+// removes snapshots older than the retention. The whole hash expires when no
+// snapshot is recorded during the retention period.
+func (store *RedisStore) Record(orgID types.OrgID, snapshot *Snapshot) error {
+	ctx := context.Background()
+	key := fmt.Sprintf(RedisSnapshotsKey, orgID)
+
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	// dates are read outside of the transaction on purpose: only dates
+	// older than the retention are deleted and snapshots are always
+	// recorded for today, so a concurrent Record can't add a date that
+	// would be deleted here and deleting the same date twice is harmless
+	dates, err := store.connection.HKeys(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	oldest := Date(time.Now().Add(-store.retention))
+	expired := make([]string, 0)
+	for _, date := range dates {
+		if date < oldest {
+			expired = append(expired, date)
+		}
+	}
+
+	_, err = store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.HSet(ctx, key, snapshot.Date, value)
+		if len(expired) > 0 {
+			pipe.HDel(ctx, key, expired...)
+		}
+		pipe.Expire(ctx, key, store.retention)
+		return nil
+	})
+	return err
+}
+
+// List method returns snapshots of the organization in given range
+func (store *RedisStore) List(orgID types.OrgID, from, to time.Time) ([]Snapshot, error) {
+	values, err := store.connection.HGetAll(context.Background(), fmt.Sprintf(RedisSnapshotsKey, orgID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(values))
+	for date, value := range values {
+		if !inRange(date, from, to) {
+			continue
+		}
+
+		var snapshot Snapshot
+		if err := json.Unmarshal([]byte(value), &snapshot); err != nil {
+			log.Error().Err(err).Str("date", date).Msg("Unable to parse trends snapshot stored in Redis")
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sortSnapshots(snapshots)
+
+	return snapshots, nil
+}
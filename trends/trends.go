@@ -0,0 +1,133 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trends contains implementation of the history of organization
+// overviews. One snapshot with numbers of affected clusters and hits is
+// recorded for each organization and day, so the trend of the health of the
+// fleet can be displayed.
+package trends
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// MemoryStorage selects snapshots stored in memory of the service
+	// instance
+	MemoryStorage = "memory"
+	// RedisStorage selects snapshots stored in Redis, shared by all
+	// instances
+	RedisStorage = "redis"
+
+	// DateFormat is the format of the day the snapshot belongs to
+	DateFormat = time.DateOnly
+
+	// DefaultRetention is used when the retention is not configured
+	DefaultRetention = 90 * 24 * time.Hour
+)
+
+// Snapshot represents the overview of the organization recorded for one day
+type Snapshot struct {
+	// Date is the day the snapshot belongs to in DateFormat
+	Date string `json:"date"`
+	// ClustersHit is the number of clusters hit by at least one rule
+	ClustersHit int `json:"clusters_hit"`
+	// HitsByTotalRisk contains number of hits for each total risk
+	HitsByTotalRisk map[int]int `json:"hit_by_risk"`
+	// HitsByRule contains number of clusters hit by each rule
+	HitsByRule map[types.RuleID]int `json:"hit_by_rule"`
+}
+
+// Store represents storage of the snapshots
+type Store interface {
+	// Record stores the snapshot of the organization. Snapshot recorded
+	// for the same day before is replaced.
+	Record(orgID types.OrgID, snapshot *Snapshot) error
+	// List returns snapshots of the organization recorded between from
+	// and to (both included) ordered by date
+	List(orgID types.OrgID, from, to time.Time) ([]Snapshot, error)
+}
+
+// New function constructs the snapshot store selected in configuration.
+// Redis connection is used only for the Redis storage. Nil is returned
+// when recording of snapshots is disabled.
+func New(conf Configuration, connection redisV9.UniversalClient) (Store, error) {
+	if !conf.Enabled {
+		log.Info().Msg("Recording of trends is disabled")
+		return nil, nil
+	}
+
+	retention := conf.Retention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	switch conf.Storage {
+	case "", MemoryStorage:
+		log.Info().Msg("Using in-memory store for trends")
+		return NewMemoryStore(retention), nil
+	case RedisStorage:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Msg("Using Redis store for trends")
+		return NewRedisStore(connection, retention), nil
+	default:
+		return nil, fmt.Errorf("unknown trends storage '%s'", conf.Storage)
+	}
+}
+
+// Date function returns the day of given time in DateFormat
+func Date(t time.Time) string {
+	return t.UTC().Format(DateFormat)
+}
+
+// inRange function checks if the date of the snapshot is between from and
+// to (both included)
+func inRange(date string, from, to time.Time) bool {
+	return date >= Date(from) && date <= Date(to)
+}
+
+// MissingDates function returns days between the first and the last of
+// given snapshots ordered by date for which no snapshot has been recorded.
+// Snapshots are recorded only when the overview of the organization is
+// computed, so the history can contain such gaps.
+func MissingDates(snapshots []Snapshot) []string {
+	missing := make([]string, 0)
+	for i := 1; i < len(snapshots); i++ {
+		day, err := time.Parse(DateFormat, snapshots[i-1].Date)
+		if err != nil {
+			continue
+		}
+		for day = day.AddDate(0, 0, 1); Date(day) < snapshots[i].Date; day = day.AddDate(0, 0, 1) {
+			missing = append(missing, Date(day))
+		}
+	}
+	return missing
+}
+
+// sortSnapshots function orders snapshots by date
+func sortSnapshots(snapshots []Snapshot) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Date < snapshots[j].Date
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trends_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/trends"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const testOrgID = 42
+
+var testKey = fmt.Sprintf(trends.RedisSnapshotsKey, testOrgID)
+
+// snapshotFor returns snapshot for the day that is given number of days ago
+func snapshotFor(daysAgo, clustersHit int) trends.Snapshot {
+	return trends.Snapshot{
+		Date:            trends.Date(time.Now().AddDate(0, 0, -daysAgo)),
+		ClustersHit:     clustersHit,
+		HitsByTotalRisk: map[int]int{1: clustersHit},
+		HitsByRule:      map[types.RuleID]int{"rule.module|ERROR_KEY": clustersHit},
+	}
+}
+
+// TestNewStore checks that the storage selected in configuration is used
+func TestNewStore(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	store, err := trends.New(trends.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = trends.New(trends.Configuration{Enabled: true}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &trends.MemoryStore{}, store)
+
+	store, err = trends.New(trends.Configuration{Enabled: true, Storage: trends.RedisStorage}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &trends.RedisStore{}, store)
+
+	_, err = trends.New(trends.Configuration{Enabled: true, Storage: trends.RedisStorage}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = trends.New(trends.Configuration{Enabled: true, Storage: "disk"}, client.Connection)
+	assert.EqualError(t, err, "unknown trends storage 'disk'")
+}
+
+// TestMemoryStore checks that snapshots are replaced per day, listed in
+// order and removed after the retention
+func TestMemoryStore(t *testing.T) {
+	store := trends.NewMemoryStore(7 * 24 * time.Hour)
+
+	for _, snapshot := range []trends.Snapshot{
+		snapshotFor(0, 1), snapshotFor(2, 2), snapshotFor(10, 3), snapshotFor(0, 4),
+	} {
+		assert.NoError(t, store.Record(testOrgID, &snapshot))
+	}
+
+	snapshots, err := store.List(testOrgID, time.Now().AddDate(0, 0, -30), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []trends.Snapshot{snapshotFor(2, 2), snapshotFor(0, 4)}, snapshots)
+
+	snapshots, err = store.List(testOrgID, time.Now().AddDate(0, 0, -3), time.Now().AddDate(0, 0, -1))
+	assert.NoError(t, err)
+	assert.Equal(t, []trends.Snapshot{snapshotFor(2, 2)}, snapshots)
+
+	snapshots, err = store.List(testOrgID+1, time.Now().AddDate(0, 0, -30), time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+// TestRedisStoreRecord checks that snapshot is stored into the hash and
+// snapshots older than the retention are removed
+func TestRedisStoreRecord(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := trends.NewRedisStore(client, 7*24*time.Hour)
+
+	snapshot := snapshotFor(0, 1)
+	value, err := json.Marshal(snapshot)
+	helpers.FailOnError(t, err)
+	expired := snapshotFor(10, 1).Date
+
+	server.ExpectHKeys(testKey).SetVal([]string{snapshotFor(2, 1).Date, expired})
+	server.ExpectTxPipeline()
+	server.ExpectHSet(testKey, snapshot.Date, value).SetVal(1)
+	server.ExpectHDel(testKey, expired).SetVal(1)
+	server.ExpectExpire(testKey, 7*24*time.Hour).SetVal(true)
+	server.ExpectTxPipelineExec()
+
+	assert.NoError(t, store.Record(testOrgID, &snapshot))
+
+	server.ExpectHKeys(testKey).SetErr(errors.New("connection refused"))
+	assert.Error(t, store.Record(testOrgID, &snapshot))
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestRedisStoreList checks that snapshots in range are read from the hash
+func TestRedisStoreList(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := trends.NewRedisStore(client, 7*24*time.Hour)
+
+	values := map[string]string{}
+	for _, snapshot := range []trends.Snapshot{snapshotFor(0, 1), snapshotFor(1, 2), snapshotFor(5, 3)} {
+		value, err := json.Marshal(snapshot)
+		helpers.FailOnError(t, err)
+		values[snapshot.Date] = string(value)
+	}
+	values[snapshotFor(3, 0).Date] = "not a snapshot"
+
+	server.ExpectHGetAll(testKey).SetVal(values)
+	snapshots, err := store.List(testOrgID, time.Now().AddDate(0, 0, -4), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []trends.Snapshot{snapshotFor(1, 2), snapshotFor(0, 1)}, snapshots)
+
+	server.ExpectHGetAll(testKey).SetErr(errors.New("connection refused"))
+	_, err = store.List(testOrgID, time.Now().AddDate(0, 0, -4), time.Now())
+	assert.Error(t, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+// TestMissingDates checks that days without snapshot between the first and
+// the last snapshot are returned
+func TestMissingDates(t *testing.T) {
+	assert.Empty(t, trends.MissingDates(nil))
+	assert.Empty(t, trends.MissingDates([]trends.Snapshot{snapshotFor(3, 1)}))
+	assert.Empty(t, trends.MissingDates([]trends.Snapshot{snapshotFor(1, 1), snapshotFor(0, 1)}))
+
+	assert.Equal(t, []string{
+		trends.Date(time.Now().AddDate(0, 0, -4)),
+		trends.Date(time.Now().AddDate(0, 0, -3)),
+		trends.Date(time.Now().AddDate(0, 0, -1)),
+	}, trends.MissingDates([]trends.Snapshot{snapshotFor(5, 1), snapshotFor(2, 1), snapshotFor(0, 1)}))
+}
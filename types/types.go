@@ -39,6 +39,9 @@ type RuleContent = types.RuleContent
 // RuleID is a rename for types.RuleID
 type RuleID = types.RuleID
 
+// RuleSelector is a rename for types.RuleSelector
+type RuleSelector = types.RuleSelector
+
 // ClusterName is a rename for types.ClusterName
 type ClusterName = types.ClusterName
 
@@ -211,6 +214,36 @@ type RecommendationListView struct {
 	ImpactedClustersCnt uint32       `json:"impacted_clusters_count"`
 }
 
+// ListMeta contains information about the page of the list returned in
+// response
+type ListMeta struct {
+	// Count is the number of items in the response
+	Count int `json:"count"`
+	// Total is the number of items matching the filters
+	Total int `json:"total"`
+	// Limit is the maximum number of items in the response, zero if the
+	// number of items is not limited
+	Limit int `json:"limit"`
+	// Offset is the number of skipped items
+	Offset int `json:"offset"`
+}
+
+// ClusterListMeta contains information about the page of the clusters list
+// returned in response
+type ClusterListMeta struct {
+	// Count is the number of clusters in the response
+	Count int `json:"count"`
+	// Total is the number of all clusters of the organization
+	Total int `json:"total"`
+	// Filtered is the number of clusters matching the filters
+	Filtered int `json:"filtered"`
+	// Limit is the maximum number of clusters in the response, zero if the
+	// number of clusters is not limited
+	Limit int `json:"limit"`
+	// Offset is the number of skipped clusters
+	Offset int `json:"offset"`
+}
+
 // ClusterListView represents a single item in the response for Clusters List view
 type ClusterListView struct {
 	ClusterID       types.ClusterName `json:"cluster_id"`
@@ -243,6 +276,20 @@ type RuleErrorKeyContentV2 = types.RuleErrorKeyContentV2
 // ErrorKeyMetadataV2 is in RuleErrorKeyContentV2
 type ErrorKeyMetadataV2 = types.ErrorKeyMetadataV2
 
+// Acknowledgement represents acknowledgement of rule returned to client.
+// Expiration is managed by Smart Proxy, it is not stored in aggregator.
+type Acknowledgement struct {
+	types.Acknowledgement
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// AcknowledgementsResponse represents list of acknowledgements returned to
+// client
+type AcknowledgementsResponse struct {
+	Metadata types.AcknowledgementsMetadata `json:"meta"`
+	Data     []Acknowledgement              `json:"data"`
+}
+
 // InfoResponse is a data structure returned by /info REST API endpoint
 type InfoResponse struct {
 	SmartProxy     map[string]string `json:"SmartProxy"`
@@ -281,6 +328,48 @@ type RequestStatus struct {
 	Processed string `json:"processed" redis:"processed_timestamp"`
 }
 
+// ClusterRequestStatus structure represents status of On Demand Data
+// Gathering request together with the cluster it was sent by
+type ClusterRequestStatus struct {
+	ClusterID ClusterName `json:"cluster"`
+	RequestStatus
+}
+
+// ClusterLatestRequest structure represents the most recently received On
+// Demand Data Gathering request of a cluster together with its rule hits
+type ClusterLatestRequest struct {
+	RequestStatus
+	RuleHits []RuleID
+}
+
+// RuleHitsSummary structure represents number of rule hits found by On
+// Demand Data Gathering request, also grouped by their total risk
+type RuleHitsSummary struct {
+	TotalHitCount   int         `json:"total_hit_count"`
+	HitsByTotalRisk map[int]int `json:"hits_by_total_risk"`
+}
+
+// ClusterLatestRequestStatus structure represents status of the latest On
+// Demand Data Gathering request of a cluster. Request related attributes are
+// omitted when the cluster has not sent any request yet.
+type ClusterLatestRequestStatus struct {
+	ClusterID ClusterName      `json:"cluster"`
+	Status    string           `json:"status"`
+	RequestID string           `json:"requestID,omitempty"`
+	Received  string           `json:"received,omitempty"`
+	Processed string           `json:"processed,omitempty"`
+	RuleHits  *RuleHitsSummary `json:"rule_hits,omitempty"`
+}
+
+// RequestStatusEvent structure represents change of the status of On Demand
+// Data Gathering request sent to clients watching the cluster
+type RequestStatusEvent struct {
+	RequestID string `json:"requestID"`
+	Status    string `json:"status"`
+	Received  string `json:"received"`
+	Processed string `json:"processed,omitempty"`
+}
+
 // SimplifiedRuleHit structure represents one simplified rule hit for On Demand Data Gathering
 type SimplifiedRuleHit struct {
 	RuleFQDN    string `json:"rule_fqdn"`
@@ -288,3 +377,11 @@ type SimplifiedRuleHit struct {
 	Description string `json:"description"`
 	TotalRisk   int    `json:"total_risk"`
 }
+
+// SimplifiedReportDiff structure represents differences between simplified
+// rule hits of two On Demand Data Gathering requests
+type SimplifiedReportDiff struct {
+	Appeared    []SimplifiedRuleHit `json:"appeared"`
+	Disappeared []SimplifiedRuleHit `json:"disappeared"`
+	Persisted   []SimplifiedRuleHit `json:"persisted"`
+}
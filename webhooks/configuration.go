@@ -0,0 +1,42 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"time"
+)
+
+// Configuration represents the configuration of webhook notifications
+type Configuration struct {
+	// Enabled turns on webhook endpoints and the background evaluator
+	Enabled bool `mapstructure:"enabled" toml:"enabled"`
+	// Storage selects where registered webhooks and last seen hits are
+	// stored: "memory" (useful for development only) or "redis"
+	Storage string `mapstructure:"storage" toml:"storage"`
+	// Interval is the time between two evaluations of recommendations
+	// hitting clusters of organizations with registered webhooks
+	Interval time.Duration `mapstructure:"interval" toml:"interval"`
+	// Timeout is the maximum time of one attempt to deliver notification
+	Timeout time.Duration `mapstructure:"timeout" toml:"timeout"`
+	// MaxRetries is the number of retries of failed deliveries
+	MaxRetries int `mapstructure:"max_retries" toml:"max_retries"`
+	// RetryBackoff is the delay before the first retry, it is doubled with
+	// each retry
+	RetryBackoff time.Duration `mapstructure:"retry_backoff" toml:"retry_backoff"`
+	// AllowInsecureEndpoints allows http endpoints and endpoints on
+	// loopback, private, carrier-grade NAT or link-local addresses (useful
+	// for development only)
+	AllowInsecureEndpoints bool `mapstructure:"allow_insecure_endpoints" toml:"allow_insecure_endpoints"`
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/metrics"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// SignatureHeader contains HMAC-SHA256 signature of the payload
+	// computed with the secret of the webhook, in "sha256=<hex>" format
+	SignatureHeader = "X-Insights-Signature"
+	// DeliveryHeader contains ID of the notification derived from the
+	// organization, the webhook and the notified hits. It is the same for
+	// all attempts and all instances, so receivers can ignore duplicates.
+	DeliveryHeader = "X-Insights-Delivery"
+
+	// NewHitsEvent is sent when new recommendations hit clusters of the
+	// organization
+	NewHitsEvent = "new_recommendation_hits"
+
+	// default values used when delivery is not configured
+	defaultTimeout      = 10 * time.Second
+	defaultRetryBackoff = time.Second
+)
+
+// Payload represents the notification sent to webhook endpoint
+type Payload struct {
+	Event      string      `json:"event"`
+	DeliveryID string      `json:"delivery_id"`
+	WebhookID  string      `json:"webhook_id"`
+	OrgID      types.OrgID `json:"org_id"`
+	Timestamp  time.Time   `json:"timestamp"`
+	Hits       []Hit       `json:"hits"`
+}
+
+// Sign function computes signature of the payload sent in SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	// writing to hash never returns an error
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender delivers notifications to webhook endpoints
+type Sender struct {
+	client       *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// NewSender function constructs sender with timeouts and retries taken from
+// the configuration. Connections to addresses that are not public are
+// refused unless insecure endpoints are allowed.
+func NewSender(conf Configuration) *Sender {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	retryBackoff := conf.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !conf.AllowInsecureEndpoints {
+		dialer.Control = checkDialedAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// connections through proxy would be made to the proxy address, so
+	// the dialed address check would not apply to the endpoint
+	transport.Proxy = nil
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// redirects are not followed, otherwise the endpoint could
+		// redirect notifications to internal services
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &Sender{
+		client:       client,
+		maxRetries:   conf.MaxRetries,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// Send method posts the payload to the webhook endpoint. Failed deliveries
+// are retried when the endpoint is not reachable, responds with server error
+// or asks to slow down.
+func (sender *Sender) Send(ctx context.Context, webhook *Webhook, payload *Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	backoff := sender.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := sender.send(ctx, webhook, payload.DeliveryID, body)
+		if err == nil {
+			metrics.WebhookDeliveries.WithLabelValues("delivered").Inc()
+			return nil
+		}
+
+		if !retry || attempt >= sender.maxRetries {
+			metrics.WebhookDeliveries.WithLabelValues("failed").Inc()
+			return err
+		}
+
+		metrics.WebhookDeliveries.WithLabelValues("retried").Inc()
+		log.Debug().Err(err).Str("webhookID", webhook.ID).Int("attempt", attempt+1).Msg("Retrying webhook delivery")
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// send method makes one attempt to deliver the payload. The first return
+// value tells if the delivery can be retried.
+func (sender *Sender) send(ctx context.Context, webhook *Webhook, deliveryID string, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	request.Header.Set(DeliveryHeader, deliveryID)
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+	return retry, fmt.Errorf("webhook endpoint responded with HTTP code %d", response.StatusCode)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+var (
+	// ErrInsecureEndpoint is returned when URL of webhook endpoint does
+	// not use https scheme
+	ErrInsecureEndpoint = errors.New("absolute https URL expected")
+	// ErrForbiddenAddress is returned when webhook endpoint is not
+	// reachable on public address, so notifications can't be used to
+	// reach internal services
+	ErrForbiddenAddress = errors.New("webhook endpoint address is not public")
+
+	// sharedAddressSpace is the range of carrier-grade NAT addresses
+	// (RFC 6598) that are not reachable from the public internet
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+// CheckEndpoint function validates URL of webhook endpoint. The URL must use
+// https scheme and the host must not be (or resolve to) loopback, private,
+// carrier-grade NAT, link-local or unspecified address. A host name that can't be resolved
+// now is accepted, because the address is checked again each time the
+// notification is delivered. Both checks are skipped when insecure
+// endpoints are allowed in configuration.
+func CheckEndpoint(ctx context.Context, conf Configuration, rawURL string) error {
+	endpoint, err := url.Parse(rawURL)
+	if err != nil || endpoint.Host == "" {
+		return ErrInsecureEndpoint
+	}
+	if conf.AllowInsecureEndpoints {
+		if endpoint.Scheme != "https" && endpoint.Scheme != "http" {
+			return ErrInsecureEndpoint
+		}
+		return nil
+	}
+	if endpoint.Scheme != "https" {
+		return ErrInsecureEndpoint
+	}
+
+	host := endpoint.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		return checkAddress(ip)
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, address := range addresses {
+		if err := checkAddress(address.IP); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAddress function checks that the IP address can be used by webhook
+// endpoint
+func checkAddress(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
+
+// checkDialedAddress function is used as control function of the dialer,
+// so the address is checked after the host name is resolved and just before
+// the connection is made. This way the check can't be bypassed by DNS
+// record that changes after the registration.
+func checkDialedAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return checkAddress(ip)
+}
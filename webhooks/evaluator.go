@@ -0,0 +1,210 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// defaultInterval is used when the evaluation interval is not configured
+	defaultInterval = 5 * time.Minute
+	// leaseMarginDivisor sets the part of the lease that is left for
+	// storing hits after notifications are sent
+	leaseMarginDivisor = 10
+)
+
+// deliveryNamespace is the namespace of name-based UUIDs used as delivery IDs
+var deliveryNamespace = uuid.MustParse("6b0f5c1e-3a52-4d8e-9f0a-2c7d1e4b8a90")
+
+// HitsSource returns recommendations currently hitting clusters of the
+// organization. Acked recommendations and recommendations disabled for the
+// cluster must not be returned.
+type HitsSource func(ctx context.Context, orgID types.OrgID) ([]Hit, error)
+
+// Evaluator periodically compares hits of organizations with registered
+// webhooks with the previous evaluation and notifies webhooks about new hits
+type Evaluator struct {
+	store    Store
+	source   HitsSource
+	sender   *Sender
+	interval time.Duration
+}
+
+// NewEvaluator function constructs the evaluator
+func NewEvaluator(conf Configuration, store Store, source HitsSource) *Evaluator {
+	interval := conf.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Evaluator{
+		store:    store,
+		source:   source,
+		sender:   NewSender(conf),
+		interval: interval,
+	}
+}
+
+// Run method evaluates all organizations periodically until the stop
+// channel is closed
+func (evaluator *Evaluator) Run(stopChannel <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopChannel
+		cancel()
+	}()
+
+	ticker := time.NewTicker(evaluator.interval)
+	defer ticker.Stop()
+	log.Info().Msgf("Evaluating webhooks each %v", evaluator.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Webhooks evaluation loop stopped")
+			return
+		case <-ticker.C:
+			evaluator.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate method evaluates all organizations with registered webhooks.
+// Failure of one organization does not stop evaluation of others.
+func (evaluator *Evaluator) Evaluate(ctx context.Context) {
+	orgIDs, err := evaluator.store.Organizations()
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to read organizations with webhooks")
+		return
+	}
+
+	for _, orgID := range orgIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := evaluator.evaluateOrganization(ctx, orgID); err != nil {
+			log.Error().Err(err).Uint32("orgID", uint32(orgID)).Msg("Unable to evaluate webhooks of organization")
+		}
+	}
+}
+
+// evaluateOrganization method notifies webhooks of the organization about
+// hits that were not found by the previous evaluation. The first evaluation
+// only records current hits, so webhooks are not flooded with recommendations
+// that were hitting the clusters before the webhook was registered.
+func (evaluator *Evaluator) evaluateOrganization(ctx context.Context, orgID types.OrgID) error {
+	// the lease is held at most for one interval, so evaluation that can't
+	// release it (for example because the replica was killed) is not
+	// blocking evaluations for longer than that
+	leased, err := evaluator.store.AcquireLease(orgID, evaluator.interval)
+	if err != nil {
+		return err
+	}
+	if !leased {
+		log.Debug().Uint32("orgID", uint32(orgID)).Msg("Organization is evaluated by another instance")
+		return nil
+	}
+	defer func() {
+		if err := evaluator.store.ReleaseLease(orgID); err != nil {
+			log.Error().Err(err).Uint32("orgID", uint32(orgID)).Msg("Unable to release webhooks evaluation lease")
+		}
+	}()
+
+	// deliveries are retried one after another, so the evaluation is
+	// bounded to end before the lease expires, otherwise another instance
+	// could evaluate the organization concurrently and notify the same hits
+	ctx, cancel := context.WithTimeout(ctx, evaluator.interval-evaluator.interval/leaseMarginDivisor)
+	defer cancel()
+
+	webhooks, err := evaluator.store.List(orgID)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	hits, err := evaluator.source(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := evaluator.store.ReadHits(orgID)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil {
+		evaluator.notify(ctx, orgID, webhooks, newHits(previous, hits))
+	}
+
+	return evaluator.store.WriteHits(orgID, hitsStateFromHits(hits))
+}
+
+// notify method sends new hits to all webhooks with matching filter.
+// Notifications that can't be delivered even after retries are dropped.
+func (evaluator *Evaluator) notify(ctx context.Context, orgID types.OrgID, webhooks []Webhook, hits []Hit) {
+	if len(hits) == 0 {
+		return
+	}
+
+	for i := range webhooks {
+		webhook := &webhooks[i]
+
+		matching := make([]Hit, 0)
+		for j := range hits {
+			if webhook.Filter.Matches(&hits[j]) {
+				matching = append(matching, hits[j])
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+
+		payload := Payload{
+			Event:      NewHitsEvent,
+			DeliveryID: deliveryID(orgID, webhook.ID, matching),
+			WebhookID:  webhook.ID,
+			OrgID:      orgID,
+			Timestamp:  time.Now().UTC(),
+			Hits:       matching,
+		}
+		if err := evaluator.sender.Send(ctx, webhook, &payload); err != nil {
+			log.Error().Err(err).Uint32("orgID", uint32(orgID)).Str("webhookID", webhook.ID).Msg("Unable to deliver webhook notification")
+		}
+	}
+}
+
+// deliveryID function derives ID of the notification from the organization,
+// the webhook and the notified hits, so the same notification sent by
+// different instances can be recognized by the receiver
+func deliveryID(orgID types.OrgID, webhookID string, hits []Hit) string {
+	keys := make([]string, len(hits))
+	for i := range hits {
+		keys[i] = fmt.Sprintf("%s|%s", hits[i].ClusterID, hits[i].RuleID)
+	}
+	slices.Sort(keys)
+
+	name := fmt.Sprintf("%d|%s|%s", orgID, webhookID, strings.Join(keys, ","))
+	return uuid.NewSHA1(deliveryNamespace, []byte(name)).String()
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
+)
+
+const testSecret = "top secret"
+
+// webhookReceiver records notifications received by the test webhook
+// endpoint. The first failures requests are answered with given status code.
+type webhookReceiver struct {
+	mutex       sync.Mutex
+	failures    int
+	failureCode int
+	attempts    int
+	payloads    []webhooks.Payload
+	deliveryIDs []string
+}
+
+func (receiver *webhookReceiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.attempts++
+	if receiver.attempts <= receiver.failures {
+		writer.WriteHeader(receiver.failureCode)
+		return
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil || request.Header.Get(webhooks.SignatureHeader) != webhooks.Sign(testSecret, body) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload webhooks.Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	receiver.payloads = append(receiver.payloads, payload)
+	receiver.deliveryIDs = append(receiver.deliveryIDs, request.Header.Get(webhooks.DeliveryHeader))
+}
+
+// hitsSource returns source returning given hits for each evaluation
+func hitsSource(evaluations ...[]webhooks.Hit) webhooks.HitsSource {
+	evaluation := 0
+	return func(_ context.Context, orgID types.OrgID) ([]webhooks.Hit, error) {
+		if orgID != testOrgID || evaluation >= len(evaluations) {
+			return nil, nil
+		}
+		evaluation++
+		return evaluations[evaluation-1], nil
+	}
+}
+
+var (
+	criticalHit = webhooks.Hit{ClusterID: testCluster, RuleID: testRule1, TotalRisk: 4}
+	moderateHit = webhooks.Hit{ClusterID: testCluster, RuleID: testRule2, TotalRisk: 2}
+	// test endpoints listen on loopback, so insecure endpoints are allowed
+	testConfiguration = webhooks.Configuration{RetryBackoff: time.Millisecond, MaxRetries: 2, AllowInsecureEndpoints: true}
+)
+
+// registerTestWebhook registers webhook for the test endpoint
+func registerTestWebhook(t *testing.T, store webhooks.Store, url string) {
+	helpers.FailOnError(t, store.Add(testOrgID, &webhooks.Webhook{
+		ID:     "webhook",
+		URL:    url,
+		Secret: testSecret,
+		Filter: webhooks.Filter{TotalRiskMin: webhooks.DefaultTotalRiskMin},
+	}))
+}
+
+// TestEvaluatorNotifiesNewHits checks that only new hits matching the filter
+// are notified and that the first evaluation only records current hits
+func TestEvaluatorNotifiesNewHits(t *testing.T) {
+	receiver := &webhookReceiver{}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	store := webhooks.NewMemoryStore()
+	registerTestWebhook(t, store, endpoint.URL)
+
+	otherCriticalHit := webhooks.Hit{ClusterID: "other", RuleID: testRule1, TotalRisk: 4}
+	evaluator := webhooks.NewEvaluator(testConfiguration, store, hitsSource(
+		[]webhooks.Hit{criticalHit},
+		[]webhooks.Hit{criticalHit, moderateHit},
+		[]webhooks.Hit{criticalHit, moderateHit, otherCriticalHit},
+	))
+
+	for range 3 {
+		evaluator.Evaluate(context.Background())
+	}
+
+	assert.Len(t, receiver.payloads, 1)
+	payload := receiver.payloads[0]
+	assert.Equal(t, webhooks.NewHitsEvent, payload.Event)
+	assert.Equal(t, "webhook", payload.WebhookID)
+	assert.Equal(t, types.OrgID(testOrgID), payload.OrgID)
+	assert.Equal(t, []webhooks.Hit{otherCriticalHit}, payload.Hits)
+	assert.Equal(t, payload.DeliveryID, receiver.deliveryIDs[0])
+
+	hits, err := store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.RuleID{testRule1, testRule2}, hits[testCluster])
+}
+
+// TestEvaluatorRetriesDelivery checks that failed deliveries are retried
+// with the same delivery ID
+func TestEvaluatorRetriesDelivery(t *testing.T) {
+	receiver := &webhookReceiver{failures: 2, failureCode: http.StatusServiceUnavailable}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	store := webhooks.NewMemoryStore()
+	registerTestWebhook(t, store, endpoint.URL)
+
+	evaluator := webhooks.NewEvaluator(testConfiguration, store, hitsSource(
+		[]webhooks.Hit{}, []webhooks.Hit{criticalHit},
+	))
+	evaluator.Evaluate(context.Background())
+	evaluator.Evaluate(context.Background())
+
+	assert.Equal(t, 3, receiver.attempts)
+	assert.Len(t, receiver.payloads, 1)
+}
+
+// TestEvaluatorBoundsDeliveryByLease checks that retried deliveries are
+// stopped before the evaluation lease expires and current hits are stored
+func TestEvaluatorBoundsDeliveryByLease(t *testing.T) {
+	receiver := &webhookReceiver{failures: 100, failureCode: http.StatusServiceUnavailable}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	store := webhooks.NewMemoryStore()
+	registerTestWebhook(t, store, endpoint.URL)
+
+	evaluator := webhooks.NewEvaluator(webhooks.Configuration{
+		Interval:               100 * time.Millisecond,
+		RetryBackoff:           time.Minute,
+		MaxRetries:             3,
+		AllowInsecureEndpoints: true,
+	}, store, hitsSource([]webhooks.Hit{}, []webhooks.Hit{criticalHit}))
+	evaluator.Evaluate(context.Background())
+
+	start := time.Now()
+	evaluator.Evaluate(context.Background())
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 1, receiver.attempts)
+
+	hits, err := store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RuleID{testRule1}, hits[testCluster])
+}
+
+// TestSenderDoesNotRetryClientErrors checks that deliveries rejected by the
+// webhook endpoint are not retried
+func TestSenderDoesNotRetryClientErrors(t *testing.T) {
+	receiver := &webhookReceiver{failures: 1, failureCode: http.StatusGone}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	sender := webhooks.NewSender(testConfiguration)
+	err := sender.Send(context.Background(), &webhooks.Webhook{URL: endpoint.URL, Secret: testSecret}, &webhooks.Payload{})
+	assert.EqualError(t, err, "webhook endpoint responded with HTTP code 410")
+	assert.Equal(t, 1, receiver.attempts)
+}
+
+// TestEvaluatorSkipsLeasedOrganization checks that organization evaluated by
+// another instance is skipped
+func TestEvaluatorSkipsLeasedOrganization(t *testing.T) {
+	store := webhooks.NewMemoryStore()
+	registerTestWebhook(t, store, "https://example.com")
+
+	leased, err := store.AcquireLease(testOrgID, time.Minute)
+	helpers.FailOnError(t, err)
+	assert.True(t, leased)
+
+	evaluator := webhooks.NewEvaluator(testConfiguration, store, hitsSource([]webhooks.Hit{criticalHit}))
+	evaluator.Evaluate(context.Background())
+
+	hits, err := store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Nil(t, hits)
+
+	helpers.FailOnError(t, store.ReleaseLease(testOrgID))
+	evaluator.Evaluate(context.Background())
+
+	hits, err = store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.RuleID{testRule1}, hits[testCluster])
+}
+
+// TestEvaluatorDeliveryIDIsDeterministic checks that the same notification
+// sent by different instances has the same delivery ID
+func TestEvaluatorDeliveryIDIsDeterministic(t *testing.T) {
+	receiver := &webhookReceiver{}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	otherCriticalHit := webhooks.Hit{ClusterID: "other", RuleID: testRule1, TotalRisk: 4}
+	for _, hits := range [][]webhooks.Hit{
+		{criticalHit, otherCriticalHit},
+		{otherCriticalHit, criticalHit},
+	} {
+		store := webhooks.NewMemoryStore()
+		registerTestWebhook(t, store, endpoint.URL)
+
+		evaluator := webhooks.NewEvaluator(testConfiguration, store, hitsSource([]webhooks.Hit{}, hits))
+		evaluator.Evaluate(context.Background())
+		evaluator.Evaluate(context.Background())
+	}
+
+	assert.Len(t, receiver.deliveryIDs, 2)
+	assert.Equal(t, receiver.deliveryIDs[0], receiver.deliveryIDs[1])
+}
+
+// TestSenderRefusesPrivateAddresses checks that notifications are not sent
+// to endpoints on addresses that are not public
+func TestSenderRefusesPrivateAddresses(t *testing.T) {
+	receiver := &webhookReceiver{}
+	endpoint := httptest.NewServer(receiver)
+	defer endpoint.Close()
+
+	sender := webhooks.NewSender(webhooks.Configuration{})
+	err := sender.Send(context.Background(), &webhooks.Webhook{URL: endpoint.URL, Secret: testSecret}, &webhooks.Payload{})
+	assert.ErrorIs(t, err, webhooks.ErrForbiddenAddress)
+	assert.Equal(t, 0, receiver.attempts)
+}
+
+// TestSenderDoesNotFollowRedirects checks that the webhook endpoint can't
+// redirect notifications to other services
+func TestSenderDoesNotFollowRedirects(t *testing.T) {
+	receiver := &webhookReceiver{}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+
+	endpoint := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer endpoint.Close()
+
+	sender := webhooks.NewSender(testConfiguration)
+	err := sender.Send(context.Background(), &webhooks.Webhook{URL: endpoint.URL, Secret: testSecret}, &webhooks.Payload{})
+	assert.EqualError(t, err, "webhook endpoint responded with HTTP code 307")
+	assert.Equal(t, 0, receiver.attempts)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+// MemoryStore is a Store implementation that keeps webhooks in memory of the
+// service instance
+type MemoryStore struct {
+	mutex    sync.RWMutex
+	webhooks map[types.OrgID][]Webhook
+	hits     map[types.OrgID]HitsState
+	leases   map[types.OrgID]time.Time
+}
+
+// NewMemoryStore function constructs new in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		webhooks: make(map[types.OrgID][]Webhook),
+		hits:     make(map[types.OrgID]HitsState),
+		leases:   make(map[types.OrgID]time.Time),
+	}
+}
+
+// Add method registers new webhook for the organization
+func (store *MemoryStore) Add(orgID types.OrgID, webhook *Webhook) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.webhooks[orgID] = append(store.webhooks[orgID], *webhook)
+	return nil
+}
+
+// List method returns webhooks registered by the organization
+func (store *MemoryStore) List(orgID types.OrgID) ([]Webhook, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return slices.Clone(store.webhooks[orgID]), nil
+}
+
+// Delete method removes webhook of the organization. Last seen hits are
+// forgotten together with the last webhook.
+func (store *MemoryStore) Delete(orgID types.OrgID, webhookID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	index := slices.IndexFunc(store.webhooks[orgID], func(webhook Webhook) bool {
+		return webhook.ID == webhookID
+	})
+	if index < 0 {
+		return ErrWebhookNotFound
+	}
+
+	store.webhooks[orgID] = slices.Delete(store.webhooks[orgID], index, index+1)
+	if len(store.webhooks[orgID]) == 0 {
+		delete(store.webhooks, orgID)
+		delete(store.hits, orgID)
+	}
+	return nil
+}
+
+// Organizations method returns organizations with at least one webhook
+func (store *MemoryStore) Organizations() ([]types.OrgID, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	orgIDs := make([]types.OrgID, 0, len(store.webhooks))
+	for orgID := range store.webhooks {
+		orgIDs = append(orgIDs, orgID)
+	}
+	return orgIDs, nil
+}
+
+// ReadHits method returns hits found by the last evaluation
+func (store *MemoryStore) ReadHits(orgID types.OrgID) (HitsState, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.hits[orgID], nil
+}
+
+// WriteHits method stores hits found by the evaluation
+func (store *MemoryStore) WriteHits(orgID types.OrgID, hits HitsState) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.hits[orgID] = hits
+	return nil
+}
+
+// AcquireLease method acquires the lease for evaluation of the organization
+// when it is not held or when it has expired
+func (store *MemoryStore) AcquireLease(orgID types.OrgID, ttl time.Duration) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	if expiresAt, found := store.leases[orgID]; found && now.Before(expiresAt) {
+		return false, nil
+	}
+	store.leases[orgID] = now.Add(ttl)
+	return true, nil
+}
+
+// ReleaseLease method releases the lease for evaluation of the organization
+func (store *MemoryStore) ReleaseLease(orgID types.OrgID) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.leases, orgID)
+	return nil
+}
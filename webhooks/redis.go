@@ -0,0 +1,177 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// RedisWebhooksKey is a key pattern for hash with webhooks registered
+	// by one organization. Organization ID is used as a parameter. Webhook
+	// IDs are used as fields of the hash.
+	RedisWebhooksKey = "smart-proxy:webhooks:%v"
+	// RedisOrganizationsKey is a key of set with organizations that have
+	// at least one webhook
+	RedisOrganizationsKey = "smart-proxy:webhooks:organizations"
+	// RedisHitsKey is a key pattern for hits found by the last evaluation
+	// of one organization
+	RedisHitsKey = "smart-proxy:webhooks:%v:hits"
+	// RedisLeaseKey is a key pattern for lease that prevents concurrent
+	// evaluations of one organization by multiple replicas
+	RedisLeaseKey = "smart-proxy:webhooks:%v:lease"
+)
+
+// RedisStore is a Store implementation that keeps webhooks in Redis
+type RedisStore struct {
+	connection redisV9.Cmdable
+}
+
+// NewRedisStore function constructs new Redis store
+func NewRedisStore(connection redisV9.Cmdable) *RedisStore {
+	return &RedisStore{
+		connection: connection,
+	}
+}
+
+// Add method registers new webhook for the organization
+func (store *RedisStore) Add(orgID types.OrgID, webhook *Webhook) error {
+	ctx := context.Background()
+
+	value, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.HSet(ctx, fmt.Sprintf(RedisWebhooksKey, orgID), webhook.ID, value)
+		pipe.SAdd(ctx, RedisOrganizationsKey, uint32(orgID))
+		return nil
+	})
+	return err
+}
+
+// List method returns webhooks registered by the organization
+func (store *RedisStore) List(orgID types.OrgID) ([]Webhook, error) {
+	values, err := store.connection.HGetAll(context.Background(), fmt.Sprintf(RedisWebhooksKey, orgID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]Webhook, 0, len(values))
+	for webhookID, value := range values {
+		var webhook Webhook
+		if err := json.Unmarshal([]byte(value), &webhook); err != nil {
+			log.Error().Err(err).Str("webhookID", webhookID).Msg("Unable to parse webhook stored in Redis")
+			continue
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+// Delete method removes webhook of the organization. Last seen hits are
+// forgotten together with the last webhook.
+func (store *RedisStore) Delete(orgID types.OrgID, webhookID string) error {
+	ctx := context.Background()
+	key := fmt.Sprintf(RedisWebhooksKey, orgID)
+
+	deleted, err := store.connection.HDel(ctx, key, webhookID).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrWebhookNotFound
+	}
+
+	remaining, err := store.connection.HLen(ctx, key).Result()
+	if err != nil || remaining > 0 {
+		return err
+	}
+
+	_, err = store.connection.TxPipelined(ctx, func(pipe redisV9.Pipeliner) error {
+		pipe.SRem(ctx, RedisOrganizationsKey, uint32(orgID))
+		pipe.Del(ctx, fmt.Sprintf(RedisHitsKey, orgID))
+		return nil
+	})
+	return err
+}
+
+// Organizations method returns organizations with at least one webhook
+func (store *RedisStore) Organizations() ([]types.OrgID, error) {
+	members, err := store.connection.SMembers(context.Background(), RedisOrganizationsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	orgIDs := make([]types.OrgID, 0, len(members))
+	for _, member := range members {
+		orgID, err := strconv.ParseUint(member, 10, 32)
+		if err != nil {
+			log.Error().Err(err).Str("member", member).Msg("Unable to parse organization ID stored in Redis")
+			continue
+		}
+		orgIDs = append(orgIDs, types.OrgID(orgID))
+	}
+	return orgIDs, nil
+}
+
+// ReadHits method returns hits found by the last evaluation
+func (store *RedisStore) ReadHits(orgID types.OrgID) (HitsState, error) {
+	value, err := store.connection.Get(context.Background(), fmt.Sprintf(RedisHitsKey, orgID)).Bytes()
+	if errors.Is(err, redisV9.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hits HitsState
+	if err := json.Unmarshal(value, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// WriteHits method stores hits found by the evaluation
+func (store *RedisStore) WriteHits(orgID types.OrgID, hits HitsState) error {
+	value, err := json.Marshal(hits)
+	if err != nil {
+		return err
+	}
+
+	return store.connection.Set(context.Background(), fmt.Sprintf(RedisHitsKey, orgID), value, 0).Err()
+}
+
+// AcquireLease method acquires the lease for evaluation of the organization.
+// The lease is stored in Redis, so it is shared by all replicas.
+func (store *RedisStore) AcquireLease(orgID types.OrgID, ttl time.Duration) (bool, error) {
+	return store.connection.SetNX(context.Background(), fmt.Sprintf(RedisLeaseKey, orgID), 1, ttl).Result()
+}
+
+// ReleaseLease method releases the lease for evaluation of the organization
+func (store *RedisStore) ReleaseLease(orgID types.OrgID) error {
+	return store.connection.Del(context.Background(), fmt.Sprintf(RedisLeaseKey, orgID)).Err()
+}
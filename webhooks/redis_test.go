@@ -0,0 +1,139 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
+)
+
+var (
+	testWebhooksKey = fmt.Sprintf(webhooks.RedisWebhooksKey, testOrgID)
+	testHitsKey     = fmt.Sprintf(webhooks.RedisHitsKey, testOrgID)
+)
+
+func TestRedisStoreAddAndList(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := webhooks.NewRedisStore(client)
+
+	webhook := webhooks.Webhook{ID: "first", URL: "https://example.com", Secret: "secret"}
+	value, err := json.Marshal(webhook)
+	helpers.FailOnError(t, err)
+
+	server.ExpectTxPipeline()
+	server.ExpectHSet(testWebhooksKey, webhook.ID, value).SetVal(1)
+	server.ExpectSAdd(webhooks.RedisOrganizationsKey, uint32(testOrgID)).SetVal(1)
+	server.ExpectTxPipelineExec()
+	assert.NoError(t, store.Add(testOrgID, &webhook))
+
+	server.ExpectHGetAll(testWebhooksKey).SetVal(map[string]string{
+		webhook.ID: string(value),
+		"broken":   "not a webhook",
+	})
+	registered, err := store.List(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, []webhooks.Webhook{webhook}, registered)
+
+	server.ExpectSMembers(webhooks.RedisOrganizationsKey).SetVal([]string{"42", "not a number"})
+	orgIDs, err := store.Organizations()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.OrgID{testOrgID}, orgIDs)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisStoreDelete(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := webhooks.NewRedisStore(client)
+
+	server.ExpectHDel(testWebhooksKey, "missing").SetVal(0)
+	assert.ErrorIs(t, store.Delete(testOrgID, "missing"), webhooks.ErrWebhookNotFound)
+
+	server.ExpectHDel(testWebhooksKey, "first").SetVal(1)
+	server.ExpectHLen(testWebhooksKey).SetVal(1)
+	assert.NoError(t, store.Delete(testOrgID, "first"))
+
+	server.ExpectHDel(testWebhooksKey, "second").SetVal(1)
+	server.ExpectHLen(testWebhooksKey).SetVal(0)
+	server.ExpectTxPipeline()
+	server.ExpectSRem(webhooks.RedisOrganizationsKey, uint32(testOrgID)).SetVal(1)
+	server.ExpectDel(testHitsKey).SetVal(1)
+	server.ExpectTxPipelineExec()
+	assert.NoError(t, store.Delete(testOrgID, "second"))
+
+	server.ExpectHDel(testWebhooksKey, "third").SetErr(errors.New("connection refused"))
+	assert.Error(t, store.Delete(testOrgID, "third"))
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisStoreHits(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := webhooks.NewRedisStore(client)
+
+	state := webhooks.HitsState{testCluster: {testRule1, testRule2}}
+	value, err := json.Marshal(state)
+	helpers.FailOnError(t, err)
+
+	server.ExpectGet(testHitsKey).RedisNil()
+	hits, err := store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Nil(t, hits)
+
+	server.ExpectSet(testHitsKey, value, 0).SetVal("OK")
+	assert.NoError(t, store.WriteHits(testOrgID, state))
+
+	server.ExpectGet(testHitsKey).SetVal(string(value))
+	hits, err = store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, state, hits)
+
+	server.ExpectGet(testHitsKey).SetErr(errors.New("connection refused"))
+	_, err = store.ReadHits(testOrgID)
+	assert.Error(t, err)
+
+	helpers.RedisExpectationsMet(t, server)
+}
+
+func TestRedisStoreLease(t *testing.T) {
+	client, server := redismock.NewClientMock()
+	store := webhooks.NewRedisStore(client)
+	leaseKey := fmt.Sprintf(webhooks.RedisLeaseKey, testOrgID)
+
+	server.ExpectSetNX(leaseKey, 1, time.Minute).SetVal(true)
+	leased, err := store.AcquireLease(testOrgID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+
+	server.ExpectSetNX(leaseKey, 1, time.Minute).SetVal(false)
+	leased, err = store.AcquireLease(testOrgID, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, leased)
+
+	server.ExpectDel(leaseKey).SetVal(1)
+	assert.NoError(t, store.ReleaseLease(testOrgID))
+
+	helpers.RedisExpectationsMet(t, server)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhooks contains implementation of webhook notifications. An
+// organization registers webhook endpoints with filters and the background
+// evaluator periodically compares recommendations hitting clusters of the
+// organization with the previous evaluation. New hits matching the filter of
+// the webhook are sent to the webhook endpoint in signed JSON payload.
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	redisV9 "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+)
+
+const (
+	// MemoryStorage selects webhooks stored in memory of the service
+	// instance
+	MemoryStorage = "memory"
+	// RedisStorage selects webhooks stored in Redis, shared by all
+	// instances
+	RedisStorage = "redis"
+
+	// MaxWebhooksPerOrganization is the maximum number of webhooks
+	// registered by one organization
+	MaxWebhooksPerOrganization = 10
+
+	// DefaultTotalRiskMin is used when the filter does not specify the
+	// minimal total risk, so only critical recommendations are notified
+	DefaultTotalRiskMin = 4
+)
+
+// ErrWebhookNotFound is returned when the webhook is not registered by the
+// organization
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Filter selects hits the webhook is notified about. All conditions must be
+// met.
+type Filter struct {
+	// TotalRiskMin is the minimal total risk of the recommendation
+	TotalRiskMin int `json:"total_risk_min"`
+	// Tags are required to be all assigned to the recommendation
+	Tags []string `json:"tags,omitempty"`
+	// RuleIDs limits notifications to given recommendations when not
+	// empty
+	RuleIDs []types.RuleID `json:"rule_ids,omitempty"`
+}
+
+// Webhook represents one webhook endpoint registered by an organization
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret is used to sign payloads, it is never returned to clients
+	Secret    string    `json:"secret,omitempty"`
+	Filter    Filter    `json:"filter"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Hit represents one recommendation hitting one cluster
+type Hit struct {
+	ClusterID   types.ClusterName `json:"cluster"`
+	RuleID      types.RuleID      `json:"rule_id"`
+	TotalRisk   int               `json:"total_risk"`
+	Tags        []string          `json:"tags"`
+	Description string            `json:"description"`
+}
+
+// HitsState contains recommendations hitting each cluster found by the last
+// evaluation
+type HitsState map[types.ClusterName][]types.RuleID
+
+// Store represents storage of registered webhooks and last seen hits
+type Store interface {
+	// Add registers new webhook for the organization
+	Add(orgID types.OrgID, webhook *Webhook) error
+	// List returns webhooks registered by the organization
+	List(orgID types.OrgID) ([]Webhook, error)
+	// Delete removes webhook of the organization. ErrWebhookNotFound is
+	// returned when the webhook does not exist.
+	Delete(orgID types.OrgID, webhookID string) error
+	// Organizations returns organizations with at least one webhook
+	Organizations() ([]types.OrgID, error)
+	// ReadHits returns hits found by the last evaluation. Nil is returned
+	// when the organization has not been evaluated yet.
+	ReadHits(orgID types.OrgID) (HitsState, error)
+	// WriteHits stores hits found by the evaluation
+	WriteHits(orgID types.OrgID, hits HitsState) error
+	// AcquireLease tries to acquire the lease for evaluation of the
+	// organization, so only one service instance evaluates it at the same
+	// time. False is returned when the lease is held by someone else. The
+	// lease expires after given time when it is not released.
+	AcquireLease(orgID types.OrgID, ttl time.Duration) (bool, error)
+	// ReleaseLease releases the lease for evaluation of the organization
+	ReleaseLease(orgID types.OrgID) error
+}
+
+// New function constructs the webhook store selected in configuration.
+// Redis connection is used only for the Redis storage. Nil is returned
+// when webhooks are disabled.
+func New(conf Configuration, connection redisV9.UniversalClient) (Store, error) {
+	if !conf.Enabled {
+		log.Info().Msg("Webhook notifications are disabled")
+		return nil, nil
+	}
+
+	switch conf.Storage {
+	case "", MemoryStorage:
+		log.Info().Msg("Using in-memory store for webhooks")
+		return NewMemoryStore(), nil
+	case RedisStorage:
+		if connection == nil {
+			return nil, services.ErrNoRedisConnection
+		}
+		log.Info().Msg("Using Redis store for webhooks")
+		return NewRedisStore(connection), nil
+	default:
+		return nil, fmt.Errorf("unknown webhooks storage '%s'", conf.Storage)
+	}
+}
+
+// Matches method checks if the hit meets all conditions of the filter
+func (filter *Filter) Matches(hit *Hit) bool {
+	if hit.TotalRisk < filter.TotalRiskMin {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !slices.Contains(hit.Tags, tag) {
+			return false
+		}
+	}
+
+	return len(filter.RuleIDs) == 0 || slices.Contains(filter.RuleIDs, hit.RuleID)
+}
+
+// hitsStateFromHits function constructs state stored for next evaluation
+func hitsStateFromHits(hits []Hit) HitsState {
+	state := make(HitsState)
+	for i := range hits {
+		state[hits[i].ClusterID] = append(state[hits[i].ClusterID], hits[i].RuleID)
+	}
+	return state
+}
+
+// newHits function returns hits that were not found by the previous
+// evaluation
+func newHits(previous HitsState, hits []Hit) []Hit {
+	found := make([]Hit, 0)
+	for i := range hits {
+		if !slices.Contains(previous[hits[i].ClusterID], hits[i].RuleID) {
+			found = append(found, hits[i])
+		}
+	}
+	return found
+}
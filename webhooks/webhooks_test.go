@@ -0,0 +1,182 @@
+// Copyright 2026 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-results-smart-proxy/services"
+	"github.com/RedHatInsights/insights-results-smart-proxy/tests/helpers"
+	"github.com/RedHatInsights/insights-results-smart-proxy/types"
+	"github.com/RedHatInsights/insights-results-smart-proxy/webhooks"
+)
+
+const (
+	testOrgID   = 42
+	testCluster = types.ClusterName("34c3ecc5-624a-49a5-bab8-4fdc5e51a266")
+	testRule1   = types.RuleID("ccx_rules_ocp.external.rules.rule1|ERROR_KEY1")
+	testRule2   = types.RuleID("ccx_rules_ocp.external.rules.rule2|ERROR_KEY2")
+)
+
+// TestNewStore checks that the storage selected in configuration is used
+func TestNewStore(t *testing.T) {
+	client, _ := helpers.GetMockRedis()
+
+	store, err := webhooks.New(webhooks.Configuration{}, client.Connection)
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = webhooks.New(webhooks.Configuration{Enabled: true}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &webhooks.MemoryStore{}, store)
+
+	store, err = webhooks.New(webhooks.Configuration{Enabled: true, Storage: webhooks.RedisStorage}, client.Connection)
+	assert.NoError(t, err)
+	assert.IsType(t, &webhooks.RedisStore{}, store)
+
+	_, err = webhooks.New(webhooks.Configuration{Enabled: true, Storage: webhooks.RedisStorage}, nil)
+	assert.ErrorIs(t, err, services.ErrNoRedisConnection)
+
+	_, err = webhooks.New(webhooks.Configuration{Enabled: true, Storage: "disk"}, client.Connection)
+	assert.EqualError(t, err, "unknown webhooks storage 'disk'")
+}
+
+// TestFilterMatches checks all conditions of the webhook filter
+func TestFilterMatches(t *testing.T) {
+	hit := webhooks.Hit{
+		ClusterID: testCluster,
+		RuleID:    testRule1,
+		TotalRisk: 3,
+		Tags:      []string{"security", "openshift"},
+	}
+
+	testCases := []struct {
+		name    string
+		filter  webhooks.Filter
+		matches bool
+	}{
+		{"total risk", webhooks.Filter{TotalRiskMin: 3}, true},
+		{"higher total risk", webhooks.Filter{TotalRiskMin: 4}, false},
+		{"all tags", webhooks.Filter{TotalRiskMin: 1, Tags: []string{"security", "openshift"}}, true},
+		{"missing tag", webhooks.Filter{TotalRiskMin: 1, Tags: []string{"security", "performance"}}, false},
+		{"rule ID", webhooks.Filter{TotalRiskMin: 1, RuleIDs: []types.RuleID{testRule2, testRule1}}, true},
+		{"other rule ID", webhooks.Filter{TotalRiskMin: 1, RuleIDs: []types.RuleID{testRule2}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.matches, tc.filter.Matches(&hit))
+		})
+	}
+}
+
+// TestMemoryStore checks registration and removal of webhooks and storage of
+// last seen hits
+func TestMemoryStore(t *testing.T) {
+	store := webhooks.NewMemoryStore()
+
+	hits, err := store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Nil(t, hits)
+
+	for _, id := range []string{"first", "second"} {
+		assert.NoError(t, store.Add(testOrgID, &webhooks.Webhook{ID: id}))
+	}
+	assert.NoError(t, store.WriteHits(testOrgID, webhooks.HitsState{testCluster: {testRule1}}))
+
+	registered, err := store.List(testOrgID)
+	assert.NoError(t, err)
+	assert.Len(t, registered, 2)
+
+	orgIDs, err := store.Organizations()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.OrgID{testOrgID}, orgIDs)
+
+	assert.ErrorIs(t, store.Delete(testOrgID, "third"), webhooks.ErrWebhookNotFound)
+	assert.NoError(t, store.Delete(testOrgID, "first"))
+
+	hits, err = store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Equal(t, webhooks.HitsState{testCluster: {testRule1}}, hits)
+
+	// last seen hits are forgotten together with the last webhook
+	assert.NoError(t, store.Delete(testOrgID, "second"))
+	hits, err = store.ReadHits(testOrgID)
+	assert.NoError(t, err)
+	assert.Nil(t, hits)
+
+	orgIDs, err = store.Organizations()
+	assert.NoError(t, err)
+	assert.Empty(t, orgIDs)
+}
+
+// TestMemoryStoreLease checks that the evaluation lease is acquired only once
+// until it is released or expires
+func TestMemoryStoreLease(t *testing.T) {
+	store := webhooks.NewMemoryStore()
+
+	leased, err := store.AcquireLease(testOrgID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+
+	leased, err = store.AcquireLease(testOrgID, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, leased)
+
+	assert.NoError(t, store.ReleaseLease(testOrgID))
+	leased, err = store.AcquireLease(testOrgID, -time.Second)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+
+	// expired lease can be acquired again
+	leased, err = store.AcquireLease(testOrgID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, leased)
+}
+
+// TestCheckEndpoint checks that only https endpoints on public addresses are
+// accepted unless insecure endpoints are allowed
+func TestCheckEndpoint(t *testing.T) {
+	ctx := context.Background()
+	secure := webhooks.Configuration{}
+	insecure := webhooks.Configuration{AllowInsecureEndpoints: true}
+
+	assert.NoError(t, webhooks.CheckEndpoint(ctx, secure, "https://203.0.113.10/hook"))
+	assert.NoError(t, webhooks.CheckEndpoint(ctx, secure, "https://100.128.0.1/hook"))
+	assert.NoError(t, webhooks.CheckEndpoint(ctx, insecure, "http://127.0.0.1:8080/hook"))
+
+	for _, endpoint := range []string{"example.com/hook", "ftp://example.com/hook", "http://example.com/hook"} {
+		assert.ErrorIs(t, webhooks.CheckEndpoint(ctx, secure, endpoint), webhooks.ErrInsecureEndpoint, endpoint)
+	}
+	assert.ErrorIs(t, webhooks.CheckEndpoint(ctx, insecure, "ftp://example.com/hook"), webhooks.ErrInsecureEndpoint)
+
+	for _, endpoint := range []string{
+		"https://127.0.0.1/hook",
+		"https://[::1]/hook",
+		"https://10.1.2.3/hook",
+		"https://192.168.0.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[fe80::1]/hook",
+		"https://0.0.0.0/hook",
+		"https://100.64.0.1/hook",
+		"https://100.127.255.254/hook",
+	} {
+		assert.ErrorIs(t, webhooks.CheckEndpoint(ctx, secure, endpoint), webhooks.ErrForbiddenAddress, endpoint)
+	}
+}